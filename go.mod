@@ -3,7 +3,10 @@ module github.com/deploymenttheory/jamfpro-mcp-server
 go 1.24.3
 
 require (
+	github.com/deploymenttheory/go-api-http-client v0.4.1
+	github.com/deploymenttheory/go-api-http-client-integrations v0.0.13
 	github.com/deploymenttheory/go-api-sdk-jamfpro v1.33.0
+	github.com/fsnotify/fsnotify v1.8.0
 	github.com/spf13/cobra v1.9.1
 	github.com/spf13/viper v1.20.1
 	github.com/stretchr/testify v1.10.0
@@ -33,9 +36,6 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/sts v1.33.19 // indirect
 	github.com/aws/smithy-go v1.22.2 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/deploymenttheory/go-api-http-client v0.4.1 // indirect
-	github.com/deploymenttheory/go-api-http-client-integrations v0.0.13 // indirect
-	github.com/fsnotify/fsnotify v1.8.0 // indirect
 	github.com/go-viper/mapstructure/v2 v2.2.1 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
 	github.com/google/uuid v1.6.0 // indirect
@@ -0,0 +1,165 @@
+// Package audit records every tool invocation to append-only sinks so that changes made
+// through the MCP server can be traced back to the client session that requested them.
+package audit
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Outcomes recorded for a tool invocation
+const (
+	OutcomeSuccess              = "success"
+	OutcomeError                = "error"
+	OutcomeCancelled            = "cancelled"
+	OutcomeConfirmationRequired = "confirmation_required"
+	OutcomeDenied               = "denied"
+)
+
+// redactedValue replaces the value of sensitive arguments
+const redactedValue = "[REDACTED]"
+
+// Client identifies the MCP client that made a call, as reported during initialize
+type Client struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// Record is a single audited tool invocation. A read of a resource is recorded under the tool
+// that returns the same object, with the resource URI.
+type Record struct {
+	Time          time.Time              `json:"time"`
+	SessionID     string                 `json:"session_id,omitempty"`
+	Client        Client                 `json:"client"`
+	AccessProfile string                 `json:"access_profile,omitempty"`
+	Instance      string                 `json:"instance,omitempty"`
+	Toolset       string                 `json:"toolset,omitempty"`
+	Tool          string                 `json:"tool"`
+	Resource      string                 `json:"resource,omitempty"`
+	Access        string                 `json:"access,omitempty"`
+	Arguments     map[string]interface{} `json:"arguments,omitempty"`
+	Targets       map[string]interface{} `json:"targets,omitempty"`
+	Outcome       string                 `json:"outcome"`
+	Error         string                 `json:"error,omitempty"`
+	DurationMS    int64                  `json:"duration_ms"`
+}
+
+// Sink is a destination for audit records
+type Sink interface {
+	Write(record Record) error
+	Close() error
+}
+
+// Logger fans audit records out to one or more sinks
+type Logger struct {
+	mu    sync.Mutex
+	sinks []Sink
+}
+
+// NewLogger creates a logger that writes to the given sinks
+func NewLogger(sinks ...Sink) *Logger {
+	return &Logger{sinks: sinks}
+}
+
+// Log writes a record to every sink, returning the errors of any sinks that failed
+func (l *Logger) Log(record Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var errs []error
+	for _, sink := range l.sinks {
+		if err := sink.Write(record); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes every sink
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var errs []error
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sensitiveArgumentFragments mark argument names whose values must never reach the audit log
+var sensitiveArgumentFragments = []string{"password", "secret", "token", "passphrase", "credential", "private_key"}
+
+// RedactArguments returns a copy of arguments with the values of sensitive arguments replaced,
+// descending into nested objects and arrays
+func RedactArguments(arguments map[string]interface{}) map[string]interface{} {
+	if arguments == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(arguments))
+	for key, value := range arguments {
+		if isSensitiveArgument(key) {
+			redacted[key] = redactedValue
+			continue
+		}
+		redacted[key] = redactValue(value)
+	}
+	return redacted
+}
+
+// redactValue redacts sensitive arguments nested inside value
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return RedactArguments(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = redactValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+// isSensitiveArgument reports whether an argument name looks like it holds a secret
+func isSensitiveArgument(name string) bool {
+	name = strings.ToLower(name)
+	for _, fragment := range sensitiveArgumentFragments {
+		if strings.Contains(name, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// targetArguments are argument names that identify the Jamf Pro object a tool acts on
+var targetArguments = map[string]bool{
+	"id":            true,
+	"ids":           true,
+	"name":          true,
+	"serial_number": true,
+	"udid":          true,
+}
+
+// TargetsFromArguments extracts the arguments that identify the objects a call acts on, such
+// as id, serial_number and any argument ending in _id
+func TargetsFromArguments(arguments map[string]interface{}) map[string]interface{} {
+	var targets map[string]interface{}
+	for key, value := range arguments {
+		if !targetArguments[key] && !strings.HasSuffix(key, "_id") {
+			continue
+		}
+		if targets == nil {
+			targets = make(map[string]interface{})
+		}
+		targets[key] = value
+	}
+	return targets
+}
@@ -0,0 +1,153 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readRecords decodes every JSON line in path
+func readRecords(t *testing.T, path string) []Record {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+// TestRedactArguments tests that secrets are removed at any depth without touching the input
+func TestRedactArguments(t *testing.T) {
+	arguments := map[string]interface{}{
+		"id":                 "7",
+		"confirmation_token": "abc123",
+		"account": map[string]interface{}{
+			"username": "jdoe",
+			"Password": "hunter2",
+		},
+		"items": []interface{}{
+			map[string]interface{}{"client_secret": "s3cr3t"},
+		},
+	}
+
+	redacted := RedactArguments(arguments)
+
+	assert.Equal(t, "7", redacted["id"])
+	assert.Equal(t, redactedValue, redacted["confirmation_token"])
+	assert.Equal(t, "jdoe", redacted["account"].(map[string]interface{})["username"])
+	assert.Equal(t, redactedValue, redacted["account"].(map[string]interface{})["Password"])
+	assert.Equal(t, redactedValue, redacted["items"].([]interface{})[0].(map[string]interface{})["client_secret"])
+
+	// The caller's arguments are left as they were
+	assert.Equal(t, "hunter2", arguments["account"].(map[string]interface{})["Password"])
+}
+
+// TestTargetsFromArguments tests that only identifying arguments are treated as targets
+func TestTargetsFromArguments(t *testing.T) {
+	targets := TargetsFromArguments(map[string]interface{}{
+		"id":            "7",
+		"serial_number": "C02XYZ123",
+		"policy_id":     "12",
+		"page_size":     100,
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"id":            "7",
+		"serial_number": "C02XYZ123",
+		"policy_id":     "12",
+	}, targets)
+
+	assert.Nil(t, TargetsFromArguments(map[string]interface{}{"filter": "x"}))
+}
+
+// TestFileSinkAppendsAndRotates tests that records are appended as JSON lines and rotated by size
+func TestFileSinkAppendsAndRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	// Existing content is kept
+	require.NoError(t, os.WriteFile(path, []byte(`{"tool":"earlier","outcome":"success"}`+"\n"), 0600))
+
+	sink, err := NewFileSink(path, 400, 2)
+	require.NoError(t, err)
+
+	for i := 0; i < 6; i++ {
+		require.NoError(t, sink.Write(Record{
+			Time:      time.Now(),
+			SessionID: "session-1",
+			Tool:      "erase_computer",
+			Targets:   map[string]interface{}{"id": "7"},
+			Outcome:   OutcomeSuccess,
+		}))
+	}
+	require.NoError(t, sink.Close())
+
+	current := readRecords(t, path)
+	require.NotEmpty(t, current)
+	assert.Equal(t, "erase_computer", current[len(current)-1].Tool)
+
+	for _, backup := range []string{path + ".1", path + ".2"} {
+		info, err := os.Stat(backup)
+		require.NoError(t, err, backup)
+		assert.LessOrEqual(t, info.Size(), int64(400), backup)
+	}
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "only maxBackups rotated files are kept")
+
+	assert.Error(t, sink.Write(Record{Tool: "after_close"}))
+}
+
+// TestSyslogSink tests that records reach a local datagram socket with a syslog header
+func TestSyslogSink(t *testing.T) {
+	// Unix socket paths are limited in length, so avoid the long test temp directory
+	dir, err := os.MkdirTemp("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "log.sock")
+	listener, err := net.ListenPacket("unixgram", socket)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	sink, err := NewSyslogSink(socket)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Write(Record{
+		Time:    time.Now(),
+		Client:  Client{Name: "claude-desktop", Version: "1.0"},
+		Tool:    "erase_computer",
+		Outcome: OutcomeError,
+		Error:   "not found",
+	}))
+
+	require.NoError(t, listener.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 4096)
+	n, _, err := listener.ReadFrom(buf)
+	require.NoError(t, err)
+
+	message := string(buf[:n])
+	assert.True(t, strings.HasPrefix(message, "<84>"), message)
+	assert.Contains(t, message, syslogTag+"[")
+
+	var record Record
+	require.NoError(t, json.Unmarshal([]byte(message[strings.Index(message, "{"):]), &record))
+	assert.Equal(t, "erase_computer", record.Tool)
+	assert.Equal(t, "claude-desktop", record.Client.Name)
+}
@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// Syslog priority parts used for audit messages
+const (
+	syslogFacilityAuthPriv = 10
+	syslogSeverityWarning  = 4
+	syslogSeverityNotice   = 5
+	syslogSeverityInfo     = 6
+)
+
+// syslogTag identifies audit messages in the system log
+const syslogTag = "jamfpro-mcp-server"
+
+// SyslogSink sends each record as a JSON message to a local syslog datagram socket such as
+// /dev/log, using the authpriv facility
+type SyslogSink struct {
+	socket string
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogSink connects to the unix datagram socket at path
+func NewSyslogSink(path string) (*SyslogSink, error) {
+	sink := &SyslogSink{socket: path}
+
+	if err := sink.connect(); err != nil {
+		return nil, err
+	}
+
+	return sink, nil
+}
+
+// Write sends a record to the socket, reconnecting once if the syslog daemon was restarted
+func (s *SyslogSink) Write(record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+
+	message := fmt.Sprintf("<%d>%s %s[%d]: %s",
+		syslogFacilityAuthPriv*8+syslogSeverity(record),
+		record.Time.Format(time.Stamp),
+		syslogTag,
+		os.Getpid(),
+		data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil {
+		if _, err := s.conn.Write([]byte(message)); err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	if err := s.connect(); err != nil {
+		return err
+	}
+
+	if _, err := s.conn.Write([]byte(message)); err != nil {
+		return fmt.Errorf("failed to write to syslog socket %s: %w", s.socket, err)
+	}
+
+	return nil
+}
+
+// Close closes the socket
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// connect dials the syslog socket. Callers must hold mu once the sink is shared.
+func (s *SyslogSink) connect() error {
+	conn, err := net.Dial("unixgram", s.socket)
+	if err != nil {
+		return fmt.Errorf("failed to connect to syslog socket %s: %w", s.socket, err)
+	}
+
+	s.conn = conn
+	return nil
+}
+
+// syslogSeverity maps a record's outcome to a syslog severity
+func syslogSeverity(record Record) int {
+	switch record.Outcome {
+	case OutcomeError:
+		return syslogSeverityWarning
+	case OutcomeSuccess:
+		return syslogSeverityNotice
+	default:
+		return syslogSeverityInfo
+	}
+}
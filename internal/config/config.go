@@ -1,10 +1,13 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -15,8 +18,36 @@ type Config struct {
 	LogLevel           string   `mapstructure:"log_level"`
 	Toolsets           []string `mapstructure:"toolsets"`
 	DynamicToolsets    bool     `mapstructure:"dynamic_toolsets"`
+	ReadOnly           bool     `mapstructure:"read_only"`
+	AllowedTools       []string `mapstructure:"allowed_tools"`
+	DeniedTools        []string `mapstructure:"denied_tools"`
 	ExportTranslations bool     `mapstructure:"export_translations"`
 
+	// ListPageSize is the number of items per page of tools/list and resources/list; zero
+	// returns every item in one page
+	ListPageSize int `mapstructure:"list_page_size"`
+
+	// Destructive tool confirmation
+	RequireConfirmation    bool `mapstructure:"require_confirmation"`
+	ConfirmationTTLSeconds int  `mapstructure:"confirmation_ttl_seconds"`
+
+	// Audit logging of tool invocations
+	AuditLogFile       string `mapstructure:"audit_log_file"`
+	AuditLogMaxSizeMB  int    `mapstructure:"audit_log_max_size_mb"`
+	AuditLogMaxBackups int    `mapstructure:"audit_log_max_backups"`
+	AuditSyslogSocket  string `mapstructure:"audit_syslog_socket"`
+
+	// Transport configuration
+	Transport          string   `mapstructure:"transport"`
+	HTTPListenAddress  string   `mapstructure:"http_listen_address"`
+	HTTPEndpointPath   string   `mapstructure:"http_endpoint_path"`
+	HTTPAllowedOrigins []string `mapstructure:"http_allowed_origins"`
+
+	// HTTP sessions unused for HTTPSessionIdleSeconds are closed, and at most HTTPMaxSessions
+	// are open at once; zero disables either limit
+	HTTPSessionIdleSeconds int `mapstructure:"http_session_idle_seconds"`
+	HTTPMaxSessions        int `mapstructure:"http_max_sessions"`
+
 	// Jamf Pro configuration
 	JamfInstanceURL  string `mapstructure:"jamf_instance_url"`
 	JamfClientID     string `mapstructure:"jamf_client_id"`
@@ -25,6 +56,18 @@ type Config struct {
 	JamfPassword     string `mapstructure:"jamf_password"`
 	AuthMethod       string `mapstructure:"auth_method"`
 
+	// Secrets can instead be read from a file or from the output of a helper command. They
+	// are read again each time an API token is requested, so they can be rotated in place.
+	JamfClientSecretFile    string `mapstructure:"jamf_client_secret_file"`
+	JamfClientSecretCommand string `mapstructure:"jamf_client_secret_command"`
+	JamfPasswordFile        string `mapstructure:"jamf_password_file"`
+	JamfPasswordCommand     string `mapstructure:"jamf_password_command"`
+
+	// Additional Jamf Pro instances, selected per tool call with the instance argument. The
+	// instance configured above is the primary one and is named by PrimaryInstance.
+	JamfInstances   map[string]JamfInstanceConfig `mapstructure:"jamf_instances"`
+	PrimaryInstance string                        `mapstructure:"primary_instance"`
+
 	// Advanced Jamf Pro client settings
 	MaxRetryAttempts            int  `mapstructure:"max_retry_attempts"`
 	EnableDynamicRateLimiting   bool `mapstructure:"enable_dynamic_rate_limiting"`
@@ -38,8 +81,71 @@ type Config struct {
 	JamfLoadBalancerLock        bool `mapstructure:"jamf_load_balancer_lock"`
 	HideSensitiveData           bool `mapstructure:"hide_sensitive_data"`
 
-	// Tool description overrides
-	ToolDescriptions map[string]string `mapstructure:"tool_descriptions"`
+	// viper holds the sources the configuration was loaded from, for Watch
+	viper *viper.Viper
+
+	// JamfTransport, when set, carries every Jamf Pro API request, for example to add a proxy,
+	// mutual TLS or request tracing. It can only be set by code embedding the server.
+	JamfTransport http.RoundTripper `mapstructure:"-"`
+
+	// Secret redaction, applied when HideSensitiveData is set
+	RedactPaths       []string `mapstructure:"redact_paths"`
+	AllowSecretReveal bool     `mapstructure:"allow_secret_reveal"`
+
+	// Site restricts the primary instance to one Jamf Pro site, given as a site ID or name
+	Site string `mapstructure:"site"`
+
+	// ResourcePollSeconds is how often Jamf Pro resources with subscribers are checked
+	// for changes; zero disables subscriptions to them
+	ResourcePollSeconds int `mapstructure:"resource_poll_seconds"`
+
+	// Directory of additional prompt templates
+	PromptsDirectory string `mapstructure:"prompts_directory"`
+
+	// Access profiles limit what individual clients may do
+	AccessProfiles       map[string]AccessProfileConfig `mapstructure:"access_profiles"`
+	DefaultAccessProfile string                         `mapstructure:"default_access_profile"`
+
+	// Tool description overrides keyed by tool name, and parameter description overrides keyed
+	// by tool name then parameter name
+	ToolDescriptions      map[string]string            `mapstructure:"tool_descriptions"`
+	ParameterDescriptions map[string]map[string]string `mapstructure:"parameter_descriptions"`
+}
+
+// JamfInstanceConfig holds the connection settings of one Jamf Pro instance. The keys match
+// the top-level settings of the primary instance.
+type JamfInstanceConfig struct {
+	JamfInstanceURL  string `mapstructure:"jamf_instance_url"`
+	JamfClientID     string `mapstructure:"jamf_client_id"`
+	JamfClientSecret string `mapstructure:"jamf_client_secret"`
+	JamfUsername     string `mapstructure:"jamf_username"`
+	JamfPassword     string `mapstructure:"jamf_password"`
+	AuthMethod       string `mapstructure:"auth_method"`
+
+	JamfClientSecretFile    string `mapstructure:"jamf_client_secret_file"`
+	JamfClientSecretCommand string `mapstructure:"jamf_client_secret_command"`
+	JamfPasswordFile        string `mapstructure:"jamf_password_file"`
+	JamfPasswordCommand     string `mapstructure:"jamf_password_command"`
+
+	// Site restricts the server to one site of this instance
+	Site string `mapstructure:"site"`
+}
+
+// AccessProfileConfig describes a named access profile and the clients it applies to
+type AccessProfileConfig struct {
+	Toolsets     []string `mapstructure:"toolsets"`
+	AllowedTools []string `mapstructure:"allowed_tools"`
+	DeniedTools  []string `mapstructure:"denied_tools"`
+	ReadOnly     bool     `mapstructure:"read_only"`
+
+	// Site restricts the profile to one Jamf Pro site, within the server's own site if set
+	Site string `mapstructure:"site"`
+
+	// Clients are glob patterns matched against the client name sent during initialize
+	Clients []string `mapstructure:"clients"`
+
+	// Tokens are HTTP bearer tokens that select this profile
+	Tokens []string `mapstructure:"tokens"`
 }
 
 // Load loads configuration from various sources
@@ -78,10 +184,32 @@ func Load(cmd *cobra.Command) (*Config, error) {
 		"JAMF_CLIENT_SECRET":                 "jamf_client_secret",
 		"JAMF_USERNAME":                      "jamf_username",
 		"JAMF_PASSWORD":                      "jamf_password",
+		"JAMF_CLIENT_SECRET_FILE":            "jamf_client_secret_file",
+		"JAMF_CLIENT_SECRET_COMMAND":         "jamf_client_secret_command",
+		"JAMF_PASSWORD_FILE":                 "jamf_password_file",
+		"JAMF_PASSWORD_COMMAND":              "jamf_password_command",
 		"JAMF_AUTH_METHOD":                   "auth_method",
 		"JAMF_TOOLSETS":                      "toolsets",
 		"JAMF_DYNAMIC_TOOLSETS":              "dynamic_toolsets",
+		"JAMF_READ_ONLY":                     "read_only",
+		"JAMF_ALLOWED_TOOLS":                 "allowed_tools",
+		"JAMF_DENIED_TOOLS":                  "denied_tools",
+		"JAMF_LIST_PAGE_SIZE":                "list_page_size",
+		"JAMF_REQUIRE_CONFIRMATION":          "require_confirmation",
+		"JAMF_CONFIRMATION_TTL_SECONDS":      "confirmation_ttl_seconds",
+		"JAMF_AUDIT_LOG_FILE":                "audit_log_file",
+		"JAMF_AUDIT_LOG_MAX_SIZE_MB":         "audit_log_max_size_mb",
+		"JAMF_AUDIT_LOG_MAX_BACKUPS":         "audit_log_max_backups",
+		"JAMF_AUDIT_SYSLOG_SOCKET":           "audit_syslog_socket",
+		"JAMF_SITE":                          "site",
 		"JAMF_LOG_LEVEL":                     "log_level",
+		"JAMF_TRANSPORT":                     "transport",
+		"JAMF_HTTP_LISTEN_ADDRESS":           "http_listen_address",
+		"JAMF_HTTP_ENDPOINT_PATH":            "http_endpoint_path",
+		"JAMF_HTTP_SESSION_IDLE_SECONDS":     "http_session_idle_seconds",
+		"JAMF_HTTP_MAX_SESSIONS":             "http_max_sessions",
+		"JAMF_PROMPTS_DIRECTORY":             "prompts_directory",
+		"JAMF_RESOURCE_POLL_SECONDS":         "resource_poll_seconds",
 		"JAMF_MAX_RETRY_ATTEMPTS":            "max_retry_attempts",
 		"JAMF_ENABLE_DYNAMIC_RATE_LIMITING":  "enable_dynamic_rate_limiting",
 		"JAMF_MAX_CONCURRENT_REQUESTS":       "max_concurrent_requests",
@@ -93,6 +221,9 @@ func Load(cmd *cobra.Command) (*Config, error) {
 		"JAMF_ENABLE_CONCURRENCY_MANAGEMENT": "enable_concurrency_management",
 		"JAMF_LOAD_BALANCER_LOCK":            "jamf_load_balancer_lock",
 		"JAMF_HIDE_SENSITIVE_DATA":           "hide_sensitive_data",
+		"JAMF_ALLOW_SECRET_REVEAL":           "allow_secret_reveal",
+		"JAMF_DEFAULT_ACCESS_PROFILE":        "default_access_profile",
+		"JAMF_PRIMARY_INSTANCE":              "primary_instance",
 	}
 
 	for envVar, configKey := range envMappings {
@@ -109,6 +240,32 @@ func Load(cmd *cobra.Command) (*Config, error) {
 	// Set defaults
 	setDefaults(v)
 
+	cfg, err := decode(v)
+	if err != nil {
+		return nil, err
+	}
+	cfg.viper = v
+
+	return cfg, nil
+}
+
+// Default returns the configuration used when no file, environment variable or flag sets
+// anything. It is not validated, since the Jamf Pro connection settings have no defaults.
+func Default() (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	cfg.viper = v
+
+	return &cfg, nil
+}
+
+// decode builds a validated configuration from the current state of v
+func decode(v *viper.Viper) (*Config, error) {
 	// Unmarshal into config struct
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
@@ -123,6 +280,17 @@ func Load(cmd *cobra.Command) (*Config, error) {
 		}
 	}
 
+	// Tool patterns from the environment are comma-separated too
+	if allowedEnv := os.Getenv("JAMF_ALLOWED_TOOLS"); allowedEnv != "" {
+		cfg.AllowedTools = splitList(allowedEnv)
+	}
+	if deniedEnv := os.Getenv("JAMF_DENIED_TOOLS"); deniedEnv != "" {
+		cfg.DeniedTools = splitList(deniedEnv)
+	}
+
+	// Viper lower-cases the keys of jamf_instances, so match that for the primary instance
+	cfg.PrimaryInstance = strings.ToLower(cfg.PrimaryInstance)
+
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
@@ -131,18 +299,36 @@ func Load(cmd *cobra.Command) (*Config, error) {
 	return &cfg, nil
 }
 
+// splitList splits a comma-separated value, trimming whitespace and dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func bindFlags(cmd *cobra.Command, v *viper.Viper) error {
 	flagMappings := map[string]string{
-		"log-level":           "log_level",
-		"toolsets":            "toolsets",
-		"dynamic-toolsets":    "dynamic_toolsets",
-		"export-translations": "export_translations",
-		"jamf-instance-url":   "jamf_instance_url",
-		"jamf-client-id":      "jamf_client_id",
-		"jamf-client-secret":  "jamf_client_secret",
-		"jamf-username":       "jamf_username",
-		"jamf-password":       "jamf_password",
-		"auth-method":         "auth_method",
+		"log-level":                  "log_level",
+		"toolsets":                   "toolsets",
+		"dynamic-toolsets":           "dynamic_toolsets",
+		"read-only":                  "read_only",
+		"export-translations":        "export_translations",
+		"transport":                  "transport",
+		"http-address":               "http_listen_address",
+		"jamf-instance-url":          "jamf_instance_url",
+		"jamf-client-id":             "jamf_client_id",
+		"jamf-client-secret":         "jamf_client_secret",
+		"jamf-username":              "jamf_username",
+		"jamf-password":              "jamf_password",
+		"jamf-client-secret-file":    "jamf_client_secret_file",
+		"jamf-client-secret-command": "jamf_client_secret_command",
+		"jamf-password-file":         "jamf_password_file",
+		"jamf-password-command":      "jamf_password_command",
+		"auth-method":                "auth_method",
 	}
 
 	for flag, configKey := range flagMappings {
@@ -158,8 +344,22 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("log_level", "info")
 	v.SetDefault("toolsets", []string{"all"})
 	v.SetDefault("dynamic_toolsets", false)
+	v.SetDefault("read_only", false)
+	v.SetDefault("require_confirmation", true)
+	v.SetDefault("confirmation_ttl_seconds", 120)
+	v.SetDefault("audit_log_max_size_mb", 100)
+	v.SetDefault("audit_log_max_backups", 10)
 	v.SetDefault("export_translations", false)
+	v.SetDefault("list_page_size", 100)
+	v.SetDefault("transport", "stdio")
+	v.SetDefault("http_listen_address", "127.0.0.1:8080")
+	v.SetDefault("http_endpoint_path", "/mcp")
+	v.SetDefault("http_session_idle_seconds", 1800)
+	v.SetDefault("http_max_sessions", 100)
+	v.SetDefault("prompts_directory", "prompts")
+	v.SetDefault("resource_poll_seconds", 60)
 	v.SetDefault("auth_method", "oauth2")
+	v.SetDefault("primary_instance", "default")
 	v.SetDefault("max_retry_attempts", 3)
 	v.SetDefault("enable_dynamic_rate_limiting", true)
 	v.SetDefault("max_concurrent_requests", 5)
@@ -171,71 +371,239 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("enable_concurrency_management", true)
 	v.SetDefault("jamf_load_balancer_lock", false)
 	v.SetDefault("hide_sensitive_data", true)
+	v.SetDefault("allow_secret_reveal", false)
 }
 
 // Validate validates the configuration
 func (c *Config) Validate() error {
-	if c.JamfInstanceURL == "" {
-		return fmt.Errorf("jamf_instance_url is required")
+	if err := c.validateInstances(); err != nil {
+		return err
+	}
+
+	if len(c.Toolsets) == 0 {
+		return fmt.Errorf("at least one toolset must be specified")
+	}
+
+	if c.ListPageSize < 0 {
+		return fmt.Errorf("list_page_size cannot be negative")
+	}
+
+	if c.ResourcePollSeconds < 0 {
+		return fmt.Errorf("resource_poll_seconds cannot be negative")
+	}
+
+	if c.HTTPSessionIdleSeconds < 0 {
+		return fmt.Errorf("http_session_idle_seconds cannot be negative")
+	}
+
+	if c.HTTPMaxSessions < 0 {
+		return fmt.Errorf("http_max_sessions cannot be negative")
 	}
 
-	if !strings.HasPrefix(c.JamfInstanceURL, "http://") && !strings.HasPrefix(c.JamfInstanceURL, "https://") {
-		return fmt.Errorf("jamf_instance_url must start with http:// or https://")
+	if c.RequireConfirmation && c.ConfirmationTTLSeconds <= 0 {
+		return fmt.Errorf("confirmation_ttl_seconds must be positive when require_confirmation is enabled")
 	}
 
-	switch c.AuthMethod {
+	if c.AuditLogFile != "" && c.AuditLogMaxBackups < 0 {
+		return fmt.Errorf("audit_log_max_backups cannot be negative")
+	}
+
+	if err := c.validateAccessProfiles(); err != nil {
+		return err
+	}
+
+	switch c.Transport {
+	case "stdio":
+	case "http":
+		if c.HTTPListenAddress == "" {
+			return fmt.Errorf("http_listen_address is required for the http transport")
+		}
+		if !strings.HasPrefix(c.HTTPEndpointPath, "/") {
+			return fmt.Errorf("http_endpoint_path must start with /")
+		}
+	default:
+		return fmt.Errorf("transport must be either 'stdio' or 'http'")
+	}
+
+	return nil
+}
+
+// Instances returns the settings of every Jamf Pro instance by name, including the primary
+func (c *Config) Instances() map[string]JamfInstanceConfig {
+	instances := make(map[string]JamfInstanceConfig, len(c.JamfInstances)+1)
+	for name, instance := range c.JamfInstances {
+		if instance.AuthMethod == "" {
+			instance.AuthMethod = "oauth2"
+		}
+		instances[name] = instance
+	}
+
+	instances[c.PrimaryInstance] = JamfInstanceConfig{
+		JamfInstanceURL:  c.JamfInstanceURL,
+		JamfClientID:     c.JamfClientID,
+		JamfClientSecret: c.JamfClientSecret,
+		JamfUsername:     c.JamfUsername,
+		JamfPassword:     c.JamfPassword,
+		AuthMethod:       c.AuthMethod,
+		Site:             c.Site,
+
+		JamfClientSecretFile:    c.JamfClientSecretFile,
+		JamfClientSecretCommand: c.JamfClientSecretCommand,
+		JamfPasswordFile:        c.JamfPasswordFile,
+		JamfPasswordCommand:     c.JamfPasswordCommand,
+	}
+
+	return instances
+}
+
+// validateInstances checks the connection settings of the primary and additional instances
+func (c *Config) validateInstances() error {
+	if c.PrimaryInstance == "" {
+		return fmt.Errorf("primary_instance cannot be empty")
+	}
+	if _, exists := c.JamfInstances[c.PrimaryInstance]; exists {
+		return fmt.Errorf("jamf_instances.%s has the same name as the primary instance", c.PrimaryInstance)
+	}
+
+	for name, instance := range c.Instances() {
+		prefix := ""
+		if name != c.PrimaryInstance {
+			prefix = fmt.Sprintf("jamf_instances.%s: ", name)
+		}
+		if err := instance.validate(prefix); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validate checks that the instance has a URL and the credentials its auth method needs.
+// prefix locates the instance in error messages.
+func (i JamfInstanceConfig) validate(prefix string) error {
+	if i.JamfInstanceURL == "" {
+		return fmt.Errorf("%sjamf_instance_url is required", prefix)
+	}
+
+	if !strings.HasPrefix(i.JamfInstanceURL, "http://") && !strings.HasPrefix(i.JamfInstanceURL, "https://") {
+		return fmt.Errorf("%sjamf_instance_url must start with http:// or https://", prefix)
+	}
+
+	switch i.AuthMethod {
 	case "oauth2":
-		if c.JamfClientID == "" {
-			return fmt.Errorf("jamf_client_id is required for oauth2 authentication")
+		if i.JamfClientID == "" {
+			return fmt.Errorf("%sjamf_client_id is required for oauth2 authentication", prefix)
+		}
+		if !i.ClientSecretSource().IsSet() {
+			return fmt.Errorf("%sjamf_client_secret, jamf_client_secret_file or jamf_client_secret_command is required for oauth2 authentication", prefix)
 		}
-		if c.JamfClientSecret == "" {
-			return fmt.Errorf("jamf_client_secret is required for oauth2 authentication")
+		if err := i.ClientSecretSource().validate("jamf_client_secret", prefix); err != nil {
+			return err
 		}
 	case "basic":
-		if c.JamfUsername == "" {
-			return fmt.Errorf("jamf_username is required for basic authentication")
+		if i.JamfUsername == "" {
+			return fmt.Errorf("%sjamf_username is required for basic authentication", prefix)
+		}
+		if !i.PasswordSource().IsSet() {
+			return fmt.Errorf("%sjamf_password, jamf_password_file or jamf_password_command is required for basic authentication", prefix)
 		}
-		if c.JamfPassword == "" {
-			return fmt.Errorf("jamf_password is required for basic authentication")
+		if err := i.PasswordSource().validate("jamf_password", prefix); err != nil {
+			return err
 		}
 	default:
-		return fmt.Errorf("auth_method must be either 'oauth2' or 'basic'")
+		return fmt.Errorf("%sauth_method must be either 'oauth2' or 'basic'", prefix)
 	}
 
-	if len(c.Toolsets) == 0 {
-		return fmt.Errorf("at least one toolset must be specified")
+	return nil
+}
+
+// ClientSecretSource returns where the instance's OAuth2 client secret is read from
+func (i JamfInstanceConfig) ClientSecretSource() SecretSource {
+	return SecretSource{Value: i.JamfClientSecret, File: i.JamfClientSecretFile, Command: i.JamfClientSecretCommand}
+}
+
+// PasswordSource returns where the instance's basic authentication password is read from
+func (i JamfInstanceConfig) PasswordSource() SecretSource {
+	return SecretSource{Value: i.JamfPassword, File: i.JamfPasswordFile, Command: i.JamfPasswordCommand}
+}
+
+// Secret returns the source of the secret the instance's auth method uses
+func (i JamfInstanceConfig) Secret() SecretSource {
+	if i.AuthMethod == "basic" {
+		return i.PasswordSource()
+	}
+	return i.ClientSecretSource()
+}
+
+// validateAccessProfiles checks that the default profile exists and that bearer tokens are
+// unique across profiles
+func (c *Config) validateAccessProfiles() error {
+	if c.DefaultAccessProfile != "" {
+		if _, ok := c.AccessProfiles[c.DefaultAccessProfile]; !ok {
+			return fmt.Errorf("default_access_profile %s is not defined in access_profiles", c.DefaultAccessProfile)
+		}
+	}
+
+	tokens := make(map[string]string)
+	for name, profile := range c.AccessProfiles {
+		for _, token := range profile.Tokens {
+			if token == "" {
+				return fmt.Errorf("access profile %s has an empty token", name)
+			}
+			if other, exists := tokens[token]; exists {
+				return fmt.Errorf("access profiles %s and %s share a token", other, name)
+			}
+			tokens[token] = name
+		}
 	}
 
 	return nil
 }
 
-// GetJamfProClientConfig returns a config suitable for the Jamf Pro SDK client
-func (c *Config) GetJamfProClientConfig() map[string]interface{} {
-	config := map[string]interface{}{
-		"instance_domain":                     c.JamfInstanceURL,
-		"auth_method":                         c.AuthMethod,
-		"log_level":                           c.LogLevel,
-		"log_output_format":                   "json",
-		"hide_sensitive_data":                 c.HideSensitiveData,
-		"max_retry_attempts":                  c.MaxRetryAttempts,
-		"enable_dynamic_rate_limiting":        c.EnableDynamicRateLimiting,
-		"max_concurrent_requests":             c.MaxConcurrentRequests,
-		"token_refresh_buffer_period_seconds": c.TokenRefreshBufferSeconds,
-		"total_retry_duration_seconds":        c.TotalRetryDurationSeconds,
-		"custom_timeout_seconds":              c.CustomTimeoutSeconds,
-		"follow_redirects":                    c.FollowRedirects,
-		"max_redirects":                       c.MaxRedirects,
-		"enable_concurrency_management":       c.EnableConcurrencyManagement,
-		"jamf_load_balancer_lock":             c.JamfLoadBalancerLock,
-	}
-
-	if c.AuthMethod == "oauth2" {
-		config["client_id"] = c.JamfClientID
-		config["client_secret"] = c.JamfClientSecret
+// GetJamfProClientConfig returns the Jamf Pro SDK client configuration of the primary instance
+func (c *Config) GetJamfProClientConfig(ctx context.Context) (*jamfpro.ConfigContainer, error) {
+	return c.GetInstanceClientConfig(ctx, c.PrimaryInstance)
+}
+
+// GetInstanceClientConfig returns the Jamf Pro SDK client configuration of a named instance,
+// reading its secret from a file or command if configured. Connection settings come from the
+// instance; logging, retry and concurrency settings are shared by every instance.
+func (c *Config) GetInstanceClientConfig(ctx context.Context, name string) (*jamfpro.ConfigContainer, error) {
+	instance, ok := c.Instances()[name]
+	if !ok {
+		return nil, fmt.Errorf("jamf instance %s is not configured", name)
+	}
+
+	secret, err := instance.Secret().Resolve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("jamf instance %s: %w", name, err)
+	}
+
+	container := &jamfpro.ConfigContainer{
+		LogLevel:                    c.LogLevel,
+		HideSensitiveData:           c.HideSensitiveData,
+		InstanceDomain:              instance.JamfInstanceURL,
+		AuthMethod:                  instance.AuthMethod,
+		ClientID:                    instance.JamfClientID,
+		Username:                    instance.JamfUsername,
+		JamfLoadBalancerLock:        c.JamfLoadBalancerLock,
+		MaxRetryAttempts:            c.MaxRetryAttempts,
+		MaxConcurrentRequests:       c.MaxConcurrentRequests,
+		EnableDynamicRateLimiting:   c.EnableDynamicRateLimiting,
+		CustomTimeout:               c.CustomTimeoutSeconds,
+		TokenRefreshBufferPeriod:    c.TokenRefreshBufferSeconds,
+		TotalRetryDuration:          c.TotalRetryDurationSeconds,
+		FollowRedirects:             c.FollowRedirects,
+		MaxRedirects:                c.MaxRedirects,
+		EnableConcurrencyManagement: c.EnableConcurrencyManagement,
+		RetryEligiableRequests:      true,
+	}
+
+	if instance.AuthMethod == "basic" {
+		container.Password = secret
 	} else {
-		config["basic_auth_username"] = c.JamfUsername
-		config["basic_auth_password"] = c.JamfPassword
+		container.ClientSecret = secret
 	}
 
-	return config
+	return container, nil
 }
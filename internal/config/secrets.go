@@ -0,0 +1,101 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// secretCommandTimeout bounds how long a secret helper command may run
+var secretCommandTimeout = 30 * time.Second
+
+// secretCommandWaitDelay bounds how long a timed-out command's output is waited for once the
+// shell has been killed, since processes it started may still hold the output open
+const secretCommandWaitDelay = time.Second
+
+// SecretSource is where a secret is read from: a literal value, a file such as a Docker or
+// Kubernetes secret, or a command such as `pass show jamf` that prints it on stdout
+type SecretSource struct {
+	Value   string
+	File    string
+	Command string
+}
+
+// IsSet reports whether any source is configured
+func (s SecretSource) IsSet() bool {
+	return s.Value != "" || s.File != "" || s.Command != ""
+}
+
+// Dynamic reports whether the secret can change while the server runs and should be read
+// again whenever it is used
+func (s SecretSource) Dynamic() bool {
+	return s.File != "" || s.Command != ""
+}
+
+// validate checks that exactly one source is configured. key is the setting name used in
+// error messages, such as jamf_client_secret.
+func (s SecretSource) validate(key, prefix string) error {
+	count := 0
+	for _, set := range []bool{s.Value != "", s.File != "", s.Command != ""} {
+		if set {
+			count++
+		}
+	}
+	if count > 1 {
+		return fmt.Errorf("%sset only one of %s, %s_file and %s_command", prefix, key, key, key)
+	}
+
+	if s.File != "" {
+		if _, err := os.Stat(s.File); err != nil {
+			return fmt.Errorf("%s%s_file: %w", prefix, key, err)
+		}
+	}
+
+	return nil
+}
+
+// Resolve returns the secret, reading the file or running the command as needed. Surrounding
+// whitespace, including the trailing newline most tools print, is removed.
+func (s SecretSource) Resolve(ctx context.Context) (string, error) {
+	switch {
+	case s.File != "":
+		data, err := os.ReadFile(s.File)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return nonEmptySecret(string(data), "secret file "+s.File)
+
+	case s.Command != "":
+		ctx, cancel := context.WithTimeout(ctx, secretCommandTimeout)
+		defer cancel()
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "/bin/sh", "-c", s.Command)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		cmd.WaitDelay = secretCommandWaitDelay
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return "", fmt.Errorf("secret command did not finish: %w", ctx.Err())
+			}
+			return "", fmt.Errorf("secret command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return nonEmptySecret(stdout.String(), "secret command")
+
+	default:
+		return s.Value, nil
+	}
+}
+
+// nonEmptySecret trims a secret read from a file or command and rejects empty results
+func nonEmptySecret(secret, origin string) (string, error) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return "", fmt.Errorf("%s returned an empty secret", origin)
+	}
+	return secret, nil
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSecretFile writes contents to a file in a temporary directory and returns its path
+func writeSecretFile(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+// TestResolveSecretFile tests that secret files are read on every use, trimmed, and rejected
+// when empty or missing
+func TestResolveSecretFile(t *testing.T) {
+	path := writeSecretFile(t, "first-secret\n")
+	source := SecretSource{File: path}
+
+	secret, err := source.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first-secret", secret)
+
+	// A rotated secret is picked up without building a new source
+	require.NoError(t, os.WriteFile(path, []byte("  rotated-secret  \n"), 0o600))
+	secret, err = source.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "rotated-secret", secret)
+
+	require.NoError(t, os.WriteFile(path, []byte(" \n"), 0o600))
+	_, err = source.Resolve(context.Background())
+	assert.EqualError(t, err, "secret file "+path+" returned an empty secret")
+
+	_, err = SecretSource{File: filepath.Join(t.TempDir(), "missing")}.Resolve(context.Background())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// TestResolveSecretCommand tests that a command's trimmed output is the secret and that
+// failing or silent commands are errors
+func TestResolveSecretCommand(t *testing.T) {
+	secret, err := SecretSource{Command: "printf 'command-secret\\n'"}.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "command-secret", secret)
+
+	_, err = SecretSource{Command: "true"}.Resolve(context.Background())
+	assert.EqualError(t, err, "secret command returned an empty secret")
+
+	_, err = SecretSource{Command: "echo vault is sealed >&2; exit 3"}.Resolve(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exit status 3")
+	assert.Contains(t, err.Error(), "vault is sealed")
+
+	// Without a file or command the literal value is returned as it is
+	secret, err = SecretSource{Value: " literal "}.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, " literal ", secret)
+}
+
+// TestResolveSecretCommandTimeout tests that a command which does not finish is stopped,
+// even when a process it started still holds its output open
+func TestResolveSecretCommandTimeout(t *testing.T) {
+	timeout := secretCommandTimeout
+	secretCommandTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { secretCommandTimeout = timeout })
+
+	started := time.Now()
+	_, err := SecretSource{Command: "sleep 5; echo too-late"}.Resolve(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), 3*time.Second)
+}
+
+// TestSecretSourceValidate tests that only one source may be set and that a secret file must exist
+func TestSecretSourceValidate(t *testing.T) {
+	path := writeSecretFile(t, "secret")
+
+	assert.NoError(t, SecretSource{}.validate("jamf_client_secret", ""))
+	assert.NoError(t, SecretSource{Value: "secret"}.validate("jamf_client_secret", ""))
+	assert.NoError(t, SecretSource{File: path}.validate("jamf_client_secret", ""))
+	assert.NoError(t, SecretSource{Command: "echo secret"}.validate("jamf_client_secret", ""))
+
+	err := SecretSource{Value: "secret", Command: "echo secret"}.validate("jamf_client_secret", "")
+	assert.EqualError(t, err, "set only one of jamf_client_secret, jamf_client_secret_file and jamf_client_secret_command")
+
+	err = SecretSource{File: path, Command: "echo secret"}.validate("jamf_password", "jamf_instances.eu: ")
+	assert.EqualError(t, err, "jamf_instances.eu: set only one of jamf_password, jamf_password_file and jamf_password_command")
+
+	err = SecretSource{File: filepath.Join(t.TempDir(), "missing")}.validate("jamf_password", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "jamf_password_file: ")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// TestInstanceSecretSources tests that an instance reads the secret of its auth method and
+// that the only-one-source rule applies to it
+func TestInstanceSecretSources(t *testing.T) {
+	instance := JamfInstanceConfig{
+		JamfInstanceURL:         "https://example.jamfcloud.com",
+		AuthMethod:              "oauth2",
+		JamfClientID:            "client-id",
+		JamfClientSecretCommand: "echo client-secret",
+		JamfUsername:            "admin",
+		JamfPassword:            "password",
+	}
+	require.NoError(t, instance.validate(""))
+	assert.Equal(t, SecretSource{Command: "echo client-secret"}, instance.Secret())
+
+	instance.AuthMethod = "basic"
+	assert.Equal(t, SecretSource{Value: "password"}, instance.Secret())
+
+	instance.JamfPasswordCommand = "echo password"
+	assert.EqualError(t, instance.validate(""), "set only one of jamf_password, jamf_password_file and jamf_password_command")
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"reflect"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch calls onChange with the reloaded configuration each time the config file changes. A
+// configuration that fails to load or validate is passed as an error instead, and the caller
+// should keep running with the previous one. Watch reports false when no config file was
+// loaded and there is nothing to watch.
+func (c *Config) Watch(onChange func(updated *Config, err error)) bool {
+	if c.viper == nil || c.viper.ConfigFileUsed() == "" {
+		return false
+	}
+
+	c.viper.OnConfigChange(func(fsnotify.Event) {
+		updated, err := decode(c.viper)
+		if err != nil {
+			onChange(nil, err)
+			return
+		}
+		updated.viper = c.viper
+		updated.JamfTransport = c.JamfTransport
+		onChange(updated, nil)
+	})
+	c.viper.WatchConfig()
+
+	return true
+}
+
+// File returns the path of the config file that was loaded, if any
+func (c *Config) File() string {
+	if c.viper == nil {
+		return ""
+	}
+	return c.viper.ConfigFileUsed()
+}
+
+// ChangedSettings returns the keys of the settings whose values differ between c and other
+func (c *Config) ChangedSettings(other *Config) []string {
+	var changed []string
+
+	current := reflect.ValueOf(c).Elem()
+	updated := reflect.ValueOf(other).Elem()
+	for i := 0; i < current.NumField(); i++ {
+		key := current.Type().Field(i).Tag.Get("mapstructure")
+		if key == "" || key == "-" {
+			continue
+		}
+		if !reflect.DeepEqual(current.Field(i).Interface(), updated.Field(i).Interface()) {
+			changed = append(changed, key)
+		}
+	}
+
+	return changed
+}
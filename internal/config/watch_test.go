@@ -0,0 +1,37 @@
+package config
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestChangedSettings tests that settings are compared by value and reported by their
+// configuration keys, ignoring fields that are not loaded from configuration
+func TestChangedSettings(t *testing.T) {
+	current := &Config{
+		Toolsets:         []string{"computers"},
+		ListPageSize:     50,
+		JamfClientSecret: "secret",
+		ToolDescriptions: map[string]string{"get_computers": "List computers"},
+	}
+
+	same := &Config{
+		Toolsets:         []string{"computers"},
+		ListPageSize:     50,
+		JamfClientSecret: "secret",
+		ToolDescriptions: map[string]string{"get_computers": "List computers"},
+		JamfTransport:    http.DefaultTransport,
+	}
+	assert.Empty(t, current.ChangedSettings(same))
+
+	updated := &Config{
+		Toolsets:         []string{"computers", "scripts"},
+		ListPageSize:     50,
+		JamfClientSecret: "rotated",
+		ToolDescriptions: map[string]string{"get_computers": "List every computer"},
+		HTTPMaxSessions:  10,
+	}
+	assert.Equal(t, []string{"toolsets", "http_max_sessions", "jamf_client_secret", "tool_descriptions"}, current.ChangedSettings(updated))
+}
@@ -0,0 +1,131 @@
+// Package prompts provides the built-in Jamf Pro workflow prompts offered over MCP
+package prompts
+
+import (
+	"fmt"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+)
+
+// Register adds the built-in Jamf Pro workflow prompts to registry
+func Register(registry *mcp.PromptRegistry) error {
+	for _, tmpl := range builtinPrompts {
+		if err := registry.RegisterTemplate(tmpl); err != nil {
+			return fmt.Errorf("failed to register built-in prompt %s: %w", tmpl.Name, err)
+		}
+	}
+	return nil
+}
+
+// builtinPrompts are the workflow prompts shipped with the server. They only reference tools
+// by name; whether a tool can actually be called depends on the enabled toolsets.
+var builtinPrompts = []mcp.PromptTemplate{
+	{
+		Name:        "offboard_mac",
+		Description: "Offboard a Mac identified by its serial number: review its inventory, then unenroll or wipe it",
+		Arguments: []mcp.PromptTemplateArgument{
+			{
+				Name:        "serial_number",
+				Description: "Serial number of the Mac to offboard",
+				Required:    true,
+			},
+			{
+				Name:        "action",
+				Description: "What to do once the Mac has been reviewed",
+				Type:        mcp.PromptArgumentString,
+				Enum:        []string{"review", "unenroll", "erase"},
+			},
+			{
+				Name:        "ticket",
+				Description: "Change or offboarding ticket reference to quote in the summary",
+			},
+		},
+		Messages: []mcp.PromptTemplateMessage{
+			{
+				Role: "user",
+				Text: `Offboard the Mac with serial number {{.serial_number}} from Jamf Pro.{{if .ticket}} This is tracked in ticket {{.ticket}}.{{end}}
+
+1. Call get_computers_inventory with the filter hardware.serialNumber=="{{.serial_number}}" and the sections GENERAL, HARDWARE, USER_AND_LOCATION and DISK_ENCRYPTION. Stop and tell me if no computer, or more than one, matches.
+2. Summarise the computer: its Jamf Pro ID, name, assigned user, last check-in, management state and FileVault status.
+{{- if eq .action "erase"}}
+3. Call get_computer_recovery_lock_password for the computer so the recovery lock password is on record before the device is wiped.
+4. Ask me to confirm, then call erase_computer with the computer's ID.
+{{- else if eq .action "unenroll"}}
+3. Ask me to confirm, then call remove_computer_mdm_profile with the computer's ID.
+{{- else}}
+3. Do not change anything. List the steps you would take to unenroll or erase the Mac and the tools you would call.
+{{- end}}
+
+Finish with a short summary suitable for pasting into the offboarding record.`,
+			},
+		},
+	},
+	{
+		Name:        "audit_filevault_coverage",
+		Description: "Audit FileVault encryption coverage across managed computers and list the gaps",
+		Arguments: []mcp.PromptTemplateArgument{
+			{
+				Name:        "filter",
+				Description: "Optional RSQL filter to narrow the computers audited, for example general.site.name==\"London\"",
+			},
+			{
+				Name:        "page_size",
+				Description: "Number of records to request per page",
+				Type:        mcp.PromptArgumentInteger,
+			},
+		},
+		Messages: []mcp.PromptTemplateMessage{
+			{
+				Role: "user",
+				Text: `Audit FileVault coverage in Jamf Pro{{if .filter}} for computers matching {{.filter}}{{end}}.
+
+1. Call get_computers_filevault_inventory{{if .page_size}} with page_size {{.page_size}}{{end}}{{if .filter}} and the filter {{.filter}}{{end}}, following the pages until every record has been read.
+2. Call get_computers_inventory with the DISK_ENCRYPTION and GENERAL sections{{if .filter}} and the same filter{{end}} to find computers that are missing from the FileVault inventory.
+3. Report the total number of computers, how many are fully encrypted, and the coverage percentage.
+4. List every computer that is not encrypted, is still encrypting, or has no valid personal recovery key, with its ID, name and last check-in.
+
+Do not retrieve or display any recovery keys.`,
+			},
+		},
+	},
+	{
+		Name:        "triage_failing_policy",
+		Description: "Triage a Jamf Pro policy that is failing, reviewing its scope, payloads and scripts",
+		Arguments: []mcp.PromptTemplateArgument{
+			{
+				Name:        "policy",
+				Description: "ID or name of the failing policy",
+				Required:    true,
+			},
+			{
+				Name:        "serial_number",
+				Description: "Serial number of a computer on which the policy failed",
+			},
+			{
+				Name:        "error_output",
+				Description: "Error output copied from the policy log",
+			},
+		},
+		Messages: []mcp.PromptTemplateMessage{
+			{
+				Role: "user",
+				Text: `The Jamf Pro policy "{{.policy}}" is failing. Help me triage it.
+
+1. Look the policy up with get_policy_by_id if "{{.policy}}" is numeric, otherwise with get_policy_by_name.
+2. Summarise its trigger, frequency, scope, exclusions and payloads.
+3. For every script in the policy, call get_script_by_id and review it for problems such as missing parameters, hard-coded paths or commands that need a logged-in user.
+{{- if .serial_number}}
+4. Call get_computers_inventory with the filter hardware.serialNumber=="{{.serial_number}}" and check whether the computer is in scope, has checked in recently and meets the policy's requirements.
+{{- end}}
+{{- if .error_output}}
+
+The policy log shows this error:
+
+{{.error_output}}
+{{- end}}
+
+Finish with the most likely causes, ranked, and the change you would make for each. Do not modify the policy.`,
+			},
+		},
+	},
+}
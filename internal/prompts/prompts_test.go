@@ -0,0 +1,59 @@
+package prompts
+
+import (
+	"testing"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegister tests that every built-in prompt registers and renders
+func TestRegister(t *testing.T) {
+	registry := mcp.NewPromptRegistry()
+	require.NoError(t, Register(registry))
+
+	prompts := registry.ListPrompts()
+	require.Len(t, prompts, len(builtinPrompts))
+
+	for _, prompt := range prompts {
+		// Render each prompt with only its required arguments
+		args := make(map[string]string)
+		for _, arg := range prompt.Arguments {
+			if arg.Required {
+				args[arg.Name] = "42"
+			}
+		}
+
+		result, err := registry.GetPrompt(prompt.Name, args)
+		require.NoError(t, err, prompt.Name)
+		require.NotEmpty(t, result.Messages, prompt.Name)
+		assert.NotContains(t, result.Messages[0].Content.Text, "<no value>", prompt.Name)
+	}
+}
+
+// TestOffboardMacActions tests that the offboarding prompt only suggests destructive tools when asked to
+func TestOffboardMacActions(t *testing.T) {
+	registry := mcp.NewPromptRegistry()
+	require.NoError(t, Register(registry))
+
+	render := func(action string) string {
+		result, err := registry.GetPrompt("offboard_mac", map[string]string{
+			"serial_number": "C02ABC123",
+			"action":        action,
+		})
+		require.NoError(t, err)
+		return result.Messages[0].Content.Text
+	}
+
+	review := render("")
+	assert.Contains(t, review, `hardware.serialNumber=="C02ABC123"`)
+	assert.NotContains(t, review, "erase_computer")
+	assert.NotContains(t, review, "remove_computer_mdm_profile")
+
+	assert.Contains(t, render("unenroll"), "remove_computer_mdm_profile")
+	assert.Contains(t, render("erase"), "erase_computer")
+
+	_, err := registry.GetPrompt("offboard_mac", map[string]string{"serial_number": "C02ABC123", "action": "shred"})
+	assert.Error(t, err)
+}
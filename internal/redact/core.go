@@ -0,0 +1,96 @@
+package redact
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// core is a zapcore.Core that redacts JSON carried in log fields before passing entries on
+type core struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+// WrapCore returns a core that redacts string fields holding JSON, such as logged protocol
+// messages, and structured fields such as tool arguments
+func WrapCore(inner zapcore.Core, redactor *Redactor) zapcore.Core {
+	return &core{Core: inner, redactor: redactor}
+}
+
+// ZapOption returns a logger option that wraps the logger's core with WrapCore
+func ZapOption(redactor *Redactor) zap.Option {
+	return zap.WrapCore(func(inner zapcore.Core) zapcore.Core {
+		return WrapCore(inner, redactor)
+	})
+}
+
+// With redacts fields added to a child logger
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{Core: c.Core.With(c.redactFields(fields)), redactor: c.redactor}
+}
+
+// Check adds this core, rather than the wrapped one, so that Write sees every entry
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write redacts fields and writes the entry to the wrapped core
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.redactFields(fields))
+}
+
+// redactFields returns fields with secrets masked, copying the slice only when needed
+func (c *core) redactFields(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+	for i, field := range fields {
+		replacement, changed := c.redactField(field)
+		if !changed {
+			continue
+		}
+		if redacted == nil {
+			redacted = append([]zapcore.Field(nil), fields...)
+		}
+		redacted[i] = replacement
+	}
+
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
+
+// redactField masks secrets in a single field
+func (c *core) redactField(field zapcore.Field) (zapcore.Field, bool) {
+	switch field.Type {
+	case zapcore.StringType:
+		trimmed := strings.TrimSpace(field.String)
+		if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+			return field, false
+		}
+		data, err := c.redactor.RedactJSON([]byte(trimmed))
+		if err != nil {
+			return field, false
+		}
+		return zap.String(field.Key, string(data)), true
+
+	case zapcore.ReflectType:
+		encoded, err := json.Marshal(field.Interface)
+		if err != nil {
+			return field, false
+		}
+		data, err := c.redactor.RedactJSON(encoded)
+		if err != nil {
+			return field, false
+		}
+		return zap.Reflect(field.Key, json.RawMessage(data)), true
+
+	default:
+		return field, false
+	}
+}
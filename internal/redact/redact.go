@@ -0,0 +1,196 @@
+// Package redact masks secrets such as FileVault recovery keys and recovery lock passwords in
+// JSON documents before they reach a client or a log.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Mask replaces redacted values
+const Mask = "[REDACTED]"
+
+// DefaultPaths are the JSON paths of the secrets Jamf Pro returns in inventory responses
+var DefaultPaths = []string{
+	"**.personalRecoveryKey",
+	"**.recoveryLockPassword",
+	"**.institutional_recovery_key",
+	"**.password",
+	"**.client_secret",
+}
+
+// Redactor masks the values found at a set of JSON paths.
+//
+// A path is a list of object keys separated by dots. A "*" segment matches any single key and
+// a "**" segment matches any number of keys, so "**.password" masks a password key at any
+// depth. Arrays are traversed transparently: "computers.general.name" matches the name of every
+// element of the computers array. Keys are compared case-insensitively.
+type Redactor struct {
+	paths [][]string
+}
+
+// New creates a redactor for the given paths
+func New(paths []string) (*Redactor, error) {
+	redactor := &Redactor{}
+	for _, path := range paths {
+		segments := strings.Split(path, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid redaction path %q: empty segment", path)
+			}
+		}
+		redactor.paths = append(redactor.paths, segments)
+	}
+	return redactor, nil
+}
+
+// Matches reports whether the value at path should be masked
+func (r *Redactor) Matches(path []string) bool {
+	for _, pattern := range r.paths {
+		if matchPath(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactJSON returns data with every matching value masked. Object key order is kept but the
+// output is always compact JSON. Null and empty string values are left alone so that a
+// missing secret stays visible.
+func (r *Redactor) RedactJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var buf bytes.Buffer
+	if err := r.rewrite(dec, &buf, nil); err != nil {
+		return nil, err
+	}
+
+	// Reject trailing data so that only whole documents are rewritten
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after JSON document")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// rewrite copies the next JSON value from dec to buf, masking values at matching paths
+func (r *Redactor) rewrite(dec *json.Decoder, buf *bytes.Buffer, path []string) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch delim := token.(type) {
+	case json.Delim:
+		switch delim {
+		case '{':
+			buf.WriteByte('{')
+			for first := true; dec.More(); first = false {
+				if !first {
+					buf.WriteByte(',')
+				}
+
+				keyToken, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key, _ := keyToken.(string)
+				if err := writeJSON(buf, key); err != nil {
+					return err
+				}
+				buf.WriteByte(':')
+
+				keyPath := append(path[:len(path):len(path)], key)
+				if r.Matches(keyPath) {
+					if err := maskValue(dec, buf); err != nil {
+						return err
+					}
+					continue
+				}
+
+				if err := r.rewrite(dec, buf, keyPath); err != nil {
+					return err
+				}
+			}
+			if _, err := dec.Token(); err != nil {
+				return err
+			}
+			buf.WriteByte('}')
+
+		case '[':
+			buf.WriteByte('[')
+			for first := true; dec.More(); first = false {
+				if !first {
+					buf.WriteByte(',')
+				}
+				if err := r.rewrite(dec, buf, path); err != nil {
+					return err
+				}
+			}
+			if _, err := dec.Token(); err != nil {
+				return err
+			}
+			buf.WriteByte(']')
+		}
+		return nil
+
+	default:
+		return writeJSON(buf, token)
+	}
+}
+
+// maskValue consumes the next value from dec and writes the mask in its place
+func maskValue(dec *json.Decoder, buf *bytes.Buffer) error {
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	if trimmed := bytes.TrimSpace(raw); bytes.Equal(trimmed, []byte("null")) || bytes.Equal(trimmed, []byte(`""`)) {
+		buf.Write(trimmed)
+		return nil
+	}
+
+	return writeJSON(buf, Mask)
+}
+
+// writeJSON appends the JSON encoding of value to buf
+func writeJSON(buf *bytes.Buffer, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+// matchPath reports whether path matches pattern, where "*" matches one segment and "**"
+// matches any number of segments
+func matchPath(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchPath(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(path) == 0 {
+		return false
+	}
+
+	if pattern[0] != "*" && !strings.EqualFold(pattern[0], path[0]) {
+		return false
+	}
+
+	return matchPath(pattern[1:], path[1:])
+}
@@ -0,0 +1,81 @@
+package redact
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestRedactJSON tests path matching, array traversal and preservation of everything else
+func TestRedactJSON(t *testing.T) {
+	redactor, err := New([]string{"**.personalRecoveryKey", "results.general.name", "meta.*.secretValue"})
+	require.NoError(t, err)
+
+	input := `{
+		"results": [
+			{"id": "1", "general": {"name": "Finance-MBP", "platform": "Mac"}, "personalRecoveryKey": "ABCD-EFGH"},
+			{"id": "2", "general": {"name": "HR-MBP"}, "personalRecoveryKey": ""}
+		],
+		"meta": {"a": {"secretValue": 42}, "totalCount": 2},
+		"PersonalRecoveryKey": null
+	}`
+
+	output, err := redactor.RedactJSON([]byte(input))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"results": [
+			{"id": "1", "general": {"name": "[REDACTED]", "platform": "Mac"}, "personalRecoveryKey": "[REDACTED]"},
+			{"id": "2", "general": {"name": "[REDACTED]"}, "personalRecoveryKey": ""}
+		],
+		"meta": {"a": {"secretValue": "[REDACTED]"}, "totalCount": 2},
+		"PersonalRecoveryKey": null
+	}`, string(output))
+
+	// Key order is kept
+	assert.Regexp(t, `^\{"results":.*"meta":.*"PersonalRecoveryKey":null\}$`, string(output))
+}
+
+// TestRedactJSONRejectsInvalidInput tests that malformed and trailing data are errors
+func TestRedactJSONRejectsInvalidInput(t *testing.T) {
+	redactor, err := New(DefaultPaths)
+	require.NoError(t, err)
+
+	_, err = redactor.RedactJSON([]byte(`{"password": `))
+	assert.Error(t, err)
+
+	_, err = redactor.RedactJSON([]byte(`{} {}`))
+	assert.Error(t, err)
+
+	_, err = New([]string{"a..b"})
+	assert.Error(t, err)
+}
+
+// TestWrapCore tests that JSON in string and structured log fields is redacted
+func TestWrapCore(t *testing.T) {
+	redactor, err := New(DefaultPaths)
+	require.NoError(t, err)
+
+	observed, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(observed, ZapOption(redactor))
+
+	logger.With(zap.String("context", `{"password":"hunter2"}`)).Debug("Sent message",
+		zap.String("message", `{"result":{"recoveryLockPassword":"s3cr3t"}}`),
+		zap.Any("arguments", map[string]interface{}{"id": "7", "client_secret": "abc"}),
+		zap.String("plain", "password=visible-because-not-json"))
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+
+	assert.Equal(t, `{"password":"[REDACTED]"}`, fields["context"])
+	assert.Equal(t, `{"result":{"recoveryLockPassword":"[REDACTED]"}}`, fields["message"])
+	arguments, ok := fields["arguments"].(json.RawMessage)
+	require.True(t, ok)
+	assert.JSONEq(t, `{"id":"7","client_secret":"[REDACTED]"}`, string(arguments))
+	assert.Equal(t, "password=visible-because-not-json", fields["plain"])
+}
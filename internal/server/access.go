@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"go.uber.org/zap"
+)
+
+// profileToken maps an HTTP bearer token to the access profile it selects
+type profileToken struct {
+	token   string
+	profile string
+}
+
+// initializeAccessProfiles registers the configured access profiles with the MCP server and
+// records which bearer tokens select them
+func (s *Server) initializeAccessProfiles() error {
+	if len(s.config.AccessProfiles) == 0 {
+		return nil
+	}
+
+	// Client name patterns are tried in name order so that matching is deterministic
+	names := make([]string, 0, len(s.config.AccessProfiles))
+	for name := range s.config.AccessProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	profiles := make([]mcp.AccessProfile, 0, len(names))
+	for _, name := range names {
+		cfg := s.config.AccessProfiles[name]
+		profiles = append(profiles, mcp.AccessProfile{
+			Name:         name,
+			Toolsets:     cfg.Toolsets,
+			AllowedTools: cfg.AllowedTools,
+			DeniedTools:  cfg.DeniedTools,
+			ReadOnly:     cfg.ReadOnly,
+			Site:         cfg.Site,
+			Clients:      cfg.Clients,
+		})
+
+		for _, token := range cfg.Tokens {
+			s.profileTokens = append(s.profileTokens, profileToken{token: token, profile: name})
+		}
+	}
+
+	if err := s.mcpServer.SetAccessProfiles(profiles, s.config.DefaultAccessProfile); err != nil {
+		return err
+	}
+
+	s.logger.Info("Access profiles configured",
+		zap.Strings("profiles", names),
+		zap.String("default_profile", s.config.DefaultAccessProfile),
+		zap.Int("bearer_tokens", len(s.profileTokens)))
+
+	return nil
+}
+
+// bearerAccessProfile returns the access profile selected by the request's bearer token. It
+// returns nil without error when the request carries no token.
+func (s *Server) bearerAccessProfile(r *http.Request) (*mcp.AccessProfile, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return nil, nil
+	}
+
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, fmt.Errorf("authorization header must use the Bearer scheme")
+	}
+
+	for _, candidate := range s.profileTokens {
+		if subtle.ConstantTimeCompare([]byte(candidate.token), []byte(token)) == 1 {
+			profile, ok := s.mcpServer.AccessProfile(candidate.profile)
+			if !ok {
+				return nil, fmt.Errorf("access profile %s is not defined", candidate.profile)
+			}
+			return profile, nil
+		}
+	}
+
+	return nil, fmt.Errorf("bearer token is not recognised")
+}
+
+// authorizeHTTPSession checks that a request on an existing session presents the same bearer
+// token profile that the session was created with
+func (s *Server) authorizeHTTPSession(w http.ResponseWriter, r *http.Request, session *mcp.Session) (int, error) {
+	profile, err := s.bearerAccessProfile(r)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		return http.StatusUnauthorized, err
+	}
+
+	if session.AccessProfilePinned() {
+		if profile != session.AccessProfile() {
+			return http.StatusForbidden, fmt.Errorf("session was created with a different bearer token")
+		}
+		return http.StatusOK, nil
+	}
+
+	if profile != nil {
+		return http.StatusForbidden, fmt.Errorf("session was not created with a bearer token")
+	}
+
+	return http.StatusOK, nil
+}
+
+// sessionAccessProfile returns the access profile of the session in ctx, if any
+func sessionAccessProfile(ctx context.Context) *mcp.AccessProfile {
+	if session := mcp.SessionFromContext(ctx); session != nil {
+		return session.AccessProfile()
+	}
+	return nil
+}
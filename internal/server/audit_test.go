@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/audit"
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/config"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/toolsets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recordingSink keeps audit records in memory
+type recordingSink struct {
+	mu      sync.Mutex
+	records []audit.Record
+}
+
+func (r *recordingSink) Write(record audit.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, record)
+	return nil
+}
+
+func (r *recordingSink) Close() error {
+	return nil
+}
+
+// outcomes returns the tool and outcome of every record
+func (r *recordingSink) outcomes() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var outcomes []string
+	for _, record := range r.records {
+		outcomes = append(outcomes, record.Tool+" "+record.Outcome)
+	}
+	return outcomes
+}
+
+// fakeJamfClient serves computers from memory; other client methods are not implemented
+type fakeJamfClient struct {
+	toolsets.JamfProClient
+	computers map[string]*jamfpro.ResourceComputerInventory
+}
+
+func (f *fakeJamfClient) GetComputerInventoryByID(id string) (*jamfpro.ResourceComputerInventory, error) {
+	computer, ok := f.computers[id]
+	if !ok {
+		return nil, fmt.Errorf("computer %s not found", id)
+	}
+	return computer, nil
+}
+
+// newAuditedServer creates a bare server whose primary instance is client and whose audit
+// records are kept by the returned sink
+func newAuditedServer(t *testing.T, client toolsets.JamfProClient) (*Server, *recordingSink) {
+	t.Helper()
+
+	sink := &recordingSink{}
+	s := &Server{
+		config:     &config.Config{PrimaryInstance: "default", ResourcePollSeconds: 60},
+		mcpServer:  mcp.NewServer("test-server", "1.0.0"),
+		logger:     zap.NewNop(),
+		wireLogger: zap.NewNop(),
+		instances:  map[string]*jamfInstance{"default": {name: "default", client: client}},
+		auditor:    audit.NewLogger(sink),
+	}
+	s.mcpServer.SetToolCallObserver(s.auditToolCall)
+	s.jamfResources = newJamfResourceProvider(s)
+	s.mcpServer.SetResourceProvider(s.jamfResources)
+	return s, sink
+}
+
+// enableTestToolsets builds toolsets over the primary client of a bare server and enables
+// the named ones, which become the configured toolsets
+func enableTestToolsets(t *testing.T, s *Server, names ...string) {
+	t.Helper()
+
+	toolFilter, err := toolsets.NewToolFilter(nil, nil)
+	require.NoError(t, err)
+	s.toolFilter = toolFilter
+	s.factory = toolsets.NewFactory(s.instances[s.config.PrimaryInstance].client, s.logger)
+	s.toolsets = make(map[string]toolsets.Toolset)
+	s.callToolsets = make(map[callToolsetKey]toolsets.Toolset)
+
+	s.config.Toolsets = names
+	for _, name := range names {
+		_, _, err := s.enableToolset(name)
+		require.NoError(t, err)
+	}
+}
+
+// listTools sends tools/list, following cursors, and returns every listed tool by name
+func listTools(t *testing.T, s *Server, ctx context.Context) map[string]mcp.Tool {
+	t.Helper()
+
+	tools := make(map[string]mcp.Tool)
+	cursor := ""
+	for {
+		response, err := s.mcpServer.HandleMessage(ctx, &mcp.Message{JSONRPC: "2.0", ID: 1, Method: "tools/list", Params: map[string]interface{}{"cursor": cursor}})
+		require.NoError(t, err)
+		require.Nil(t, response.Error)
+
+		result, ok := response.Result.(*mcp.ListToolsResult)
+		require.True(t, ok)
+		for _, tool := range result.Tools {
+			tools[tool.Name] = tool
+		}
+		if result.NextCursor == nil {
+			return tools
+		}
+		cursor = *result.NextCursor
+	}
+}
+
+// newTestSession creates an initialized session with the given access profile, which may be nil
+func newTestSession(t *testing.T, s *Server, profile *mcp.AccessProfile) context.Context {
+	t.Helper()
+
+	session, err := s.mcpServer.CreateSession()
+	require.NoError(t, err)
+	if profile != nil {
+		session.SetAccessProfile(profile)
+	}
+	ctx := mcp.ContextWithSession(context.Background(), session)
+
+	response, err := s.mcpServer.HandleMessage(ctx, &mcp.Message{JSONRPC: "2.0", ID: 0, Method: "initialize", Params: map[string]interface{}{}})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+	return ctx
+}
+
+// callTool sends tools/call and returns the response
+func callTool(t *testing.T, s *Server, ctx context.Context, name string, arguments map[string]interface{}) *mcp.Message {
+	t.Helper()
+
+	response, err := s.mcpServer.HandleMessage(ctx, &mcp.Message{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "tools/call",
+		Params:  map[string]interface{}{"name": name, "arguments": arguments},
+	})
+	require.NoError(t, err)
+	return response
+}
+
+// TestAuditRefusedToolCalls tests that calls refused before reaching a tool are audited as denied
+func TestAuditRefusedToolCalls(t *testing.T) {
+	s, sink := newAuditedServer(t, &fakeJamfClient{})
+	s.registerServerTool(mcp.Tool{Name: "delete_everything", Access: mcp.ToolAccessDestructive}, func(ctx context.Context, params mcp.CallToolParams) (*mcp.CallToolResult, error) {
+		return textResult("deleted", false), nil
+	})
+
+	restricted := newTestSession(t, s, &mcp.AccessProfile{Name: "helpdesk", ReadOnly: true})
+	assert.NotNil(t, callTool(t, s, restricted, "delete_everything", nil).Error)
+
+	unrestricted := newTestSession(t, s, nil)
+	assert.NotNil(t, callTool(t, s, unrestricted, "get_unknown", nil).Error)
+
+	s.mcpServer.SetReadOnly(true)
+	assert.NotNil(t, callTool(t, s, unrestricted, "delete_everything", map[string]interface{}{"id": "7"}).Error)
+
+	assert.Equal(t, []string{"delete_everything denied", "get_unknown denied", "delete_everything denied"}, sink.outcomes())
+	assert.Equal(t, "helpdesk", sink.records[0].AccessProfile)
+	assert.Contains(t, sink.records[0].Error, "access profile helpdesk")
+	assert.Equal(t, map[string]interface{}{"id": "7"}, sink.records[2].Targets)
+}
+
+// TestAuditServerTools tests that the server's own tools are audited like toolset tools
+func TestAuditServerTools(t *testing.T) {
+	s, sink := newAuditedServer(t, &fakeJamfClient{})
+	s.registerServerTool(mcp.Tool{Name: "list_jamf_instances", Access: mcp.ToolAccessRead}, s.handleListJamfInstances)
+	s.registerServerTool(mcp.Tool{Name: "enable_toolset", Access: mcp.ToolAccessRead}, func(ctx context.Context, params mcp.CallToolParams) (*mcp.CallToolResult, error) {
+		return textResult("toolset reports is not available", true), nil
+	})
+
+	ctx := newTestSession(t, s, nil)
+	callTool(t, s, ctx, "list_jamf_instances", nil)
+	callTool(t, s, ctx, "enable_toolset", map[string]interface{}{"toolset": "reports"})
+
+	assert.Equal(t, []string{"list_jamf_instances success", "enable_toolset error"}, sink.outcomes())
+	assert.Equal(t, "toolset reports is not available", sink.records[1].Error)
+	assert.Equal(t, "default", sink.records[0].Instance)
+}
+
+// TestAuditResourceReads tests that reads of Jamf Pro resources are audited under the tool
+// that governs them, whether they succeed or are refused
+func TestAuditResourceReads(t *testing.T) {
+	s, sink := newAuditedServer(t, &fakeJamfClient{computers: map[string]*jamfpro.ResourceComputerInventory{"7": {ID: "7"}}})
+	ctx := newTestSession(t, s, nil)
+
+	// The governing tool is not enabled yet
+	_, err := s.jamfResources.ReadResource(ctx, "jamf://computers/7")
+	require.ErrorIs(t, err, mcp.ErrResourceAccessDenied)
+
+	s.registerServerTool(mcp.Tool{Name: "get_computer_inventory_by_id", Access: mcp.ToolAccessRead}, nil)
+	_, err = s.jamfResources.ReadResource(ctx, "jamf://computers/7")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"get_computer_inventory_by_id denied", "get_computer_inventory_by_id success"}, sink.outcomes())
+	assert.Equal(t, "jamf://computers/7", sink.records[1].Resource)
+	assert.Equal(t, map[string]interface{}{"id": "7"}, sink.records[1].Arguments)
+}
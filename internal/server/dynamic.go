@@ -0,0 +1,271 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/toolsets"
+	"go.uber.org/zap"
+)
+
+// Meta-tools offered in dynamic toolset mode
+const (
+	toolListAvailableToolsets = "list_available_toolsets"
+	toolGetToolsetTools       = "get_toolset_tools"
+	toolEnableToolset         = "enable_toolset"
+)
+
+// toolsetSummary describes a toolset in list_available_toolsets output
+type toolsetSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+	Enabled     bool   `json:"enabled"`
+	ToolCount   int    `json:"tool_count"`
+}
+
+// toolSummary describes a tool in get_toolset_tools output
+type toolSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// registerDynamicToolsetTools registers the meta-tools that let a client discover and enable
+// toolsets at runtime instead of receiving every tool up front
+func (s *Server) registerDynamicToolsetTools() {
+	toolsetArgument := map[string]interface{}{
+		"toolset": map[string]interface{}{
+			"type":        "string",
+			"description": "Name of the toolset, as returned by list_available_toolsets",
+		},
+	}
+
+	metaTools := []struct {
+		tool    mcp.Tool
+		handler mcp.ToolHandler
+	}{
+		{
+			tool: mcp.Tool{
+				Name:        toolListAvailableToolsets,
+				Description: "List the Jamf Pro toolsets that can be enabled, with a description and whether each is already enabled",
+				Access:      mcp.ToolAccessRead,
+				Annotations: mcp.ReadOnlyAnnotations("List Available Toolsets"),
+				InputSchema: mcp.ToolInputSchema{
+					Type:       "object",
+					Properties: map[string]interface{}{},
+					Required:   []string{},
+				},
+			},
+			handler: s.handleListAvailableToolsets,
+		},
+		{
+			tool: mcp.Tool{
+				Name:        toolGetToolsetTools,
+				Description: "List the tools a toolset provides without enabling it",
+				Access:      mcp.ToolAccessRead,
+				Annotations: mcp.ReadOnlyAnnotations("Get Toolset Tools"),
+				InputSchema: mcp.ToolInputSchema{
+					Type:       "object",
+					Properties: toolsetArgument,
+					Required:   []string{"toolset"},
+				},
+			},
+			handler: s.handleGetToolsetTools,
+		},
+		{
+			tool: mcp.Tool{
+				Name:        toolEnableToolset,
+				Description: "Enable a toolset so that its tools become available. The client is notified that the tool list has changed.",
+				// Enabling a toolset changes the session's tools but not Jamf Pro, so it stays
+				// available in read-only mode
+				Access:      mcp.ToolAccessRead,
+				Annotations: mcp.WriteAnnotations("Enable Toolset", true),
+				InputSchema: mcp.ToolInputSchema{
+					Type:       "object",
+					Properties: toolsetArgument,
+					Required:   []string{"toolset"},
+				},
+			},
+			handler: s.handleEnableToolset,
+		},
+	}
+
+	for _, meta := range metaTools {
+		s.registerServerTool(meta.tool, meta.handler)
+		s.logger.Debug("Registered dynamic toolset tool", zap.String("tool", meta.tool.Name))
+	}
+}
+
+// handleListAvailableToolsets handles the list_available_toolsets meta-tool
+func (s *Server) handleListAvailableToolsets(ctx context.Context, params mcp.CallToolParams) (*mcp.CallToolResult, error) {
+	profile := sessionAccessProfile(ctx)
+
+	var summaries []toolsetSummary
+	for _, name := range s.availableToolsets() {
+		if !profile.AllowsToolset(name) {
+			continue
+		}
+
+		toolset, enabled, err := s.lookupToolset(name)
+		if err != nil {
+			continue
+		}
+		registration, _ := toolsets.Lookup(name)
+
+		summaries = append(summaries, toolsetSummary{
+			Name:        name,
+			Description: toolset.GetDescription(),
+			Category:    registration.Category,
+			Enabled:     enabled,
+			ToolCount:   len(s.visibleTools(toolset)),
+		})
+	}
+
+	response, err := toolsets.FormatJSONResponse(ctx, summaries)
+	if err != nil {
+		return textResult(err.Error(), true), nil
+	}
+
+	return textResult(fmt.Sprintf("Found %d available toolsets:\n\n%s", len(summaries), response), false), nil
+}
+
+// handleGetToolsetTools handles the get_toolset_tools meta-tool
+func (s *Server) handleGetToolsetTools(ctx context.Context, params mcp.CallToolParams) (*mcp.CallToolResult, error) {
+	name, err := toolsets.GetStringArgument(params.Arguments, "toolset", true)
+	if err != nil {
+		return textResult(err.Error(), true), nil
+	}
+
+	if profile := sessionAccessProfile(ctx); !profile.AllowsToolset(name) {
+		return textResult(fmt.Sprintf("toolset %s is not permitted by access profile %s", name, profile.Name), true), nil
+	}
+
+	toolset, _, err := s.lookupToolset(name)
+	if err != nil {
+		return textResult(err.Error(), true), nil
+	}
+
+	tools := s.visibleTools(toolset)
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+
+	summaries := make([]toolSummary, 0, len(tools))
+	for _, tool := range tools {
+		tool = s.describeTool(tool)
+		summaries = append(summaries, toolSummary{Name: tool.Name, Description: tool.Description})
+	}
+
+	response, err := toolsets.FormatJSONResponse(ctx, summaries)
+	if err != nil {
+		return textResult(err.Error(), true), nil
+	}
+
+	return textResult(fmt.Sprintf("Toolset %s provides %d tools:\n\n%s", name, len(summaries), response), false), nil
+}
+
+// handleEnableToolset handles the enable_toolset meta-tool
+func (s *Server) handleEnableToolset(ctx context.Context, params mcp.CallToolParams) (*mcp.CallToolResult, error) {
+	name, err := toolsets.GetStringArgument(params.Arguments, "toolset", true)
+	if err != nil {
+		return textResult(err.Error(), true), nil
+	}
+
+	if profile := sessionAccessProfile(ctx); !profile.AllowsToolset(name) {
+		return textResult(fmt.Sprintf("toolset %s is not permitted by access profile %s", name, profile.Name), true), nil
+	}
+
+	// Only toolsets in the configured allow-list may be enabled
+	if !s.isAvailableToolset(name) {
+		return textResult(fmt.Sprintf("toolset %s is not available", name), true), nil
+	}
+
+	toolset, enabled, err := s.enableToolset(name)
+	if err != nil {
+		return textResult(err.Error(), true), nil
+	}
+
+	if !enabled {
+		return textResult(fmt.Sprintf("Toolset %s is already enabled", name), false), nil
+	}
+
+	s.mcpServer.NotifyToolsListChanged(ctx)
+
+	s.logger.Info("Enabled toolset at runtime",
+		zap.String("toolset", name),
+		zap.Int("tools", len(toolset.GetTools())))
+
+	return textResult(fmt.Sprintf("Enabled toolset %s with %d tools", name, len(s.visibleTools(toolset))), false), nil
+}
+
+// visibleTools returns the tools of a toolset that clients can see, leaving out tools removed
+// by the tool filter and mutating tools in read-only mode
+func (s *Server) visibleTools(toolset toolsets.Toolset) []mcp.Tool {
+	tools := toolset.GetTools()
+
+	visible := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if allowed, _ := s.toolFilter.Allows(tool.Name); !allowed {
+			continue
+		}
+		if s.config.ReadOnly && !tool.IsReadOnly() {
+			continue
+		}
+		visible = append(visible, tool)
+	}
+	return visible
+}
+
+// availableToolsets returns the toolsets a client may enable, which is the configured
+// allow-list with "all" expanded
+func (s *Server) availableToolsets() []string {
+	names := append([]string(nil), s.getEnabledToolsets()...)
+	sort.Strings(names)
+	return names
+}
+
+// isAvailableToolset reports whether name is in the configured allow-list
+func (s *Server) isAvailableToolset(name string) bool {
+	for _, available := range s.getEnabledToolsets() {
+		if available == name {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupToolset returns the enabled instance of a toolset, or a fresh instance for inspection
+// if it has not been enabled yet
+func (s *Server) lookupToolset(name string) (toolsets.Toolset, bool, error) {
+	if !s.isAvailableToolset(name) {
+		return nil, false, fmt.Errorf("toolset %s is not available", name)
+	}
+
+	s.toolsetsMu.RLock()
+	toolset, enabled := s.toolsets[name]
+	s.toolsetsMu.RUnlock()
+
+	if enabled {
+		return toolset, true, nil
+	}
+
+	toolset, err := s.factory.CreateToolset(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return toolset, false, nil
+}
+
+// textResult wraps text in a tool result
+func textResult(text string, isError bool) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.ToolContent{
+			{
+				Type: "text",
+				Text: text,
+			},
+		},
+		IsError: isError,
+	}
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEnableToolsetFollowsAllowList tests that in dynamic mode a client can enable only the
+// toolsets in the configured allow-list
+func TestEnableToolsetFollowsAllowList(t *testing.T) {
+	s, _ := newAuditedServer(t, &fakeJamfClient{})
+	enableTestToolsets(t, s)
+	s.config.Toolsets = []string{"policies"}
+	s.config.DynamicToolsets = true
+	s.registerDynamicToolsetTools()
+	ctx := newTestSession(t, s, nil)
+
+	response := callTool(t, s, ctx, "enable_toolset", map[string]interface{}{"toolset": "computers"})
+	require.Nil(t, response.Error)
+	result, ok := response.Result.(*mcp.CallToolResult)
+	require.True(t, ok)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "toolset computers is not available", result.Content[0].Text)
+
+	tools := listTools(t, s, ctx)
+	assert.NotContains(t, tools, "get_computers")
+	assert.NotContains(t, tools, "delete_computer_by_id")
+	assert.NotContains(t, s.toolsets, "computers")
+
+	response = callTool(t, s, ctx, "enable_toolset", map[string]interface{}{"toolset": "policies"})
+	require.Nil(t, response.Error)
+	result, ok = response.Result.(*mcp.CallToolResult)
+	require.True(t, ok)
+	assert.False(t, result.IsError)
+	assert.Contains(t, listTools(t, s, ctx), "get_policies")
+}
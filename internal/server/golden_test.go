@@ -0,0 +1,132 @@
+package server
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/config"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// offlineJamfTransport issues OAuth2 tokens and answers every other Jamf Pro request with
+// 404 Not Found, so New can build its clients without a network
+type offlineJamfTransport struct{}
+
+func (offlineJamfTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	status, body := http.StatusNotFound, ""
+	if req.URL.Path == oauthTokenPath {
+		status, body = http.StatusOK, `{"access_token":"token","expires_in":3600,"token_type":"Bearer"}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// newDefaultServer builds a server with New from the default configuration, given only the
+// primary instance's connection settings and whatever configure changes
+func newDefaultServer(t *testing.T, configure func(cfg *config.Config)) *Server {
+	t.Helper()
+
+	cfg, err := config.Default()
+	require.NoError(t, err)
+	cfg.JamfInstanceURL = "https://example.jamfcloud.com"
+	cfg.JamfClientID = "client-id"
+	cfg.JamfClientSecret = "client-secret"
+	cfg.JamfTransport = offlineJamfTransport{}
+	if configure != nil {
+		configure(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+
+	s, err := New(cfg, zap.NewNop())
+	require.NoError(t, err)
+	return s
+}
+
+// listAllToolsJSON returns every page of the server's tools/list response, joined into one
+// result, as indented JSON
+func listAllToolsJSON(t *testing.T, s *Server) []byte {
+	t.Helper()
+
+	ctx := newTestSession(t, s, nil)
+	all := &mcp.ListToolsResult{Tools: []mcp.Tool{}}
+	cursor := ""
+	for {
+		response, err := s.mcpServer.HandleMessage(ctx, &mcp.Message{JSONRPC: "2.0", ID: 1, Method: "tools/list", Params: map[string]interface{}{"cursor": cursor}})
+		require.NoError(t, err)
+		require.Nil(t, response.Error)
+
+		result, ok := response.Result.(*mcp.ListToolsResult)
+		require.True(t, ok)
+		all.Tools = append(all.Tools, result.Tools...)
+		if result.NextCursor == nil {
+			break
+		}
+		cursor = *result.NextCursor
+	}
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	require.NoError(t, err)
+	return append(data, '\n')
+}
+
+// goldenServers configures the servers whose tools/list output is kept in testdata
+var goldenServers = []struct {
+	name      string
+	configure func(cfg *config.Config)
+}{
+	{name: "tools_list"},
+	{name: "tools_list_instances", configure: func(cfg *config.Config) {
+		cfg.JamfInstances = map[string]config.JamfInstanceConfig{
+			"eu": {JamfInstanceURL: "https://eu.jamfcloud.com", JamfClientID: "client-id", JamfClientSecret: "client-secret"},
+		}
+	}},
+	{name: "tools_list_dynamic", configure: func(cfg *config.Config) {
+		cfg.DynamicToolsets = true
+	}},
+}
+
+// TestToolsListGolden tests the full tools/list output of servers built with New against the
+// files in testdata. Run the test with -update after an intended change to a tool definition.
+func TestToolsListGolden(t *testing.T) {
+	for _, tt := range goldenServers {
+		t.Run(tt.name, func(t *testing.T) {
+			golden := filepath.Join("testdata", tt.name+".golden.json")
+			actual := listAllToolsJSON(t, newDefaultServer(t, tt.configure))
+
+			if *updateGolden {
+				require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+				require.NoError(t, os.WriteFile(golden, actual, 0o644))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err, "run go test ./internal/server -run TestToolsListGolden -update to create it")
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
+
+// TestToolsListIsDeterministic tests that two servers built from the same configuration list
+// their tools identically
+func TestToolsListIsDeterministic(t *testing.T) {
+	configure := goldenServers[1].configure
+	assert.Equal(t, string(listAllToolsJSON(t, newDefaultServer(t, configure))), string(listAllToolsJSON(t, newDefaultServer(t, configure))))
+}
@@ -0,0 +1,495 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"go.uber.org/zap"
+)
+
+const (
+	// mcpSessionIDHeader carries the session identifier on every request after initialize
+	mcpSessionIDHeader = "Mcp-Session-Id"
+
+	// maxHTTPRequestBytes caps the size of a single POSTed JSON-RPC payload
+	maxHTTPRequestBytes = 4 << 20
+
+	// httpShutdownTimeout bounds how long in-flight requests get to finish on shutdown
+	httpShutdownTimeout = 10 * time.Second
+)
+
+// errNotificationStreamClosed is returned to a session notifying a stream that has ended
+var errNotificationStreamClosed = errors.New("notification stream closed")
+
+// serveHTTP serves the MCP Streamable HTTP transport until ctx is cancelled
+func (s *Server) serveHTTP(ctx context.Context) error {
+	// Long-lived notification streams are closed on shutdown rather than waited for
+	streamCtx, closeStreams := context.WithCancel(ctx)
+	defer closeStreams()
+
+	mux := http.NewServeMux()
+	mux.Handle(s.config.HTTPEndpointPath, s.httpHandler(streamCtx))
+
+	if idle := s.httpSessions.idleTimeout; idle > 0 {
+		go s.httpSessions.expire(streamCtx, min(idle, time.Minute))
+	}
+
+	httpServer := &http.Server{
+		Addr:              s.config.HTTPListenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	httpServer.RegisterOnShutdown(closeStreams)
+
+	errChan := make(chan error, 1)
+	go func() {
+		s.logger.Info("Listening for MCP HTTP connections",
+			zap.String("address", s.config.HTTPListenAddress),
+			zap.String("path", s.config.HTTPEndpointPath))
+		errChan <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to shut down http server: %w", err)
+		}
+		return nil
+	case err := <-errChan:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("http server error: %w", err)
+	}
+}
+
+// httpHandler returns the handler for the single MCP endpoint. Notification streams opened
+// with GET end when streamCtx is cancelled.
+func (s *Server) httpHandler(streamCtx context.Context) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.isAllowedOrigin(r) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			s.handleHTTPPost(w, r)
+		case http.MethodGet:
+			s.handleHTTPGet(streamCtx, w, r)
+		case http.MethodDelete:
+			s.handleHTTPDelete(w, r)
+		default:
+			w.Header().Set("Allow", "GET, POST, DELETE")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// handleHTTPPost dispatches one JSON-RPC message, or a batch of them, through the shared MCP server
+func (s *Server) handleHTTPPost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxHTTPRequestBytes))
+	if err != nil {
+		writeHTTPError(w, http.StatusRequestEntityTooLarge, mcp.InvalidRequest, "failed to read request body")
+		return
+	}
+
+	s.wireLogger.Debug("Received message", zap.String("message", string(body)))
+
+	messages, batch, err := decodeHTTPMessages(body)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, mcp.ParseError, err.Error())
+		return
+	}
+
+	session, status, err := s.resolveHTTPSession(w, r, messages)
+	if err != nil {
+		writeHTTPError(w, status, mcp.InvalidRequest, err.Error())
+		return
+	}
+	defer s.httpSessions.release(session.ID())
+
+	ctx := mcp.ContextWithSession(r.Context(), session)
+
+	// Notifications and client responses are acknowledged without a body
+	var requests []*mcp.Message
+	for _, msg := range messages {
+		if msg.ID == nil || msg.Method == "" {
+			if msg.Method != "" {
+				if _, err := s.mcpServer.HandleMessage(ctx, msg); err != nil {
+					s.logger.Error("Failed to handle notification", zap.String("method", msg.Method), zap.Error(err), mcp.SessionField(ctx))
+				}
+			}
+			continue
+		}
+		requests = append(requests, msg)
+	}
+
+	if len(requests) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	if acceptsMediaType(r, "text/event-stream") {
+		s.streamHTTPResponses(ctx, w, requests)
+		return
+	}
+
+	responses := make([]*mcp.Message, 0, len(requests))
+	for _, msg := range requests {
+		if response := s.handleHTTPRequest(ctx, msg); response != nil {
+			responses = append(responses, response)
+		}
+	}
+
+	// Every request was cancelled by the client
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	var payload interface{} = responses[0]
+	if batch {
+		payload = responses
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, mcp.InternalError, "failed to marshal response")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		s.wireLogger.Error("Failed to write response", zap.Error(err))
+		return
+	}
+
+	s.wireLogger.Debug("Sent message", zap.String("message", string(data)))
+}
+
+// streamHTTPResponses writes notifications about the requests and then their responses as
+// server-sent events, closing the stream when every request has been answered
+func (s *Server) streamHTTPResponses(ctx context.Context, w http.ResponseWriter, requests []*mcp.Message) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeHTTPError(w, http.StatusInternalServerError, mcp.InternalError, "streaming not supported")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	stream := &sseWriter{w: w, flusher: flusher}
+
+	// Progress and other request-scoped notifications travel on this stream
+	ctx = mcp.ContextWithNotifier(ctx, func(msg *mcp.Message) error {
+		return s.writeEvent(stream, msg)
+	})
+
+	for _, msg := range requests {
+		response := s.handleHTTPRequest(ctx, msg)
+		if response == nil {
+			continue
+		}
+
+		if err := s.writeEvent(stream, response); err != nil {
+			s.wireLogger.Error("Failed to write event", zap.Error(err))
+			return
+		}
+	}
+}
+
+// writeEvent writes a message to an event stream and logs it
+func (s *Server) writeEvent(stream *sseWriter, msg *mcp.Message) error {
+	data, err := stream.WriteMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	s.wireLogger.Debug("Sent message", zap.String("message", string(data)))
+	return nil
+}
+
+// sseWriter serialises JSON-RPC messages onto a server-sent event stream
+type sseWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	flusher http.Flusher
+}
+
+// WriteMessage writes msg as a single "message" event and flushes it to the client
+func (sw *sseWriter) WriteMessage(msg *mcp.Message) ([]byte, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	if _, err := fmt.Fprintf(sw.w, "event: message\ndata: %s\n\n", data); err != nil {
+		return nil, fmt.Errorf("failed to write event: %w", err)
+	}
+	sw.flusher.Flush()
+
+	return data, nil
+}
+
+// handleHTTPRequest runs a single request through the MCP server, converting failures into error responses
+func (s *Server) handleHTTPRequest(ctx context.Context, msg *mcp.Message) *mcp.Message {
+	response, err := s.mcpServer.HandleMessage(ctx, msg)
+	if err != nil {
+		s.logger.Error("Failed to handle message", zap.Error(err), mcp.SessionField(ctx))
+		return &mcp.Message{
+			JSONRPC: "2.0",
+			ID:      msg.ID,
+			Error: &mcp.Error{
+				Code:    mcp.InternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+	return response
+}
+
+// handleHTTPGet opens a server-sent event stream on which the session receives notifications
+// that are not tied to a request, such as notifications/tools/list_changed
+func (s *Server) handleHTTPGet(streamCtx context.Context, w http.ResponseWriter, r *http.Request) {
+	if !acceptsMediaType(r, "text/event-stream") {
+		http.Error(w, "GET requires Accept: text/event-stream", http.StatusNotAcceptable)
+		return
+	}
+
+	sessionID := r.Header.Get(mcpSessionIDHeader)
+	if sessionID == "" {
+		http.Error(w, "missing "+mcpSessionIDHeader+" header", http.StatusBadRequest)
+		return
+	}
+
+	session, ok := s.httpSessions.acquire(sessionID)
+	if !ok {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+	defer s.httpSessions.release(sessionID)
+
+	if status, err := s.authorizeHTTPSession(w, r, session); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	stream := &sseWriter{w: w, flusher: flusher}
+
+	// Notifications are handed to this handler, which alone writes to the stream, so nothing
+	// writes to w after the handler returns
+	events := make(chan *mcp.Message)
+	closed := make(chan struct{})
+	defer close(closed)
+
+	// A newer stream for the same session replaces this one
+	release := session.SetNotifier(func(msg *mcp.Message) error {
+		select {
+		case events <- msg:
+			return nil
+		case <-closed:
+			return errNotificationStreamClosed
+		}
+	})
+	defer release()
+
+	s.logger.Debug("Opened notification stream", zap.String("session_id", sessionID))
+	defer s.logger.Debug("Closed notification stream", zap.String("session_id", sessionID))
+
+	for {
+		select {
+		case msg := <-events:
+			if err := s.writeEvent(stream, msg); err != nil {
+				s.wireLogger.Error("Failed to write event", zap.Error(err))
+				return
+			}
+		case <-r.Context().Done():
+			return
+		case <-session.Done():
+			return
+		case <-streamCtx.Done():
+			return
+		}
+	}
+}
+
+// handleHTTPDelete terminates the session named in the Mcp-Session-Id header
+func (s *Server) handleHTTPDelete(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.Header.Get(mcpSessionIDHeader)
+	if sessionID == "" {
+		http.Error(w, "missing "+mcpSessionIDHeader+" header", http.StatusBadRequest)
+		return
+	}
+
+	session, ok := s.httpSessions.acquire(sessionID)
+	if !ok {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+	defer s.httpSessions.release(sessionID)
+
+	if status, err := s.authorizeHTTPSession(w, r, session); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	s.httpSessions.close(sessionID)
+	s.logger.Info("Closed MCP session", zap.String("session_id", sessionID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// resolveHTTPSession creates a session for initialize requests and looks up the existing one
+// otherwise. The session is marked in use, and the caller releases it once the request is done.
+func (s *Server) resolveHTTPSession(w http.ResponseWriter, r *http.Request, messages []*mcp.Message) (*mcp.Session, int, error) {
+	for _, msg := range messages {
+		if msg.Method != "initialize" {
+			continue
+		}
+
+		if len(messages) > 1 {
+			return nil, http.StatusBadRequest, fmt.Errorf("initialize request must not be part of a batch")
+		}
+
+		// Resolve the bearer token before creating a session so that bad tokens leave nothing behind
+		profile, err := s.bearerAccessProfile(r)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			return nil, http.StatusUnauthorized, err
+		}
+
+		session, err := s.httpSessions.create()
+		if errors.Is(err, errTooManySessions) {
+			return nil, http.StatusServiceUnavailable, err
+		}
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		if profile != nil {
+			session.SetAccessProfile(profile)
+		}
+
+		w.Header().Set(mcpSessionIDHeader, session.ID())
+		s.logger.Info("Created MCP session", zap.String("session_id", session.ID()))
+		return session, http.StatusOK, nil
+	}
+
+	sessionID := r.Header.Get(mcpSessionIDHeader)
+	if sessionID == "" {
+		return nil, http.StatusBadRequest, fmt.Errorf("missing %s header", mcpSessionIDHeader)
+	}
+
+	session, ok := s.httpSessions.acquire(sessionID)
+	if !ok {
+		return nil, http.StatusNotFound, fmt.Errorf("session not found: %s", sessionID)
+	}
+
+	if status, err := s.authorizeHTTPSession(w, r, session); err != nil {
+		s.httpSessions.release(sessionID)
+		return nil, status, err
+	}
+
+	return session, http.StatusOK, nil
+}
+
+// isAllowedOrigin guards against DNS rebinding by validating the Origin header of browser requests
+func (s *Server) isAllowedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	if len(s.config.HTTPAllowedOrigins) > 0 {
+		for _, allowed := range s.config.HTTPAllowedOrigins {
+			if allowed == "*" || strings.EqualFold(allowed, origin) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Without an explicit allow-list only same-origin requests are accepted
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// decodeHTTPMessages decodes a single JSON-RPC message or a batch array
+func decodeHTTPMessages(body []byte) ([]*mcp.Message, bool, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil, false, fmt.Errorf("empty request body")
+	}
+
+	if trimmed[0] == '[' {
+		var messages []*mcp.Message
+		if err := json.Unmarshal(trimmed, &messages); err != nil {
+			return nil, true, fmt.Errorf("failed to unmarshal message batch: %w", err)
+		}
+		if len(messages) == 0 {
+			return nil, true, fmt.Errorf("empty message batch")
+		}
+		return messages, true, nil
+	}
+
+	var msg mcp.Message
+	if err := json.Unmarshal(trimmed, &msg); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+
+	return []*mcp.Message{&msg}, false, nil
+}
+
+// acceptsMediaType reports whether the request's Accept header lists the given media type
+func acceptsMediaType(r *http.Request, mediaType string) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			if strings.EqualFold(strings.TrimSpace(strings.Split(part, ";")[0]), mediaType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeHTTPError writes a JSON-RPC error object with the given HTTP status
+func writeHTTPError(w http.ResponseWriter, status int, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&mcp.Message{
+		JSONRPC: "2.0",
+		Error: &mcp.Error{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
@@ -0,0 +1,254 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/config"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newHTTPTest serves the MCP endpoint of a bare server with one tool that reports progress
+func newHTTPTest(t *testing.T, cfg *config.Config) (*Server, *httptest.Server) {
+	t.Helper()
+
+	cfg.HTTPEndpointPath = "/mcp"
+	mcpServer := mcp.NewServer("test-server", "1.0.0")
+	s := &Server{
+		config:       cfg,
+		mcpServer:    mcpServer,
+		logger:       zap.NewNop(),
+		wireLogger:   zap.NewNop(),
+		httpSessions: newHTTPSessionTracker(mcpServer, zap.NewNop(), time.Duration(cfg.HTTPSessionIdleSeconds)*time.Second, cfg.HTTPMaxSessions),
+	}
+
+	mcpServer.RegisterToolDefinition(&mcp.Tool{Name: "progress_tool"})
+	mcpServer.RegisterTool("progress_tool", func(ctx context.Context, params mcp.CallToolParams) (*mcp.CallToolResult, error) {
+		mcp.ReportProgress(ctx, 1, 1, "done")
+		return &mcp.CallToolResult{Content: []mcp.ToolContent{{Type: "text", Text: "ok"}}}, nil
+	})
+
+	streamCtx, closeStreams := context.WithCancel(context.Background())
+	httpServer := httptest.NewServer(s.httpHandler(streamCtx))
+	t.Cleanup(func() {
+		closeStreams()
+		httpServer.Close()
+	})
+
+	return s, httpServer
+}
+
+// postMessage POSTs a JSON-RPC message to the endpoint with the given headers
+func postMessage(t *testing.T, httpServer *httptest.Server, body string, headers map[string]string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, httpServer.URL+"/mcp", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// sendHTTPRequest sends a body-less request with the given method and session
+func sendHTTPRequest(t *testing.T, httpServer *httptest.Server, method, sessionID string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, httpServer.URL+"/mcp", nil)
+	require.NoError(t, err)
+	req.Header.Set(mcpSessionIDHeader, sessionID)
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// initializeHTTPSession sends initialize and returns the new session ID
+func initializeHTTPSession(t *testing.T, httpServer *httptest.Server) string {
+	t.Helper()
+
+	resp := postMessage(t, httpServer, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	sessionID := resp.Header.Get(mcpSessionIDHeader)
+	require.NotEmpty(t, sessionID)
+	return sessionID
+}
+
+// readEvent reads the data of the next server-sent event
+func readEvent(t *testing.T, reader *bufio.Reader) *mcp.Message {
+	t.Helper()
+
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		if data, ok := strings.CutPrefix(strings.TrimSpace(line), "data: "); ok {
+			var msg mcp.Message
+			require.NoError(t, json.Unmarshal([]byte(data), &msg))
+			return &msg
+		}
+	}
+}
+
+// TestHTTPSessionLifecycle tests that initialize issues a session ID that later requests must
+// send, and that DELETE ends the session
+func TestHTTPSessionLifecycle(t *testing.T) {
+	_, httpServer := newHTTPTest(t, &config.Config{})
+	sessionID := initializeHTTPSession(t, httpServer)
+
+	resp := postMessage(t, httpServer, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`, nil)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = postMessage(t, httpServer, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`, map[string]string{mcpSessionIDHeader: sessionID})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var response mcp.Message
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, float64(2), response.ID)
+	assert.Nil(t, response.Error)
+
+	resp = postMessage(t, httpServer, `{"jsonrpc":"2.0","method":"notifications/initialized"}`, map[string]string{mcpSessionIDHeader: sessionID})
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	resp = sendHTTPRequest(t, httpServer, http.MethodDelete, sessionID)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = postMessage(t, httpServer, `{"jsonrpc":"2.0","id":3,"method":"tools/list"}`, map[string]string{mcpSessionIDHeader: sessionID})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+// TestHTTPUnknownSession tests that every method answers 404 for a session that does not exist
+func TestHTTPUnknownSession(t *testing.T) {
+	_, httpServer := newHTTPTest(t, &config.Config{})
+
+	resp := postMessage(t, httpServer, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`, map[string]string{mcpSessionIDHeader: "unknown"})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, http.StatusNotFound, sendHTTPRequest(t, httpServer, http.MethodGet, "unknown").StatusCode)
+	assert.Equal(t, http.StatusNotFound, sendHTTPRequest(t, httpServer, http.MethodDelete, "unknown").StatusCode)
+}
+
+// TestHTTPStreamsResponses tests that a client accepting event streams receives the progress of
+// its request and then the response as server-sent events
+func TestHTTPStreamsResponses(t *testing.T) {
+	_, httpServer := newHTTPTest(t, &config.Config{})
+	sessionID := initializeHTTPSession(t, httpServer)
+
+	resp := postMessage(t, httpServer,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"progress_tool","_meta":{"progressToken":"p"}}}`,
+		map[string]string{mcpSessionIDHeader: sessionID, "Accept": "application/json, text/event-stream"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	assert.Equal(t, mcp.NotificationProgress, readEvent(t, reader).Method)
+	response := readEvent(t, reader)
+	assert.Equal(t, float64(2), response.ID)
+	assert.Nil(t, response.Error)
+}
+
+// TestHTTPNotificationStream tests that a GET stream receives notifications that are not tied
+// to a request, and that the session is released when the client goes away
+func TestHTTPNotificationStream(t *testing.T) {
+	s, httpServer := newHTTPTest(t, &config.Config{})
+	sessionID := initializeHTTPSession(t, httpServer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL+"/mcp", nil)
+	require.NoError(t, err)
+	req.Header.Set(mcpSessionIDHeader, sessionID)
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The stream is open once the session has a notifier; until then notifications are dropped
+	reader := bufio.NewReader(resp.Body)
+	received := make(chan *mcp.Message, 1)
+	go func() {
+		line, err := reader.ReadString('\n')
+		for err == nil && !strings.HasPrefix(line, "data: ") {
+			line, err = reader.ReadString('\n')
+		}
+		var msg mcp.Message
+		if err == nil && json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "data: ")), &msg) == nil {
+			received <- &msg
+		}
+	}()
+	require.Eventually(t, func() bool {
+		s.mcpServer.NotifyToolsListChanged(context.Background())
+		select {
+		case msg := <-received:
+			assert.Equal(t, mcp.NotificationToolsListChanged, msg.Method)
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, 20*time.Millisecond)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		s.httpSessions.mu.Lock()
+		defer s.httpSessions.mu.Unlock()
+		return s.httpSessions.sessions[sessionID].active == 0
+	}, time.Second, 10*time.Millisecond)
+
+	// Notifications after the stream has ended are not written to it
+	s.mcpServer.NotifyToolsListChanged(context.Background())
+}
+
+// TestHTTPOriginCheck tests that browser origins other than the server's own are rejected
+// unless they are allowed
+func TestHTTPOriginCheck(t *testing.T) {
+	_, httpServer := newHTTPTest(t, &config.Config{})
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`
+
+	resp := postMessage(t, httpServer, initialize, map[string]string{"Origin": "https://evil.example.com"})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = postMessage(t, httpServer, initialize, map[string]string{"Origin": httpServer.URL})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, allowingServer := newHTTPTest(t, &config.Config{HTTPAllowedOrigins: []string{"https://app.example.com"}})
+	resp = postMessage(t, allowingServer, initialize, map[string]string{"Origin": "https://app.example.com"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = postMessage(t, allowingServer, initialize, map[string]string{"Origin": allowingServer.URL})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+// TestHTTPSessionLimits tests that sessions beyond the maximum are refused and that idle
+// sessions are closed and no longer count towards it
+func TestHTTPSessionLimits(t *testing.T) {
+	s, httpServer := newHTTPTest(t, &config.Config{HTTPSessionIdleSeconds: 60, HTTPMaxSessions: 1})
+	now := time.Now()
+	s.httpSessions.now = func() time.Time { return now }
+
+	sessionID := initializeHTTPSession(t, httpServer)
+	resp := postMessage(t, httpServer, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	now = now.Add(2 * time.Minute)
+	replacementID := initializeHTTPSession(t, httpServer)
+	assert.NotEqual(t, sessionID, replacementID)
+
+	_, ok := s.mcpServer.GetSession(sessionID)
+	assert.False(t, ok, "the idle session is closed")
+	resp = postMessage(t, httpServer, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`, map[string]string{mcpSessionIDHeader: sessionID})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
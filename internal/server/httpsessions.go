@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"go.uber.org/zap"
+)
+
+// errTooManySessions is returned when a new HTTP session would exceed the configured maximum
+var errTooManySessions = errors.New("too many open sessions, try again later")
+
+// httpSessionState is what the tracker knows about one HTTP session
+type httpSessionState struct {
+	session  *mcp.Session
+	lastUsed time.Time
+
+	// active counts the requests and notification streams currently using the session
+	active int
+}
+
+// httpSessionTracker caps the number of open HTTP sessions and closes the ones no client has
+// used for longer than the idle timeout. A session with a request in progress or an open
+// notification stream is never idle.
+type httpSessionTracker struct {
+	mcpServer   *mcp.Server
+	logger      *zap.Logger
+	idleTimeout time.Duration
+	maxSessions int
+	now         func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*httpSessionState
+}
+
+// newHTTPSessionTracker creates a tracker. A zero idleTimeout or maxSessions disables that limit.
+func newHTTPSessionTracker(mcpServer *mcp.Server, logger *zap.Logger, idleTimeout time.Duration, maxSessions int) *httpSessionTracker {
+	return &httpSessionTracker{
+		mcpServer:   mcpServer,
+		logger:      logger,
+		idleTimeout: idleTimeout,
+		maxSessions: maxSessions,
+		now:         time.Now,
+		sessions:    make(map[string]*httpSessionState),
+	}
+}
+
+// create opens a session and marks it in use until release is called with its ID. Idle
+// sessions are closed first so that they do not count towards the maximum.
+func (t *httpSessionTracker) create() (*mcp.Session, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.expireLocked()
+	if t.maxSessions > 0 && len(t.sessions) >= t.maxSessions {
+		return nil, errTooManySessions
+	}
+
+	session, err := t.mcpServer.CreateSession()
+	if err != nil {
+		return nil, err
+	}
+
+	t.sessions[session.ID()] = &httpSessionState{session: session, lastUsed: t.now(), active: 1}
+	return session, nil
+}
+
+// acquire returns the open session with the given ID and marks it in use until release is called
+func (t *httpSessionTracker) acquire(id string) (*mcp.Session, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.sessions[id]
+	if !ok {
+		return nil, false
+	}
+
+	state.active++
+	state.lastUsed = t.now()
+	return state.session, true
+}
+
+// release marks the end of a request or stream started with create or acquire
+func (t *httpSessionTracker) release(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if state, ok := t.sessions[id]; ok {
+		state.active--
+		state.lastUsed = t.now()
+	}
+}
+
+// close closes the session with the given ID
+func (t *httpSessionTracker) close(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.sessions, id)
+	t.mcpServer.CloseSession(id)
+}
+
+// expire closes idle sessions every interval until ctx is cancelled
+func (t *httpSessionTracker) expire(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.mu.Lock()
+			t.expireLocked()
+			t.mu.Unlock()
+		}
+	}
+}
+
+// expireLocked closes the sessions that have been idle for longer than the idle timeout
+func (t *httpSessionTracker) expireLocked() {
+	if t.idleTimeout <= 0 {
+		return
+	}
+
+	cutoff := t.now().Add(-t.idleTimeout)
+	for id, state := range t.sessions {
+		if state.active > 0 || state.lastUsed.After(cutoff) {
+			continue
+		}
+
+		delete(t.sessions, id)
+		t.mcpServer.CloseSession(id)
+		t.logger.Info("Closed idle MCP session", zap.String("session_id", id))
+	}
+}
@@ -0,0 +1,275 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/config"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/toolsets"
+	"go.uber.org/zap"
+)
+
+// instanceArgument selects the Jamf Pro instance a tool call runs against. It is added to
+// every tool's schema when more than one instance is configured.
+const instanceArgument = "instance"
+
+// toolListJamfInstances lists the configured Jamf Pro instances
+const toolListJamfInstances = "list_jamf_instances"
+
+// jamfInstance is a configured Jamf Pro instance and the client toolsets use for it
+type jamfInstance struct {
+	name       string
+	url        string
+	authMethod string
+	site       string
+	client     toolsets.JamfProClient
+}
+
+// instanceSummary describes an instance in list_jamf_instances output
+type instanceSummary struct {
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	AuthMethod string `json:"auth_method"`
+	Site       string `json:"site,omitempty"`
+	Primary    bool   `json:"primary"`
+}
+
+// initializeInstances builds a Jamf Pro client for the primary instance and every additional
+// instance
+func (s *Server) initializeInstances() error {
+	for name, instanceCfg := range s.config.Instances() {
+		client, err := buildJamfClient(s.config, name, s.logger)
+		if err != nil {
+			return fmt.Errorf("instance %s: %w", name, err)
+		}
+
+		instance := &jamfInstance{
+			name:       name,
+			url:        instanceCfg.JamfInstanceURL,
+			authMethod: instanceCfg.AuthMethod,
+			site:       instanceCfg.Site,
+			client:     client,
+		}
+
+		if instanceCfg.Site != "" {
+			s.logger.Info("Restricting instance to site",
+				zap.String("instance", name),
+				zap.String("site", instanceCfg.Site))
+			instance.client = toolsets.NewSiteScopedClient(client, toolsets.ParseSite(instanceCfg.Site))
+		}
+
+		s.instances[name] = instance
+	}
+
+	return nil
+}
+
+// buildJamfClient builds the Jamf Pro client of one instance directly from the configuration,
+// without going through process environment variables
+func buildJamfClient(cfg *config.Config, name string, logger *zap.Logger) (*jamfpro.Client, error) {
+	container, err := cfg.GetInstanceClientConfig(context.Background(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Secrets read from files or commands are read again on every token request
+	transport := cfg.JamfTransport
+	if instance := cfg.Instances()[name]; instance.Secret().Dynamic() {
+		transport = newSecretRefreshingTransport(transport, instance, logger)
+	}
+
+	logger.Info("Initializing Jamf Pro client",
+		zap.String("instance", name),
+		zap.String("instance_url", container.InstanceDomain),
+		zap.String("auth_method", container.AuthMethod),
+		zap.Bool("custom_transport", cfg.JamfTransport != nil),
+		zap.Bool("refreshing_secret", transport != cfg.JamfTransport),
+	)
+
+	if !isValidURL(container.InstanceDomain) {
+		return nil, fmt.Errorf("invalid Jamf instance URL format: %s", container.InstanceDomain)
+	}
+
+	client, err := newJamfProClient(container, transport, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build Jamf Pro client: %w", err)
+	}
+
+	// Test the connection
+	logger.Info("Testing Jamf Pro connection", zap.String("instance", name))
+	if _, err := client.GetJamfProInformation(); err != nil {
+		logger.Warn("Failed to test Jamf Pro connection, but continuing",
+			zap.String("instance", name),
+			zap.Error(err))
+		// Don't fail here as the connection might work for other operations
+	} else {
+		logger.Info("Successfully connected to Jamf Pro", zap.String("instance", name))
+	}
+
+	return client, nil
+}
+
+// isValidURL validates URL format
+func isValidURL(str string) bool {
+	u, err := url.Parse(str)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// multipleInstances reports whether tools take an instance argument
+func (s *Server) multipleInstances() bool {
+	return len(s.instances) > 1
+}
+
+// instanceNames returns the configured instance names, primary first and the rest sorted
+func (s *Server) instanceNames() []string {
+	names := make([]string, 0, len(s.instances))
+	for name := range s.instances {
+		if name != s.config.PrimaryInstance {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return append([]string{s.config.PrimaryInstance}, names...)
+}
+
+// requestedInstance returns the instance name a call asks for, defaulting to the primary
+func (s *Server) requestedInstance(arguments map[string]interface{}) string {
+	if name, _ := toolsets.GetStringArgument(arguments, instanceArgument, false); name != "" {
+		return strings.ToLower(name)
+	}
+	return s.config.PrimaryInstance
+}
+
+// instanceForCall returns the instance a tool call runs against
+func (s *Server) instanceForCall(arguments map[string]interface{}) (*jamfInstance, error) {
+	name := s.requestedInstance(arguments)
+	instance, ok := s.instances[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown Jamf Pro instance %q; use %s to see the configured instances", name, toolListJamfInstances)
+	}
+	return instance, nil
+}
+
+// withInstanceArgument adds the instance argument to a tool's schema when more than one
+// instance is configured. The argument is left in the call arguments so that confirmation
+// tokens stay bound to the instance they were issued for.
+func (s *Server) withInstanceArgument(tool mcp.Tool) mcp.Tool {
+	if !s.multipleInstances() {
+		return tool
+	}
+
+	properties := make(map[string]interface{}, len(tool.InputSchema.Properties)+1)
+	for key, value := range tool.InputSchema.Properties {
+		properties[key] = value
+	}
+	properties[instanceArgument] = map[string]interface{}{
+		"type":        "string",
+		"enum":        s.instanceNames(),
+		"description": fmt.Sprintf("Jamf Pro instance to use. Defaults to %s.", s.config.PrimaryInstance),
+	}
+
+	tool.InputSchema.Properties = properties
+	return tool
+}
+
+// registerInstanceTools registers list_jamf_instances when more than one instance is configured
+func (s *Server) registerInstanceTools() {
+	if !s.multipleInstances() {
+		return
+	}
+
+	tool := mcp.Tool{
+		Name:        toolListJamfInstances,
+		Description: "List the Jamf Pro instances that tools can be run against with the instance argument",
+		Access:      mcp.ToolAccessRead,
+		Annotations: mcp.ReadOnlyAnnotations("List Jamf Pro Instances"),
+		InputSchema: mcp.ToolInputSchema{
+			Type:       "object",
+			Properties: map[string]interface{}{},
+			Required:   []string{},
+		},
+	}
+
+	s.registerServerTool(tool, s.handleListJamfInstances)
+	s.logger.Info("Multiple Jamf Pro instances configured",
+		zap.Strings("instances", s.instanceNames()),
+		zap.String("primary_instance", s.config.PrimaryInstance))
+}
+
+// handleListJamfInstances handles the list_jamf_instances tool
+func (s *Server) handleListJamfInstances(ctx context.Context, params mcp.CallToolParams) (*mcp.CallToolResult, error) {
+	summaries := make([]instanceSummary, 0, len(s.instances))
+	for _, name := range s.instanceNames() {
+		instance := s.instances[name]
+		summaries = append(summaries, instanceSummary{
+			Name:       instance.name,
+			URL:        instance.url,
+			AuthMethod: instance.authMethod,
+			Site:       instance.site,
+			Primary:    name == s.config.PrimaryInstance,
+		})
+	}
+
+	response, err := toolsets.FormatJSONResponse(ctx, summaries)
+	if err != nil {
+		return textResult(err.Error(), true), nil
+	}
+
+	return textResult(fmt.Sprintf("Found %d Jamf Pro instances:\n\n%s", len(summaries), response), false), nil
+}
+
+// callToolsetKey identifies a toolset built for an instance other than the primary or for an
+// access profile's site
+type callToolsetKey struct {
+	instance string
+	site     string
+	toolset  string
+}
+
+// callToolset returns the toolset a call runs on. Calls against the primary instance without a
+// profile site use the registered toolset. Other calls get their own copy, built on the
+// requested instance and, if the session's access profile names a site, restricted to it on
+// top of the instance's own site restriction.
+func (s *Server) callToolset(ctx context.Context, instance *jamfInstance, name string, toolset toolsets.Toolset) (toolsets.Toolset, error) {
+	key := callToolsetKey{instance: instance.name, toolset: name}
+	if profile := sessionAccessProfile(ctx); profile != nil {
+		key.site = profile.Site
+	}
+	if key.instance == s.config.PrimaryInstance && key.site == "" {
+		return toolset, nil
+	}
+
+	s.callToolsetsMu.Lock()
+	defer s.callToolsetsMu.Unlock()
+
+	if cached, ok := s.callToolsets[key]; ok {
+		return cached, nil
+	}
+
+	factory := toolsets.NewFactory(s.sessionClient(ctx, instance), s.logger)
+	factory.SetRedaction(s.redaction)
+	built, err := factory.CreateToolset(name)
+	if err != nil {
+		return nil, err
+	}
+	if s.confirmations != nil {
+		built = toolsets.NewConfirmingToolset(built, s.confirmations)
+	}
+
+	s.callToolsets[key] = built
+	return built, nil
+}
+
+// sessionClient returns the client of instance, restricted to the site of the session's access
+// profile if it names one
+func (s *Server) sessionClient(ctx context.Context, instance *jamfInstance) toolsets.JamfProClient {
+	if profile := sessionAccessProfile(ctx); profile != nil && profile.Site != "" {
+		return toolsets.NewSiteScopedClient(instance.client, toolsets.ParseSite(profile.Site))
+	}
+	return instance.client
+}
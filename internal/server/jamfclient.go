@@ -0,0 +1,160 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/deploymenttheory/go-api-http-client-integrations/jamf/jamfprointegration"
+	"github.com/deploymenttheory/go-api-http-client/httpclient"
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/config"
+	"go.uber.org/zap"
+)
+
+// jamfLoadBalancerCookie is the cookie Jamf Cloud uses to pin a session to one web app node
+const jamfLoadBalancerCookie = "jpro-ingress"
+
+// newJamfProClient builds a Jamf Pro client from an SDK configuration. Without a transport the
+// SDK's own builder is used. The SDK builder always uses a default http.Client, so a custom
+// transport needs the client assembled from the same parts with that transport installed.
+func newJamfProClient(container *jamfpro.ConfigContainer, transport http.RoundTripper, logger *zap.Logger) (*jamfpro.Client, error) {
+	if transport == nil {
+		return jamfpro.BuildClient(container)
+	}
+
+	sugar := logger.Named("jamfpro").Sugar()
+	httpClient := http.Client{Transport: transport}
+	bufferPeriod := time.Duration(container.TokenRefreshBufferPeriod) * time.Second
+
+	var integration *jamfprointegration.Integration
+	var err error
+	switch container.AuthMethod {
+	case "oauth2":
+		integration, err = jamfprointegration.BuildWithOAuth(container.InstanceDomain, sugar, bufferPeriod,
+			container.ClientID, container.ClientSecret, container.HideSensitiveData, httpClient)
+	case "basic":
+		integration, err = jamfprointegration.BuildWithBasicAuth(container.InstanceDomain, sugar, bufferPeriod,
+			container.Username, container.Password, container.HideSensitiveData, httpClient)
+	default:
+		return nil, fmt.Errorf("invalid auth method %q", container.AuthMethod)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize integration: %w", err)
+	}
+
+	var cookies []*http.Cookie
+	for _, cookie := range container.CustomCookies {
+		cookies = append(cookies, &http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	}
+
+	if container.JamfLoadBalancerLock {
+		sessionCookies, err := integration.GetSessionCookies()
+		if err != nil {
+			sugar.Errorw("Failed to get session cookies for load balancer lock", zap.Error(err))
+		}
+		for _, cookie := range sessionCookies {
+			if cookie.Name == jamfLoadBalancerCookie {
+				cookies = append(cookies, cookie)
+			}
+		}
+	}
+
+	clientConfig := &httpclient.ClientConfig{
+		Sugar:                       sugar,
+		Integration:                 integration,
+		HideSensitiveData:           container.HideSensitiveData,
+		CustomCookies:               cookies,
+		MaxRetryAttempts:            container.MaxRetryAttempts,
+		MaxConcurrentRequests:       container.MaxConcurrentRequests,
+		EnableDynamicRateLimiting:   container.EnableDynamicRateLimiting,
+		Timeout:                     time.Duration(container.CustomTimeout) * time.Second,
+		TokenRefreshBufferPeriod:    bufferPeriod,
+		TotalRetryDuration:          time.Duration(container.TotalRetryDuration) * time.Second,
+		MaxRedirects:                container.MaxRedirects,
+		EnableConcurrencyManagement: container.EnableConcurrencyManagement,
+		MandatoryRequestDelay:       time.Duration(container.MandatoryRequestDelay) * time.Millisecond,
+		RetryEligiableRequests:      container.RetryEligiableRequests,
+		HTTP:                        httpClient,
+	}
+
+	client, err := clientConfig.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build HTTP client: %w", err)
+	}
+
+	return &jamfpro.Client{HTTP: client}, nil
+}
+
+// Token endpoints whose credentials secretRefreshingTransport replaces
+const (
+	oauthTokenPath = "/api/oauth/token"
+	basicTokenPath = "/api/v1/auth/token"
+)
+
+// secretRefreshingTransport reads an instance's secret again whenever the SDK requests a new
+// API token and puts it into the token request. The SDK keeps the secret it was built with for
+// the life of the client, so without this a rotated secret would need a restart.
+type secretRefreshingTransport struct {
+	base     http.RoundTripper
+	instance config.JamfInstanceConfig
+	logger   *zap.Logger
+}
+
+// newSecretRefreshingTransport wraps base, or the default transport if base is nil
+func newSecretRefreshingTransport(base http.RoundTripper, instance config.JamfInstanceConfig, logger *zap.Logger) *secretRefreshingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &secretRefreshingTransport{base: base, instance: instance, logger: logger}
+}
+
+// RoundTrip sends req, replacing the credentials of token requests with the current secret
+func (t *secretRefreshingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPost {
+		return t.base.RoundTrip(req)
+	}
+
+	oauth := t.instance.AuthMethod == "oauth2" && strings.HasSuffix(req.URL.Path, oauthTokenPath)
+	basic := t.instance.AuthMethod == "basic" && strings.HasSuffix(req.URL.Path, basicTokenPath)
+	if !oauth && !basic {
+		return t.base.RoundTrip(req)
+	}
+
+	secret, err := t.instance.Secret().Resolve(req.Context())
+	if err != nil {
+		t.logger.Error("Failed to refresh Jamf Pro secret", zap.Error(err))
+		return nil, err
+	}
+
+	// RoundTrippers must not modify the request they are given
+	req = req.Clone(req.Context())
+
+	if basic {
+		req.SetBasicAuth(t.instance.JamfUsername, secret)
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+	form.Set("client_secret", secret)
+
+	encoded := form.Encode()
+	req.Body = io.NopCloser(strings.NewReader(encoded))
+	req.ContentLength = int64(len(encoded))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(encoded)), nil
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// tokenServer records the credentials sent to the Jamf Pro token endpoints
+type tokenServer struct {
+	mu           sync.Mutex
+	clientSecret []string
+	password     []string
+	other        []string
+}
+
+func (s *tokenServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.URL.Path {
+	case oauthTokenPath:
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.clientSecret = append(s.clientSecret, r.PostForm.Get("client_secret"))
+	case basicTokenPath:
+		_, password, _ := r.BasicAuth()
+		s.password = append(s.password, password)
+	default:
+		s.other = append(s.other, r.Method+" "+r.URL.Path)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// sendToJamf sends req through client and expects the server to accept it
+func sendToJamf(t *testing.T, client *http.Client, req *http.Request) {
+	t.Helper()
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// TestSecretRefreshingTransportOAuth tests that OAuth2 token requests carry the client secret
+// currently in the secret file rather than the one the SDK was built with
+func TestSecretRefreshingTransportOAuth(t *testing.T) {
+	tokens := &tokenServer{}
+	jamf := httptest.NewServer(tokens)
+	defer jamf.Close()
+
+	secretFile := filepath.Join(t.TempDir(), "client_secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("first-secret\n"), 0o600))
+
+	transport := newSecretRefreshingTransport(nil, config.JamfInstanceConfig{
+		AuthMethod:           "oauth2",
+		JamfClientID:         "client-id",
+		JamfClientSecretFile: secretFile,
+	}, zap.NewNop())
+	client := &http.Client{Transport: transport}
+
+	newTokenRequest := func() *http.Request {
+		form := url.Values{"grant_type": {"client_credentials"}, "client_id": {"client-id"}, "client_secret": {"stale-secret"}}
+		req, err := http.NewRequest(http.MethodPost, jamf.URL+oauthTokenPath, strings.NewReader(form.Encode()))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	sendToJamf(t, client, newTokenRequest())
+	require.NoError(t, os.WriteFile(secretFile, []byte("rotated-secret\n"), 0o600))
+	sendToJamf(t, client, newTokenRequest())
+
+	assert.Equal(t, []string{"first-secret", "rotated-secret"}, tokens.clientSecret)
+
+	// Other requests are passed on untouched
+	req, err := http.NewRequest(http.MethodPost, jamf.URL+"/api/v1/scripts", strings.NewReader("{}"))
+	require.NoError(t, err)
+	sendToJamf(t, client, req)
+	assert.Equal(t, []string{"POST /api/v1/scripts"}, tokens.other)
+}
+
+// TestSecretRefreshingTransportBasic tests that basic authentication token requests carry
+// the password the password command prints now
+func TestSecretRefreshingTransportBasic(t *testing.T) {
+	tokens := &tokenServer{}
+	jamf := httptest.NewServer(tokens)
+	defer jamf.Close()
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("first-password"), 0o600))
+
+	transport := newSecretRefreshingTransport(nil, config.JamfInstanceConfig{
+		AuthMethod:          "basic",
+		JamfUsername:        "admin",
+		JamfPasswordCommand: "cat " + passwordFile,
+	}, zap.NewNop())
+	client := &http.Client{Transport: transport}
+
+	newTokenRequest := func() *http.Request {
+		req, err := http.NewRequest(http.MethodPost, jamf.URL+basicTokenPath, nil)
+		require.NoError(t, err)
+		req.SetBasicAuth("admin", "stale-password")
+		return req
+	}
+
+	sendToJamf(t, client, newTokenRequest())
+	require.NoError(t, os.WriteFile(passwordFile, []byte("rotated-password"), 0o600))
+	sendToJamf(t, client, newTokenRequest())
+
+	assert.Equal(t, []string{"first-password", "rotated-password"}, tokens.password)
+
+	// A secret that cannot be read fails the token request instead of sending a stale one
+	require.NoError(t, os.Remove(passwordFile))
+	_, err := client.Do(newTokenRequest())
+	assert.ErrorContains(t, err, "secret command failed")
+	assert.Len(t, tokens.password, 2)
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeJSON round-trips value through JSON the way a client receives it
+func decodeJSON(t *testing.T, value interface{}) interface{} {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var decoded interface{}
+	require.NoError(t, dec.Decode(&decoded))
+	return decoded
+}
+
+// schemaErrors checks value against the subset of JSON Schema used by generated output
+// schemas (type, properties, items and required) and returns every violation
+func schemaErrors(schema map[string]interface{}, value interface{}, path string) []string {
+	if types, ok := schema["type"]; ok {
+		var allowed []string
+		switch types := types.(type) {
+		case string:
+			allowed = []string{types}
+		case []interface{}:
+			for _, kind := range types {
+				allowed = append(allowed, kind.(string))
+			}
+		}
+
+		matched := false
+		for _, kind := range allowed {
+			matched = matched || jsonTypeMatches(kind, value)
+		}
+		if !matched {
+			return []string{fmt.Sprintf("%s: %v is not of type %v", path, value, allowed)}
+		}
+	}
+
+	var errs []string
+	if object, ok := value.(map[string]interface{}); ok {
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, present := object[name.(string)]; !present {
+					errs = append(errs, fmt.Sprintf("%s: missing required property %s", path, name))
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, property := range properties {
+			if propertyValue, present := object[name]; present {
+				errs = append(errs, schemaErrors(property.(map[string]interface{}), propertyValue, path+"."+name)...)
+			}
+		}
+	}
+	if array, ok := value.([]interface{}); ok {
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range array {
+				errs = append(errs, schemaErrors(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return errs
+}
+
+// jsonTypeMatches reports whether a decoded JSON value is of the named JSON Schema type
+func jsonTypeMatches(kind string, value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return kind == "null"
+	case bool:
+		return kind == "boolean"
+	case string:
+		return kind == "string"
+	case json.Number:
+		return kind == "number" || (kind == "integer" && !strings.ContainsAny(value.String(), ".eE"))
+	case []interface{}:
+		return kind == "array"
+	case map[string]interface{}:
+		return kind == "object"
+	default:
+		return false
+	}
+}
+
+// TestStructuredContentMatchesOutputSchema tests that tools declaring an output schema return
+// structured content that conforms to it, including calls that find nothing to return
+func TestStructuredContentMatchesOutputSchema(t *testing.T) {
+	general := jamfpro.ComputerInventorySubsetGeneral{Name: "Lab Mac"}
+	client := &fakeJamfClient{computers: map[string]*jamfpro.ResourceComputerInventory{
+		"7": {ID: "7", UDID: "UDID-7", General: general},
+		"8": nil,
+	}}
+	s, _ := newAuditedServer(t, client)
+	enableTestToolsets(t, s, "computer-inventory")
+	ctx := newTestSession(t, s, nil)
+
+	tool, ok := listTools(t, s, ctx)["get_computer_inventory_by_id"]
+	require.True(t, ok)
+	outputSchema, ok := decodeJSON(t, tool.OutputSchema).(map[string]interface{})
+	require.True(t, ok)
+
+	for _, id := range []string{"7", "8"} {
+		response := callTool(t, s, ctx, "get_computer_inventory_by_id", map[string]interface{}{"id": id})
+		require.Nil(t, response.Error)
+
+		result, ok := response.Result.(*mcp.CallToolResult)
+		require.True(t, ok)
+		require.False(t, result.IsError, "computer %s", id)
+		require.NotNil(t, result.StructuredContent, "computer %s", id)
+
+		assert.Empty(t, schemaErrors(outputSchema, decodeJSON(t, result.StructuredContent), "$"), "computer %s", id)
+	}
+}
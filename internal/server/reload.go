@@ -0,0 +1,222 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/config"
+	"go.uber.org/zap"
+)
+
+// connectionSettings are the settings the Jamf Pro clients were built from. Changing them
+// would need new clients, so a reload that touches them is rejected.
+var connectionSettings = map[string]bool{
+	"jamf_instance_url":          true,
+	"jamf_client_id":             true,
+	"jamf_client_secret":         true,
+	"jamf_client_secret_file":    true,
+	"jamf_client_secret_command": true,
+	"jamf_username":              true,
+	"jamf_password":              true,
+	"jamf_password_file":         true,
+	"jamf_password_command":      true,
+	"auth_method":                true,
+	"jamf_instances":             true,
+	"primary_instance":           true,
+}
+
+// liveSettings are applied while the server runs. The log level is applied by the caller,
+// which owns the logger.
+var liveSettings = map[string]bool{
+	"log_level":              true,
+	"toolsets":               true,
+	"tool_descriptions":      true,
+	"parameter_descriptions": true,
+	"list_page_size":         true,
+}
+
+// settingsChange describes what a reload changed
+type settingsChange struct {
+	// restart and live hold the keys of the changed settings that need a restart and the
+	// ones that were applied
+	restart []string
+	live    []string
+
+	toolsets     bool
+	descriptions bool
+}
+
+// Reload applies a changed configuration to the running server. The toolsets and tool
+// descriptions are updated in place and clients are sent tools/list_changed. Changes to the
+// Jamf Pro connection settings reject the whole reload with an error, and other settings are
+// left as they were with a warning that they need a restart.
+func (s *Server) Reload(updated *config.Config) error {
+	change, err := s.applySettings(updated)
+	if err != nil || change == nil {
+		return err
+	}
+
+	if len(change.restart) > 0 {
+		s.logger.Warn("Changed settings take effect only after a restart", zap.Strings("settings", change.restart))
+	}
+
+	s.mcpServer.SetPageSize(updated.ListPageSize)
+
+	listChanged := false
+	if change.toolsets && s.reconcileToolsets() {
+		listChanged = true
+	}
+	if change.descriptions {
+		s.reregisterToolDefinitions()
+		s.warnUnmatchedDescriptions()
+		listChanged = true
+	}
+	if listChanged {
+		s.mcpServer.NotifyToolsListChanged(context.Background())
+	}
+
+	if len(change.live) > 0 {
+		s.logger.Info("Applied configuration change", zap.Strings("settings", change.live))
+	}
+
+	return nil
+}
+
+// applySettings compares updated with the running configuration and copies the live settings
+// across. The comparison and the copy happen under liveMu so that they see the same
+// configuration as concurrent readers and other reloads. It returns nil if nothing changed.
+func (s *Server) applySettings(updated *config.Config) (*settingsChange, error) {
+	s.liveMu.Lock()
+	defer s.liveMu.Unlock()
+
+	changed := s.config.ChangedSettings(updated)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	change := &settingsChange{}
+	var connection []string
+	for _, key := range changed {
+		switch {
+		case connectionSettings[key]:
+			connection = append(connection, key)
+		case liveSettings[key]:
+			change.live = append(change.live, key)
+		default:
+			change.restart = append(change.restart, key)
+		}
+	}
+
+	if len(connection) > 0 {
+		return nil, fmt.Errorf("Jamf Pro connection settings cannot change while the server is running (%s); restart the server to apply them",
+			strings.Join(connection, ", "))
+	}
+
+	change.toolsets = !reflect.DeepEqual(s.config.Toolsets, updated.Toolsets)
+	change.descriptions = !reflect.DeepEqual(s.config.ToolDescriptions, updated.ToolDescriptions) ||
+		!reflect.DeepEqual(s.config.ParameterDescriptions, updated.ParameterDescriptions)
+	s.config.Toolsets = updated.Toolsets
+	s.config.ToolDescriptions = updated.ToolDescriptions
+	s.config.ParameterDescriptions = updated.ParameterDescriptions
+	s.config.LogLevel = updated.LogLevel
+	s.config.ListPageSize = updated.ListPageSize
+	s.descriptions = s.descriptionOverrides(updated)
+
+	return change, nil
+}
+
+// reconcileToolsets disables toolsets that are no longer configured and, unless clients
+// enable toolsets themselves, enables newly configured ones. It reports whether the set of
+// registered tools changed.
+func (s *Server) reconcileToolsets() bool {
+	desired := make(map[string]bool)
+	for _, name := range s.getEnabledToolsets() {
+		desired[name] = true
+	}
+
+	s.toolsetsMu.RLock()
+	var enabled []string
+	for name := range s.toolsets {
+		enabled = append(enabled, name)
+	}
+	s.toolsetsMu.RUnlock()
+
+	changed := false
+	for _, name := range enabled {
+		if !desired[name] {
+			s.disableToolset(name)
+			changed = true
+		}
+	}
+
+	// In dynamic mode the toolsets are only the ones clients may enable
+	if s.config.DynamicToolsets {
+		return changed
+	}
+
+	for name := range desired {
+		_, created, err := s.enableToolset(name)
+		if err != nil {
+			s.logger.Warn("Failed to create toolset",
+				zap.String("toolset", name),
+				zap.Error(err))
+			continue
+		}
+		if created {
+			s.logger.Info("Enabled toolset from configuration", zap.String("toolset", name))
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// disableToolset unregisters a toolset's tools and forgets the toolset
+func (s *Server) disableToolset(name string) {
+	s.toolsetsMu.Lock()
+	defer s.toolsetsMu.Unlock()
+
+	toolset, exists := s.toolsets[name]
+	if !exists {
+		return
+	}
+
+	for _, tool := range toolset.GetTools() {
+		s.mcpServer.UnregisterTool(tool.Name)
+	}
+	delete(s.toolsets, name)
+
+	s.callToolsetsMu.Lock()
+	for key := range s.callToolsets {
+		if key.toolset == name {
+			delete(s.callToolsets, key)
+		}
+	}
+	s.callToolsetsMu.Unlock()
+
+	s.logger.Info("Disabled toolset from configuration", zap.String("toolset", name))
+}
+
+// reregisterToolDefinitions registers the definitions of every enabled tool again so that
+// changed descriptions reach clients
+func (s *Server) reregisterToolDefinitions() {
+	for _, tool := range s.serverTools {
+		tool = s.describeTool(tool)
+		s.mcpServer.RegisterToolDefinition(&tool)
+	}
+
+	s.toolsetsMu.RLock()
+	defer s.toolsetsMu.RUnlock()
+
+	for _, toolset := range s.toolsets {
+		for _, tool := range toolset.GetTools() {
+			if allowed, _ := s.toolFilter.Allows(tool.Name); !allowed {
+				continue
+			}
+			tool = s.toolDefinition(tool)
+			s.mcpServer.RegisterToolDefinition(&tool)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newReloadTest creates a bare server with the computers toolset enabled and an initialized
+// session. It returns the server, the session context, the server's logs and the
+// notifications sent to the session.
+func newReloadTest(t *testing.T) (*Server, context.Context, *observer.ObservedLogs, chan *mcp.Message) {
+	t.Helper()
+
+	s, _ := newAuditedServer(t, &fakeJamfClient{})
+	core, logs := observer.New(zapcore.InfoLevel)
+	s.logger = zap.New(core)
+	enableTestToolsets(t, s, "computers")
+
+	ctx := newTestSession(t, s, nil)
+	notifications := make(chan *mcp.Message, 10)
+	mcp.SessionFromContext(ctx).SetNotifier(func(msg *mcp.Message) error {
+		notifications <- msg
+		return nil
+	})
+
+	return s, ctx, logs, notifications
+}
+
+// receivedToolsListChanged reports whether the session is sent tools/list_changed
+func receivedToolsListChanged(notifications chan *mcp.Message) bool {
+	select {
+	case msg := <-notifications:
+		return msg.Method == mcp.NotificationToolsListChanged
+	case <-time.After(100 * time.Millisecond):
+		return false
+	}
+}
+
+// TestReloadRejectsConnectionChanges tests that a reload changing the Jamf Pro credentials is
+// rejected as a whole, leaving live settings in the same reload unapplied
+func TestReloadRejectsConnectionChanges(t *testing.T) {
+	s, ctx, _, notifications := newReloadTest(t)
+
+	updated := *s.config
+	updated.JamfClientSecret = "rotated"
+	updated.Toolsets = []string{"computers", "scripts"}
+
+	err := s.Reload(&updated)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "jamf_client_secret")
+
+	assert.Equal(t, []string{"computers"}, s.config.Toolsets)
+	assert.NotContains(t, listTools(t, s, ctx), "get_scripts")
+	assert.False(t, receivedToolsListChanged(notifications))
+}
+
+// TestReloadToolsetsNotifiesClients tests that changing the configured toolsets registers and
+// unregisters their tools and tells clients the tool list changed
+func TestReloadToolsetsNotifiesClients(t *testing.T) {
+	s, ctx, logs, notifications := newReloadTest(t)
+
+	updated := *s.config
+	updated.Toolsets = []string{"scripts"}
+	require.NoError(t, s.Reload(&updated))
+
+	tools := listTools(t, s, ctx)
+	assert.Contains(t, tools, "get_scripts")
+	assert.NotContains(t, tools, "get_computers")
+	assert.True(t, receivedToolsListChanged(notifications))
+	assert.Equal(t, 1, logs.FilterMessage("Applied configuration change").FilterField(zap.Strings("settings", []string{"toolsets"})).Len())
+
+	// Reloading the same configuration changes nothing
+	require.NoError(t, s.Reload(&updated))
+	assert.False(t, receivedToolsListChanged(notifications))
+}
+
+// TestReloadDescriptionsNotifiesClients tests that changed description overrides reach the
+// registered tools and that clients are told the tool list changed
+func TestReloadDescriptionsNotifiesClients(t *testing.T) {
+	s, ctx, _, notifications := newReloadTest(t)
+
+	updated := *s.config
+	updated.ToolDescriptions = map[string]string{"get_computers": "List every Mac in the fleet"}
+	require.NoError(t, s.Reload(&updated))
+
+	assert.Equal(t, "List every Mac in the fleet", listTools(t, s, ctx)["get_computers"].Description)
+	assert.True(t, receivedToolsListChanged(notifications))
+}
+
+// TestReloadLeavesRestartSettings tests that settings which need a restart are left as they
+// were with a warning while live settings in the same reload are applied
+func TestReloadLeavesRestartSettings(t *testing.T) {
+	s, _, logs, notifications := newReloadTest(t)
+	s.config.ListPageSize = 50
+
+	updated := *s.config
+	updated.HTTPMaxSessions = 10
+	updated.ListPageSize = 20
+	require.NoError(t, s.Reload(&updated))
+
+	assert.Equal(t, 0, s.config.HTTPMaxSessions)
+	assert.Equal(t, 20, s.config.ListPageSize)
+	assert.Equal(t, 1, logs.FilterMessage("Changed settings take effect only after a restart").FilterField(zap.Strings("settings", []string{"http_max_sessions"})).Len())
+	assert.Equal(t, 1, logs.FilterMessage("Applied configuration change").FilterField(zap.Strings("settings", []string{"list_page_size"})).Len())
+	assert.False(t, receivedToolsListChanged(notifications), "the tool list did not change")
+}
@@ -0,0 +1,382 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/audit"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/toolsets"
+	"go.uber.org/zap"
+)
+
+// jamfResourceScheme prefixes the URIs of Jamf Pro objects served as resources
+const jamfResourceScheme = "jamf://"
+
+// jamfResourceTemplate describes a family of Jamf Pro objects served as resources. A session
+// may read them exactly when it may call the tool that returns the same object, so enabled
+// toolsets, the tool filter, access profiles and sites apply to resources as they do to tools.
+type jamfResourceTemplate struct {
+	template mcp.ResourceTemplate
+
+	// path holds the segments after the scheme, with {id} standing for the object ID
+	path []string
+
+	// tool is the read tool that returns the same object
+	tool string
+
+	// read returns the content of the object with the given ID
+	read func(ctx context.Context, client toolsets.JamfProClient, id string) (string, error)
+}
+
+// jamfResourceTemplates lists the Jamf Pro objects that can be read as resources
+var jamfResourceTemplates = []jamfResourceTemplate{
+	{
+		template: mcp.ResourceTemplate{
+			URITemplate: "jamf://computers/{id}",
+			Name:        "Computer",
+			Description: "Inventory record of a computer",
+			MimeType:    "application/json",
+		},
+		path: []string{"computers", "{id}"},
+		tool: "get_computer_inventory_by_id",
+		read: func(ctx context.Context, client toolsets.JamfProClient, id string) (string, error) {
+			computer, err := client.GetComputerInventoryByID(id)
+			if err != nil {
+				return "", err
+			}
+			return toolsets.FormatJSONResponse(ctx, computer)
+		},
+	},
+	{
+		template: mcp.ResourceTemplate{
+			URITemplate: "jamf://mobile-devices/{id}",
+			Name:        "Mobile Device",
+			Description: "Record of a mobile device",
+			MimeType:    "application/json",
+		},
+		path: []string{"mobile-devices", "{id}"},
+		tool: "get_mobile_device_by_id",
+		read: func(ctx context.Context, client toolsets.JamfProClient, id string) (string, error) {
+			device, err := client.GetMobileDeviceByID(id)
+			if err != nil {
+				return "", err
+			}
+			return toolsets.FormatJSONResponse(ctx, device)
+		},
+	},
+	{
+		template: mcp.ResourceTemplate{
+			URITemplate: "jamf://policies/{id}",
+			Name:        "Policy",
+			Description: "Policy with its scope, payloads and scripts",
+			MimeType:    "application/json",
+		},
+		path: []string{"policies", "{id}"},
+		tool: "get_policy_by_id",
+		read: func(ctx context.Context, client toolsets.JamfProClient, id string) (string, error) {
+			policy, err := client.GetPolicyByID(id)
+			if err != nil {
+				return "", err
+			}
+			return toolsets.FormatJSONResponse(ctx, policy)
+		},
+	},
+	{
+		template: mcp.ResourceTemplate{
+			URITemplate: "jamf://scripts/{id}/contents",
+			Name:        "Script Contents",
+			Description: "Body of a script",
+			MimeType:    "text/plain",
+		},
+		path: []string{"scripts", "{id}", "contents"},
+		tool: "get_script_by_id",
+		read: func(ctx context.Context, client toolsets.JamfProClient, id string) (string, error) {
+			script, err := client.GetScriptByID(id)
+			if err != nil {
+				return "", err
+			}
+			return script.ScriptContents, nil
+		},
+	},
+}
+
+// match returns the object ID in uri if the URI belongs to the template
+func (t *jamfResourceTemplate) match(uri string) (string, bool) {
+	rest, ok := strings.CutPrefix(uri, jamfResourceScheme)
+	if !ok {
+		return "", false
+	}
+
+	segments := strings.Split(rest, "/")
+	if len(segments) != len(t.path) {
+		return "", false
+	}
+
+	id := ""
+	for i, segment := range segments {
+		if t.path[i] == "{id}" {
+			if !isObjectID(segment) {
+				return "", false
+			}
+			id = segment
+		} else if segment != t.path[i] {
+			return "", false
+		}
+	}
+	return id, true
+}
+
+// isObjectID reports whether value is a Jamf Pro object ID, which is always numeric
+func isObjectID(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// jamfResourceProvider serves Jamf Pro objects of the primary instance as resources
+type jamfResourceProvider struct {
+	server *Server
+
+	// subscriptions holds the objects sessions are subscribed to, keyed by URI
+	mu            sync.Mutex
+	subscriptions map[string]*jamfSubscription
+}
+
+// jamfSubscription is an object watched for changes by polling
+type jamfSubscription struct {
+	template    *jamfResourceTemplate
+	id          string
+	subscribers int
+
+	// snapshots holds the hash of the content last read for each site subscribers are
+	// restricted to, with "" for subscribers that see the whole instance
+	snapshots map[string][sha256.Size]byte
+}
+
+// jamfResourceUpdate is a change to an object that a subscribed session should be told about
+type jamfResourceUpdate struct {
+	session *mcp.Session
+	uri     string
+}
+
+// sessionSite returns the site the session in ctx is restricted to, or "" if it sees the
+// whole instance
+func sessionSite(ctx context.Context) string {
+	if profile := sessionAccessProfile(ctx); profile != nil {
+		return profile.Site
+	}
+	return ""
+}
+
+// newJamfResourceProvider creates the provider of Jamf Pro resources for the server
+func newJamfResourceProvider(server *Server) *jamfResourceProvider {
+	return &jamfResourceProvider{
+		server:        server,
+		subscriptions: make(map[string]*jamfSubscription),
+	}
+}
+
+// matchTemplate returns the template serving uri and the object ID in it
+func matchTemplate(uri string) (*jamfResourceTemplate, string, bool) {
+	for i := range jamfResourceTemplates {
+		if id, ok := jamfResourceTemplates[i].match(uri); ok {
+			return &jamfResourceTemplates[i], id, true
+		}
+	}
+	return nil, "", false
+}
+
+// ListResources returns no resources: Jamf Pro objects are read through templates instead of
+// being listed
+func (p *jamfResourceProvider) ListResources(ctx context.Context) ([]mcp.Resource, error) {
+	return nil, nil
+}
+
+// ListResourceTemplates returns the templates the session in ctx may read
+func (p *jamfResourceProvider) ListResourceTemplates(ctx context.Context) ([]mcp.ResourceTemplate, error) {
+	var templates []mcp.ResourceTemplate
+	for _, template := range jamfResourceTemplates {
+		if p.server.mcpServer.AuthorizeTool(ctx, template.tool) == nil {
+			templates = append(templates, template.template)
+		}
+	}
+	return templates, nil
+}
+
+// ReadResource reads a Jamf Pro object
+func (p *jamfResourceProvider) ReadResource(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	template, id, ok := matchTemplate(uri)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", mcp.ErrResourceNotFound, uri)
+	}
+
+	started := time.Now()
+	text, err := p.read(ctx, template, id, uri)
+	switch {
+	case errors.Is(err, mcp.ErrResourceAccessDenied):
+		p.server.auditResourceRead(ctx, template, id, uri, started, audit.OutcomeDenied, err)
+		return nil, err
+	case err != nil:
+		p.server.auditResourceRead(ctx, template, id, uri, started, audit.OutcomeError, err)
+		return nil, err
+	}
+	p.server.auditResourceRead(ctx, template, id, uri, started, audit.OutcomeSuccess, nil)
+
+	return &mcp.ReadResourceResult{
+		Contents: []mcp.ResourceContent{
+			{
+				URI:      uri,
+				MimeType: template.template.MimeType,
+				Text:     text,
+			},
+		},
+	}, nil
+}
+
+// read returns the content of an object for the session in ctx, once the session is
+// authorised to call the template's tool
+func (p *jamfResourceProvider) read(ctx context.Context, template *jamfResourceTemplate, id, uri string) (string, error) {
+	if err := p.server.mcpServer.AuthorizeTool(ctx, template.tool); err != nil {
+		return "", fmt.Errorf("%w: %s: %v", mcp.ErrResourceAccessDenied, uri, err)
+	}
+
+	instance := p.server.instances[p.server.config.PrimaryInstance]
+	text, err := template.read(toolsets.ContextWithRedaction(ctx, p.server.redaction), p.server.sessionClient(ctx, instance), id)
+	if err != nil {
+		p.server.logger.Warn("Failed to read Jamf Pro resource", zap.String("uri", uri), zap.Error(err), mcp.SessionField(ctx))
+		return "", fmt.Errorf("failed to read %s: %w", uri, err)
+	}
+	return text, nil
+}
+
+// Subscribe starts polling an object for the session in ctx. The object is read once with
+// the session's access so that a session cannot watch what it could not read.
+func (p *jamfResourceProvider) Subscribe(ctx context.Context, uri string) error {
+	template, id, ok := matchTemplate(uri)
+	if !ok {
+		return fmt.Errorf("%w: %s", mcp.ErrResourceNotFound, uri)
+	}
+
+	if p.server.config.ResourcePollSeconds == 0 {
+		return fmt.Errorf("%w: subscriptions to Jamf Pro resources are disabled (resource_poll_seconds is 0)", mcp.ErrSubscriptionsUnsupported)
+	}
+
+	text, err := p.read(ctx, template, id, uri)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	subscription, ok := p.subscriptions[uri]
+	if !ok {
+		subscription = &jamfSubscription{
+			template:  template,
+			id:        id,
+			snapshots: make(map[string][sha256.Size]byte),
+		}
+		p.subscriptions[uri] = subscription
+	}
+	if _, ok := subscription.snapshots[sessionSite(ctx)]; !ok {
+		subscription.snapshots[sessionSite(ctx)] = sha256.Sum256([]byte(text))
+	}
+	subscription.subscribers++
+	return nil
+}
+
+// Unsubscribe stops polling an object once its last subscriber has gone
+func (p *jamfResourceProvider) Unsubscribe(uri string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	subscription, ok := p.subscriptions[uri]
+	if !ok {
+		return
+	}
+	subscription.subscribers--
+	if subscription.subscribers <= 0 {
+		delete(p.subscriptions, uri)
+	}
+}
+
+// poll reads every subscribed object at each interval until ctx is cancelled, and notifies
+// the subscribers of objects whose content has changed since the last read
+func (p *jamfResourceProvider) poll(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, update := range p.pollOnce(ctx) {
+				p.server.mcpServer.NotifySessionResourceUpdated(update.session, update.uri)
+			}
+		}
+	}
+}
+
+// pollOnce reads every subscribed object and returns the changes to report. Subscribers are
+// authorised again on every poll, since their toolsets or profile may have changed since they
+// subscribed, and the object is read once for each site they are restricted to with the same
+// client their own reads use, so a session only hears about changes it could read.
+func (p *jamfResourceProvider) pollOnce(ctx context.Context) []jamfResourceUpdate {
+	p.mu.Lock()
+	watched := make(map[string]*jamfSubscription, len(p.subscriptions))
+	for uri, subscription := range p.subscriptions {
+		watched[uri] = subscription
+	}
+	p.mu.Unlock()
+
+	instance := p.server.instances[p.server.config.PrimaryInstance]
+
+	var updates []jamfResourceUpdate
+	for uri, subscription := range watched {
+		// Group the subscribers that may still read the object by the site they see
+		sites := make(map[string][]*mcp.Session)
+		for _, session := range p.server.mcpServer.ResourceSubscribers(uri) {
+			sessionCtx := mcp.ContextWithSession(ctx, session)
+			if err := p.server.mcpServer.AuthorizeTool(sessionCtx, subscription.template.tool); err != nil {
+				continue
+			}
+			site := sessionSite(sessionCtx)
+			sites[site] = append(sites[site], session)
+		}
+
+		for site, sessions := range sites {
+			sessionCtx := mcp.ContextWithSession(ctx, sessions[0])
+			text, err := subscription.template.read(toolsets.ContextWithRedaction(sessionCtx, p.server.redaction), p.server.sessionClient(sessionCtx, instance), subscription.id)
+			if err != nil {
+				p.server.logger.Warn("Failed to poll Jamf Pro resource", zap.String("uri", uri), zap.String("site", site), zap.Error(err))
+				continue
+			}
+
+			snapshot := sha256.Sum256([]byte(text))
+			p.mu.Lock()
+			previous, seen := subscription.snapshots[site]
+			subscription.snapshots[site] = snapshot
+			p.mu.Unlock()
+
+			if seen && snapshot != previous {
+				for _, session := range sessions {
+					updates = append(updates, jamfResourceUpdate{session: session, uri: uri})
+				}
+			}
+		}
+	}
+	return updates
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// subscribe sends resources/subscribe and returns the response
+func subscribe(t *testing.T, s *Server, ctx context.Context, uri string) *mcp.Message {
+	t.Helper()
+
+	response, err := s.mcpServer.HandleMessage(ctx, &mcp.Message{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "resources/subscribe",
+		Params:  map[string]interface{}{"uri": uri},
+	})
+	require.NoError(t, err)
+	return response
+}
+
+// updatedSessions returns the IDs of the sessions each update is for
+func updatedSessions(updates []jamfResourceUpdate) []string {
+	var ids []string
+	for _, update := range updates {
+		ids = append(ids, update.session.ID())
+	}
+	return ids
+}
+
+// TestMatchTemplate tests which URIs the Jamf Pro resource templates serve
+func TestMatchTemplate(t *testing.T) {
+	tests := []struct {
+		uri  string
+		tool string
+		id   string
+	}{
+		{uri: "jamf://computers/7", tool: "get_computer_inventory_by_id", id: "7"},
+		{uri: "jamf://mobile-devices/12", tool: "get_mobile_device_by_id", id: "12"},
+		{uri: "jamf://policies/3", tool: "get_policy_by_id", id: "3"},
+		{uri: "jamf://scripts/42/contents", tool: "get_script_by_id", id: "42"},
+		{uri: "jamf://computers/abc"},
+		{uri: "jamf://computers/"},
+		{uri: "jamf://computers/7/contents"},
+		{uri: "jamf://scripts/42"},
+		{uri: "file:///computers/7"},
+	}
+
+	for _, tt := range tests {
+		template, id, ok := matchTemplate(tt.uri)
+		if tt.tool == "" {
+			assert.False(t, ok, tt.uri)
+			continue
+		}
+		if assert.True(t, ok, tt.uri) {
+			assert.Equal(t, tt.tool, template.tool, tt.uri)
+			assert.Equal(t, tt.id, id, tt.uri)
+		}
+	}
+}
+
+// TestResourcesFollowToolAuthorization tests that a session can list, read and subscribe to
+// an object only when it may call the tool that returns the same object
+func TestResourcesFollowToolAuthorization(t *testing.T) {
+	s, _ := newAuditedServer(t, &fakeJamfClient{computers: map[string]*jamfpro.ResourceComputerInventory{"7": {ID: "7"}}})
+	ctx := newTestSession(t, s, nil)
+
+	templates, err := s.jamfResources.ListResourceTemplates(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, templates)
+
+	response := subscribe(t, s, ctx, "jamf://computers/7")
+	require.NotNil(t, response.Error)
+	assert.Equal(t, mcp.ResourceAccessDenied, response.Error.Code)
+
+	s.registerServerTool(mcp.Tool{Name: "get_computer_inventory_by_id", Access: mcp.ToolAccessRead}, nil)
+	templates, err = s.jamfResources.ListResourceTemplates(ctx)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "jamf://computers/{id}", templates[0].URITemplate)
+	assert.Nil(t, subscribe(t, s, ctx, "jamf://computers/7").Error)
+
+	s.config.ResourcePollSeconds = 0
+	response = subscribe(t, s, ctx, "jamf://computers/8")
+	require.NotNil(t, response.Error)
+	assert.Equal(t, mcp.InvalidRequest, response.Error.Code)
+	response = subscribe(t, s, ctx, "jamf://computers/unknown")
+	require.NotNil(t, response.Error)
+	assert.Equal(t, mcp.ResourceNotFound, response.Error.Code)
+
+	// Access profiles deny resources along with their tools
+	denied := newTestSession(t, s, &mcp.AccessProfile{Name: "no-inventory", DeniedTools: []string{"get_computer_*"}})
+	templates, err = s.jamfResources.ListResourceTemplates(denied)
+	require.NoError(t, err)
+	assert.Empty(t, templates)
+	_, err = s.jamfResources.ReadResource(denied, "jamf://computers/7")
+	assert.ErrorIs(t, err, mcp.ErrResourceAccessDenied)
+}
+
+// TestPollNotifiesSubscribersBySite tests that polling reads an object with each subscriber's
+// site scope and notifies only the subscribers that may still read it
+func TestPollNotifiesSubscribersBySite(t *testing.T) {
+	computer := &jamfpro.ResourceComputerInventory{ID: "7"}
+	computer.General.Name = "Lab Mac"
+	computer.General.Site = jamfpro.SharedResourceSiteProAPI{ID: "1", Name: "London"}
+	s, _ := newAuditedServer(t, &fakeJamfClient{computers: map[string]*jamfpro.ResourceComputerInventory{"7": computer}})
+	s.registerServerTool(mcp.Tool{Name: "get_computer_inventory_by_id", Access: mcp.ToolAccessRead}, nil)
+
+	unrestricted := newTestSession(t, s, nil)
+	london := newTestSession(t, s, &mcp.AccessProfile{Name: "london", Site: "London"})
+	paris := newTestSession(t, s, &mcp.AccessProfile{Name: "paris", Site: "Paris"})
+	unrestrictedID := mcp.SessionFromContext(unrestricted).ID()
+	londonID := mcp.SessionFromContext(london).ID()
+
+	require.Nil(t, subscribe(t, s, unrestricted, "jamf://computers/7").Error)
+	require.Nil(t, subscribe(t, s, london, "jamf://computers/7").Error)
+	assert.NotNil(t, subscribe(t, s, paris, "jamf://computers/7").Error, "the computer is not in the Paris site")
+
+	assert.Empty(t, s.jamfResources.pollOnce(context.Background()), "nothing has changed")
+
+	computer.General.Name = "Renamed Mac"
+	assert.ElementsMatch(t, []string{unrestrictedID, londonID}, updatedSessions(s.jamfResources.pollOnce(context.Background())))
+
+	// Once the computer leaves the London site, only the unrestricted session hears about it
+	computer.General.Site = jamfpro.SharedResourceSiteProAPI{ID: "2", Name: "Paris"}
+	assert.Equal(t, []string{unrestrictedID}, updatedSessions(s.jamfResources.pollOnce(context.Background())))
+
+	// A session whose profile no longer allows the tool is not notified
+	mcp.SessionFromContext(unrestricted).SetAccessProfile(&mcp.AccessProfile{Name: "no-inventory", DeniedTools: []string{"get_computer_*"}})
+	computer.General.Name = "Lab Mac"
+	assert.Empty(t, s.jamfResources.pollOnce(context.Background()))
+}
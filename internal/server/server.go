@@ -1,46 +1,133 @@
 package server
 
 import (
-	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/url"
 	"os"
+	"sync"
+	"time"
 
-	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/audit"
 	"github.com/deploymenttheory/jamfpro-mcp-server/internal/config"
-	"github.com/deploymenttheory/jamfpro-mcp-server/internal/mcp"
-	"github.com/deploymenttheory/jamfpro-mcp-server/internal/toolsets"
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/prompts"
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/redact"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/toolsets"
 	"go.uber.org/zap"
 )
 
 // Server represents the main MCP server
 type Server struct {
-	config     *config.Config
-	logger     *zap.Logger
-	mcpServer  *mcp.Server
-	jamfClient *jamfpro.Client
+	config    *config.Config
+	logger    *zap.Logger
+	mcpServer *mcp.Server
+	factory   *toolsets.Factory
+
+	// wireLogger logs protocol traffic. Unlike logger it does not forward entries to clients,
+	// since forwarding the entry for a sent message would send, and log, another.
+	wireLogger *zap.Logger
+
+	// instances holds the configured Jamf Pro instances by name
+	instances map[string]*jamfInstance
+
+	// redaction masks secrets in tool and resource responses
+	redaction toolsets.Redaction
+
+	// confirmations holds the tokens that authorise destructive tool calls
+	confirmations *toolsets.ConfirmationStore
+
+	// toolFilter applies the allowed_tools and denied_tools patterns
+	toolFilter *toolsets.ToolFilter
+
+	// profileTokens maps HTTP bearer tokens to access profiles
+	profileTokens []profileToken
+
+	// auditor records every tool invocation, or is nil when auditing is disabled
+	auditor *audit.Logger
+
+	// translations holds the description overrides from the translations file and environment
+	translations map[string]string
+
+	// serverTools holds the tools the server provides itself, as defined before overrides
+	serverTools []mcp.Tool
+
+	// liveMu guards the configuration Reload compares and changes while the server runs: the
+	// live settings in config, such as config.Toolsets, and descriptions
+	liveMu sync.RWMutex
+
+	// descriptions holds every description override keyed by translation key
+	descriptions map[string]string
+
+	toolsetsMu sync.RWMutex
 	toolsets   map[string]toolsets.Toolset
+
+	// callToolsets holds toolsets built for other instances and access profile sites
+	callToolsetsMu sync.Mutex
+	callToolsets   map[callToolsetKey]toolsets.Toolset
+
+	// httpSessions tracks the sessions of HTTP clients, closing idle ones
+	httpSessions *httpSessionTracker
+
+	// fileResources and jamfResources serve resources and report changes to subscribers
+	fileResources *mcp.FileResourceProvider
+	jamfResources *jamfResourceProvider
 }
 
 // New creates a new server instance
 func New(cfg *config.Config, logger *zap.Logger) (*Server, error) {
 	// Create MCP server
 	mcpServer := mcp.NewServer("jamfpro-mcp-server", "1.0.0")
+	mcpServer.SetReadOnly(cfg.ReadOnly)
+	mcpServer.SetPageSize(cfg.ListPageSize)
 
-	// Initialize Jamf Pro client
-	jamfClient, err := initializeJamfClient(cfg, logger)
+	// Send log entries to clients that ask for them with logging/setLevel
+	forwarding := logger.WithOptions(mcp.ForwardLogs(mcpServer))
+
+	// Mask secrets in tool output and logs before anything else can emit them
+	redaction, redactionOptions, err := configureRedaction(cfg, forwarding)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize Jamf Pro client: %w", err)
+		return nil, fmt.Errorf("failed to configure redaction: %w", err)
+	}
+	wireLogger := logger.WithOptions(redactionOptions...)
+	logger = forwarding.WithOptions(redactionOptions...)
+
+	toolFilter, err := toolsets.NewToolFilter(cfg.AllowedTools, cfg.DeniedTools)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool filter: %w", err)
 	}
 
 	server := &Server{
-		config:     cfg,
-		logger:     logger,
-		mcpServer:  mcpServer,
-		jamfClient: jamfClient,
-		toolsets:   make(map[string]toolsets.Toolset),
+		config:       cfg,
+		logger:       logger,
+		wireLogger:   wireLogger,
+		mcpServer:    mcpServer,
+		redaction:    redaction,
+		instances:    make(map[string]*jamfInstance),
+		toolFilter:   toolFilter,
+		toolsets:     make(map[string]toolsets.Toolset),
+		callToolsets: make(map[callToolsetKey]toolsets.Toolset),
+		translations: mcp.LoadTranslations(),
+	}
+	server.httpSessions = newHTTPSessionTracker(mcpServer, logger,
+		time.Duration(cfg.HTTPSessionIdleSeconds)*time.Second, cfg.HTTPMaxSessions)
+	server.descriptions = server.descriptionOverrides(cfg)
+
+	// Initialize Jamf Pro clients
+	if err := server.initializeInstances(); err != nil {
+		return nil, fmt.Errorf("failed to initialize Jamf Pro client: %w", err)
+	}
+
+	if cfg.RequireConfirmation {
+		server.confirmations = toolsets.NewConfirmationStore(time.Duration(cfg.ConfirmationTTLSeconds) * time.Second)
+	}
+
+	if err := server.initializeAccessProfiles(); err != nil {
+		return nil, fmt.Errorf("failed to initialize access profiles: %w", err)
+	}
+
+	// Auditing is a compliance control, so refuse to start without it once configured
+	if err := server.initializeAudit(); err != nil {
+		return nil, fmt.Errorf("failed to initialize audit log: %w", err)
 	}
 
 	// Initialize toolsets
@@ -54,254 +141,185 @@ func New(cfg *config.Config, logger *zap.Logger) (*Server, error) {
 		// Continue anyway, resources are optional
 	}
 
+	// Initialize prompts
+	if err := server.initializePrompts(); err != nil {
+		logger.Warn("Failed to initialize prompts", zap.Error(err))
+		// Continue anyway, prompts are optional
+	}
+
 	return server, nil
 }
 
-// Start starts the MCP server
+// Start starts the MCP server on the configured transport
 func (s *Server) Start(ctx context.Context) error {
-	s.logger.Info("Starting MCP server")
-
-	// Create a scanner for reading from stdin
-	scanner := bufio.NewScanner(os.Stdin)
-
-	// Process messages from stdin
-	for scanner.Scan() {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-			line := scanner.Text()
-			if line == "" {
-				continue
-			}
+	s.logger.Info("Starting MCP server", zap.String("transport", s.config.Transport))
 
-			s.logger.Debug("Received message", zap.String("message", line))
+	if s.auditor != nil {
+		defer s.auditor.Close()
+	}
 
-			if err := s.processMessage(ctx, line); err != nil {
-				s.logger.Error("Failed to process message", zap.Error(err))
+	// Closing the sessions stops their notification delivery once the transport returns
+	defer s.mcpServer.Close()
 
-				// Send error response
-				errorMsg := &mcp.Message{
-					JSONRPC: "2.0",
-					Error: &mcp.Error{
-						Code:    mcp.InternalError,
-						Message: err.Error(),
-					},
-				}
+	s.watchResources(ctx)
 
-				if err := s.sendMessage(errorMsg); err != nil {
-					s.logger.Error("Failed to send error message", zap.Error(err))
-				}
-			}
-		}
+	switch s.config.Transport {
+	case "http":
+		return s.serveHTTP(ctx)
+	default:
+		return s.serveStdio(ctx)
 	}
+}
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("scanner error: %w", err)
-	}
+// initializeToolsets initializes the available toolsets
+func (s *Server) initializeToolsets() error {
+	s.logger.Info("Initializing toolsets", zap.Strings("enabled_toolsets", s.config.Toolsets))
 
-	return nil
-}
+	// Create toolset factory
+	s.factory = toolsets.NewFactory(s.instances[s.config.PrimaryInstance].client, s.logger)
+	s.factory.SetRedaction(s.redaction)
+	s.registerInstanceTools()
+
+	if len(s.config.AllowedTools) > 0 || len(s.config.DeniedTools) > 0 {
+		s.logger.Info("Filtering tools",
+			zap.Strings("allowed_tools", s.config.AllowedTools),
+			zap.Strings("denied_tools", s.config.DeniedTools))
+		s.warnUnmatchedToolPatterns()
+	}
 
-// processMessage processes an incoming message
-func (s *Server) processMessage(ctx context.Context, messageText string) error {
-	var msg mcp.Message
-	if err := json.Unmarshal([]byte(messageText), &msg); err != nil {
-		return fmt.Errorf("failed to unmarshal message: %w", err)
+	if len(s.descriptions) > 0 {
+		s.logger.Info("Applying description overrides", zap.Int("overrides", len(s.descriptions)))
+		s.warnUnmatchedDescriptions()
 	}
 
-	// Handle the message
-	response, err := s.mcpServer.HandleMessage(ctx, &msg)
-	if err != nil {
-		return fmt.Errorf("failed to handle message: %w", err)
+	// In dynamic mode clients enable toolsets themselves through the meta-tools
+	if s.config.DynamicToolsets {
+		s.registerDynamicToolsetTools()
+		s.logger.Info("Dynamic toolset discovery enabled",
+			zap.Strings("available_toolsets", s.availableToolsets()))
+		return nil
 	}
 
-	// Send the response
-	return s.sendMessage(response)
-}
+	// Determine which toolsets to enable
+	enabledToolsets := s.getEnabledToolsets()
 
-// sendMessage sends a message to stdout
-func (s *Server) sendMessage(msg *mcp.Message) error {
-	data, err := json.Marshal(msg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal message: %w", err)
-	}
+	// Initialize each enabled toolset
+	for _, toolsetName := range enabledToolsets {
+		s.logger.Debug("Initializing toolset", zap.String("toolset", toolsetName))
 
-	// Write to stdout with newline
-	if _, err := fmt.Fprintln(os.Stdout, string(data)); err != nil {
-		return fmt.Errorf("failed to write message: %w", err)
+		if _, _, err := s.enableToolset(toolsetName); err != nil {
+			s.logger.Warn("Failed to create toolset",
+				zap.String("toolset", toolsetName),
+				zap.Error(err))
+		}
 	}
 
-	s.logger.Debug("Sent message", zap.String("message", string(data)))
+	s.logger.Info("Toolset initialization complete",
+		zap.Int("toolsets_initialized", len(s.toolsets)),
+		zap.Int("total_tools_registered", len(s.mcpServer.GetRegisteredTools())))
+
 	return nil
 }
 
-// initializeJamfClient initializes the Jamf Pro client
-func initializeJamfClient(cfg *config.Config, logger *zap.Logger) (*jamfpro.Client, error) {
-	logger.Info("Initializing Jamf Pro client",
-		zap.String("instance_url", cfg.JamfInstanceURL),
-		zap.String("auth_method", cfg.AuthMethod),
-	)
+// enableToolset creates a toolset and registers its tools with the MCP server. It reports
+// false without error if the toolset was already enabled.
+func (s *Server) enableToolset(name string) (toolsets.Toolset, bool, error) {
+	s.toolsetsMu.Lock()
+	defer s.toolsetsMu.Unlock()
 
-	// Set environment variables for the Jamf Pro SDK
-	if err := setJamfEnvironmentVariables(cfg); err != nil {
-		return nil, fmt.Errorf("failed to set environment variables: %w", err)
+	if toolset, exists := s.toolsets[name]; exists {
+		return toolset, false, nil
 	}
 
-	// Build client using environment variables
-	client, err := jamfpro.BuildClientWithEnv()
+	toolset, err := s.factory.CreateToolset(name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build Jamf Pro client: %w", err)
+		return nil, false, err
 	}
 
-	// Test the connection
-	logger.Info("Testing Jamf Pro connection")
-	_, err = client.GetJamfProInformation()
-	if err != nil {
-		logger.Warn("Failed to test Jamf Pro connection, but continuing", zap.Error(err))
-		// Don't fail here as the connection might work for other operations
-	} else {
-		logger.Info("Successfully connected to Jamf Pro")
+	// Destructive tools need a confirmation round trip before they run
+	if s.confirmations != nil {
+		toolset = toolsets.NewConfirmingToolset(toolset, s.confirmations)
 	}
 
-	return client, nil
-}
+	s.toolsets[name] = toolset
 
-// setJamfEnvironmentVariables sets environment variables for the Jamf Pro SDK - FIXED with validation
-func setJamfEnvironmentVariables(cfg *config.Config) error {
-	// Validate URL format first
-	if !isValidURL(cfg.JamfInstanceURL) {
-		return fmt.Errorf("invalid Jamf instance URL format: %s", cfg.JamfInstanceURL)
-	}
-
-	envVars := map[string]string{
-		"INSTANCE_DOMAIN":                     cfg.JamfInstanceURL,
-		"AUTH_METHOD":                         cfg.AuthMethod,
-		"LOG_LEVEL":                           cfg.LogLevel,
-		"LOG_OUTPUT_FORMAT":                   "json",
-		"HIDE_SENSITIVE_DATA":                 fmt.Sprintf("%t", cfg.HideSensitiveData),
-		"MAX_RETRY_ATTEMPTS":                  fmt.Sprintf("%d", cfg.MaxRetryAttempts),
-		"ENABLE_DYNAMIC_RATE_LIMITING":        fmt.Sprintf("%t", cfg.EnableDynamicRateLimiting),
-		"MAX_CONCURRENT_REQUESTS":             fmt.Sprintf("%d", cfg.MaxConcurrentRequests),
-		"TOKEN_REFRESH_BUFFER_PERIOD_SECONDS": fmt.Sprintf("%d", cfg.TokenRefreshBufferSeconds),
-		"TOTAL_RETRY_DURATION_SECONDS":        fmt.Sprintf("%d", cfg.TotalRetryDurationSeconds),
-		"CUSTOM_TIMEOUT_SECONDS":              fmt.Sprintf("%d", cfg.CustomTimeoutSeconds),
-		"FOLLOW_REDIRECTS":                    fmt.Sprintf("%t", cfg.FollowRedirects),
-		"MAX_REDIRECTS":                       fmt.Sprintf("%d", cfg.MaxRedirects),
-		"ENABLE_CONCURRENCY_MANAGEMENT":       fmt.Sprintf("%t", cfg.EnableConcurrencyManagement),
-		"JAMF_LOAD_BALANCER_LOCK":             fmt.Sprintf("%t", cfg.JamfLoadBalancerLock),
-	}
-
-	// Add auth-specific variables with validation
-	if cfg.AuthMethod == "oauth2" {
-		if cfg.JamfClientID == "" {
-			return fmt.Errorf("client ID is required for OAuth2 authentication")
-		}
-		if cfg.JamfClientSecret == "" {
-			return fmt.Errorf("client secret is required for OAuth2 authentication")
-		}
-		envVars["CLIENT_ID"] = cfg.JamfClientID
-		envVars["CLIENT_SECRET"] = cfg.JamfClientSecret
-	} else {
-		if cfg.JamfUsername == "" {
-			return fmt.Errorf("username is required for basic authentication")
-		}
-		if cfg.JamfPassword == "" {
-			return fmt.Errorf("password is required for basic authentication")
+	for _, tool := range toolset.GetTools() {
+		if allowed, reason := s.toolFilter.Allows(tool.Name); !allowed {
+			s.logger.Info("Tool filtered by configuration",
+				zap.String("toolset", name),
+				zap.String("tool", tool.Name),
+				zap.String("reason", reason))
+			continue
 		}
-		envVars["BASIC_AUTH_USERNAME"] = cfg.JamfUsername
-		envVars["BASIC_AUTH_PASSWORD"] = cfg.JamfPassword
-	}
 
-	// Set all environment variables
-	for key, value := range envVars {
-		if err := os.Setenv(key, value); err != nil {
-			return fmt.Errorf("failed to set environment variable %s: %w", key, err)
-		}
-	}
+		tool = s.toolDefinition(tool)
+		s.mcpServer.RegisterToolDefinition(&tool)
 
-	return nil
-}
+		s.mcpServer.RegisterTool(tool.Name, s.createToolHandler(name, toolset, tool))
+		s.logger.Debug("Registered tool",
+			zap.String("toolset", name),
+			zap.String("tool", tool.Name))
+	}
 
-// isValidURL validates URL format - ADDED helper function
-func isValidURL(str string) bool {
-	u, err := url.Parse(str)
-	return err == nil && u.Scheme != "" && u.Host != ""
+	return toolset, true, nil
 }
 
-// initializeToolsets initializes the available toolsets
-func (s *Server) initializeToolsets() error {
-	s.logger.Info("Initializing toolsets", zap.Strings("enabled_toolsets", s.config.Toolsets))
-
-	// Create toolset factory
-	factory := toolsets.NewFactory(s.jamfClient, s.logger)
-
-	// Determine which toolsets to enable
-	enabledToolsets := s.getEnabledToolsets()
-
-	// Initialize each enabled toolset
-	for _, toolsetName := range enabledToolsets {
-		s.logger.Debug("Initializing toolset", zap.String("toolset", toolsetName))
-
-		toolset, err := factory.CreateToolset(toolsetName)
+// warnUnmatchedToolPatterns warns about allowed_tools and denied_tools patterns that match no
+// tool in the available toolsets, which usually means the pattern has a typo
+func (s *Server) warnUnmatchedToolPatterns() {
+	var toolNames []string
+	for _, name := range s.getEnabledToolsets() {
+		toolset, err := s.factory.CreateToolset(name)
 		if err != nil {
-			s.logger.Warn("Failed to create toolset",
-				zap.String("toolset", toolsetName),
-				zap.Error(err))
 			continue
 		}
-
-		s.toolsets[toolsetName] = toolset
-
-		tools := toolset.GetTools()
-		for _, tool := range tools {
-			s.mcpServer.RegisterToolDefinition(&tool)
-
-			s.mcpServer.RegisterTool(tool.Name, s.createToolHandler(toolset, tool.Name))
-			s.logger.Debug("Registered tool",
-				zap.String("toolset", toolsetName),
-				zap.String("tool", tool.Name))
+		for _, tool := range toolset.GetTools() {
+			toolNames = append(toolNames, tool.Name)
 		}
 	}
 
-	s.logger.Info("Toolset initialization complete",
-		zap.Int("toolsets_initialized", len(s.toolsets)),
-		zap.Int("total_tools_registered", len(s.mcpServer.GetRegisteredTools())))
-
-	return nil
+	for _, pattern := range s.toolFilter.UnmatchedPatterns(toolNames) {
+		s.logger.Warn("Tool pattern does not match any available tool", zap.String("pattern", pattern))
+	}
 }
 
 // getEnabledToolsets returns the list of toolsets that should be enabled
 func (s *Server) getEnabledToolsets() []string {
-	// If "all" is specified, return all available toolsets
-	for _, toolset := range s.config.Toolsets {
-		if toolset == "all" {
-			return []string{
-				"computers", "computer-inventory", "mobile-devices", "policies",
-				"scripts", "users", "groups", "configuration-profiles", "buildings",
-				"departments", "categories", "sites", "api-roles", "api-integrations",
-				"inventory", "packages", "printers", "network-segments",
-				"webhooks", "vpp", "advanced-searches", "extension-attributes",
-				"ldap", "self-service", "patch-management", "mobile-applications",
-				"mac-applications", "restrictions", "disk-encryption",
-				"enrollment", "jamf-pro-information",
-			}
-		}
-	}
+	s.liveMu.RLock()
+	defer s.liveMu.RUnlock()
 
-	return s.config.Toolsets
+	return toolsets.ExpandToolsets(s.config.Toolsets)
 }
 
 // createToolHandler creates a tool handler for a specific tool
-func (s *Server) createToolHandler(toolset toolsets.Toolset, toolName string) mcp.ToolHandler {
+func (s *Server) createToolHandler(toolsetName string, toolset toolsets.Toolset, tool mcp.Tool) mcp.ToolHandler {
+	toolName := tool.Name
+
 	return func(ctx context.Context, params mcp.CallToolParams) (*mcp.CallToolResult, error) {
-		s.logger.Debug("Executing tool",
+		// Log messages about the call go to the calling client alone
+		logger := s.logger.With(mcp.SessionField(ctx))
+		logger.Debug("Executing tool",
 			zap.String("tool", toolName),
 			zap.Any("arguments", params.Arguments))
 
+		instance, err := s.instanceForCall(params.Arguments)
+		if err != nil {
+			return textResult(err.Error(), true), nil
+		}
+
+		toolset, err := s.callToolset(ctx, instance, toolsetName, toolset)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, output := toolsets.WithStructuredOutput(ctx)
 		result, err := toolset.ExecuteTool(ctx, toolName, params.Arguments)
+		if err != nil && ctx.Err() != nil {
+			logger.Info("Tool execution cancelled by client", zap.String("tool", toolName))
+			return nil, ctx.Err()
+		}
 		if err != nil {
-			s.logger.Error("Tool execution failed",
+			logger.Error("Tool execution failed",
 				zap.String("tool", toolName),
 				zap.Error(err))
 
@@ -316,9 +334,9 @@ func (s *Server) createToolHandler(toolset toolsets.Toolset, toolName string) mc
 			}, nil
 		}
 
-		s.logger.Debug("Tool execution successful", zap.String("tool", toolName))
+		logger.Debug("Tool execution successful", zap.String("tool", toolName))
 
-		return &mcp.CallToolResult{
+		callResult := &mcp.CallToolResult{
 			Content: []mcp.ToolContent{
 				{
 					Type: "text",
@@ -326,8 +344,173 @@ func (s *Server) createToolHandler(toolset toolsets.Toolset, toolName string) mc
 				},
 			},
 			IsError: false,
-		}, nil
+		}
+
+		// Tools with an output schema return the rendered value as structured content too. A
+		// call that rendered nothing, such as one answered with "No data returned", still has
+		// to conform to the schema, so it returns an empty object; generated schemas require
+		// no properties.
+		if tool.OutputSchema != nil {
+			structured := output.Value()
+			if structured == nil {
+				structured = map[string]interface{}{}
+			}
+			callResult.StructuredContent = structured
+		}
+
+		return callResult, nil
+	}
+}
+
+// initializeAudit opens the configured audit sinks
+func (s *Server) initializeAudit() error {
+	var sinks []audit.Sink
+
+	if s.config.AuditLogFile != "" {
+		maxBytes := int64(s.config.AuditLogMaxSizeMB) * 1024 * 1024
+		sink, err := audit.NewFileSink(s.config.AuditLogFile, maxBytes, s.config.AuditLogMaxBackups)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, sink)
+		s.logger.Info("Writing audit log", zap.String("file", s.config.AuditLogFile))
+	}
+
+	if s.config.AuditSyslogSocket != "" {
+		sink, err := audit.NewSyslogSink(s.config.AuditSyslogSocket)
+		if err != nil {
+			for _, opened := range sinks {
+				opened.Close()
+			}
+			return err
+		}
+		sinks = append(sinks, sink)
+		s.logger.Info("Sending audit log to syslog", zap.String("socket", s.config.AuditSyslogSocket))
+	}
+
+	if len(sinks) > 0 {
+		s.auditor = audit.NewLogger(sinks...)
+		s.mcpServer.SetToolCallObserver(s.auditToolCall)
+	}
+
+	return nil
+}
+
+// configureRedaction returns the redaction that masks the default and configured secret paths
+// in tool responses and the logger options that mask them in everything a logger writes.
+// Redaction is off when hide_sensitive_data is.
+func configureRedaction(cfg *config.Config, logger *zap.Logger) (toolsets.Redaction, []zap.Option, error) {
+	if !cfg.HideSensitiveData {
+		logger.Warn("Sensitive data redaction is disabled; secrets will appear in tool output and logs")
+		return toolsets.Redaction{}, nil, nil
+	}
+
+	paths := append(append([]string(nil), redact.DefaultPaths...), cfg.RedactPaths...)
+	redactor, err := redact.New(paths)
+	if err != nil {
+		return toolsets.Redaction{}, nil, err
+	}
+
+	if cfg.AllowSecretReveal {
+		logger.Warn("Secret reveal is allowed; tools called with reveal_secrets return unmasked values")
 	}
+
+	redaction := toolsets.Redaction{Redactor: redactor, AllowReveal: cfg.AllowSecretReveal}
+	return redaction, []zap.Option{redact.ZapOption(redactor)}, nil
+}
+
+// auditToolCall records a tool call with the calling session and client, including calls
+// refused before they reached the tool
+func (s *Server) auditToolCall(ctx context.Context, call *mcp.ToolCall) {
+	record := s.auditRecord(ctx, call.Started, call.Params.Arguments)
+	record.Toolset = call.Tool.Toolset
+	record.Tool = call.Tool.Name
+	record.Access = string(call.Tool.Access)
+
+	switch {
+	case call.Denied:
+		record.Outcome = audit.OutcomeDenied
+	case call.Err != nil && ctx.Err() != nil:
+		record.Outcome = audit.OutcomeCancelled
+	case call.Err != nil:
+		record.Outcome = audit.OutcomeError
+	case call.Result.IsError:
+		record.Outcome = audit.OutcomeError
+		if len(call.Result.Content) > 0 {
+			record.Error = call.Result.Content[0].Text
+		}
+	case s.awaitsConfirmation(call.Tool, call.Params):
+		record.Outcome = audit.OutcomeConfirmationRequired
+	default:
+		record.Outcome = audit.OutcomeSuccess
+	}
+	if call.Err != nil {
+		record.Error = call.Err.Error()
+	}
+
+	s.writeAuditRecord(record)
+}
+
+// auditResourceRead records a read of a Jamf Pro resource, which returns the same object as
+// the tool that governs access to it
+func (s *Server) auditResourceRead(ctx context.Context, template *jamfResourceTemplate, id, uri string, started time.Time, outcome string, err error) {
+	if s.auditor == nil {
+		return
+	}
+
+	arguments := map[string]interface{}{"id": id}
+	record := s.auditRecord(ctx, started, arguments)
+	record.Tool = template.tool
+	record.Resource = uri
+	record.Access = string(mcp.ToolAccessRead)
+	record.Outcome = outcome
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	s.writeAuditRecord(record)
+}
+
+// auditRecord starts an audit record of a call with the given arguments by the session in ctx
+func (s *Server) auditRecord(ctx context.Context, started time.Time, arguments map[string]interface{}) audit.Record {
+	record := audit.Record{
+		Time:       started.UTC(),
+		Instance:   s.requestedInstance(arguments),
+		Arguments:  audit.RedactArguments(arguments),
+		Targets:    audit.TargetsFromArguments(arguments),
+		DurationMS: time.Since(started).Milliseconds(),
+	}
+
+	if session := mcp.SessionFromContext(ctx); session != nil {
+		clientInfo := session.ClientInfo()
+		record.SessionID = session.ID()
+		record.Client = audit.Client{Name: clientInfo.Name, Version: clientInfo.Version}
+		if profile := session.AccessProfile(); profile != nil {
+			record.AccessProfile = profile.Name
+		}
+	}
+
+	return record
+}
+
+// writeAuditRecord writes a record to the audit sinks and logs any failure
+func (s *Server) writeAuditRecord(record audit.Record) {
+	if err := s.auditor.Log(record); err != nil {
+		s.logger.Error("Failed to write audit record",
+			zap.String("tool", record.Tool),
+			zap.Error(err))
+	}
+}
+
+// awaitsConfirmation reports whether a successful call only previewed a destructive action
+// and issued a confirmation token rather than performing it
+func (s *Server) awaitsConfirmation(tool mcp.Tool, params mcp.CallToolParams) bool {
+	if s.confirmations == nil || tool.Access != mcp.ToolAccessDestructive {
+		return false
+	}
+
+	token, _ := params.Arguments[toolsets.ConfirmationTokenArgument].(string)
+	return token == ""
 }
 
 // initializeResourceProvider initializes the resource provider
@@ -347,13 +530,34 @@ func (s *Server) initializeResourceProvider() error {
 		return fmt.Errorf("failed to register resource directories: %w", err)
 	}
 
-	// Set the resource provider on the MCP server
-	s.mcpServer.SetResourceProvider(resourceProvider)
+	// Serve Jamf Pro objects alongside the local files
+	s.fileResources = resourceProvider
+	s.jamfResources = newJamfResourceProvider(s)
+	s.mcpServer.SetResourceProvider(mcp.NewMultiResourceProvider(s.fileResources, s.jamfResources))
 
 	s.logger.Info("Resource provider initialized")
 	return nil
 }
 
+// watchResources reports changes to subscribed resources until ctx is cancelled: local files
+// are watched on disk and Jamf Pro objects are polled
+func (s *Server) watchResources(ctx context.Context) {
+	// The resource provider is optional and may have failed to initialize
+	if s.fileResources == nil {
+		return
+	}
+
+	if err := s.fileResources.Watch(ctx, s.mcpServer.NotifyResourceUpdated); err != nil {
+		s.logger.Warn("Failed to watch resource files; subscribers will not be told of changes", zap.Error(err))
+	}
+
+	if s.config.ResourcePollSeconds > 0 {
+		interval := time.Duration(s.config.ResourcePollSeconds) * time.Second
+		s.logger.Info("Polling subscribed Jamf Pro resources", zap.Duration("interval", interval))
+		go s.jamfResources.poll(ctx, interval)
+	}
+}
+
 // registerCommonResourceDirectories registers commonly used directories as resources
 func (s *Server) registerCommonResourceDirectories(provider *mcp.FileResourceProvider) error {
 	// Register templates directory if it exists
@@ -404,6 +608,30 @@ func (s *Server) registerCommonResourceDirectories(provider *mcp.FileResourcePro
 	return nil
 }
 
+// initializePrompts registers the built-in workflow prompts and any prompt templates found in
+// the configured prompts directory
+func (s *Server) initializePrompts() error {
+	s.logger.Info("Initializing prompts")
+
+	registry := mcp.NewPromptRegistry()
+	if err := prompts.Register(registry); err != nil {
+		return err
+	}
+
+	// Set the registry first so the built-in prompts survive a bad template directory
+	s.mcpServer.SetPromptRegistry(registry)
+
+	if dir := s.config.PromptsDirectory; dir != "" && dirExists(dir) {
+		if err := registry.RegisterDirectory(dir); err != nil {
+			return fmt.Errorf("failed to register prompts directory: %w", err)
+		}
+		s.logger.Info("Registered prompts directory", zap.String("directory", dir))
+	}
+
+	s.logger.Info("Prompts initialized", zap.Int("count", len(registry.ListPrompts())))
+	return nil
+}
+
 // dirExists checks if a directory exists at the given path
 func dirExists(path string) bool {
 	info, err := os.Stat(path)
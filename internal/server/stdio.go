@@ -0,0 +1,226 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"go.uber.org/zap"
+)
+
+// maxStdioMessageBytes caps the size of a single newline-delimited message read from stdin
+const maxStdioMessageBytes = 10 << 20
+
+// serveStdio reads newline-delimited JSON-RPC messages from stdin and writes responses to stdout
+func (s *Server) serveStdio(ctx context.Context) error {
+	// A stdio connection carries exactly one client session
+	session, err := s.mcpServer.CreateSession()
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	defer s.mcpServer.CloseSession(session.ID())
+
+	ctx, cancel := context.WithCancel(mcp.ContextWithSession(ctx, session))
+	defer cancel()
+
+	dispatcher := newStdioDispatcher(s, newMessageWriter(os.Stdout), s.config.MaxConcurrentRequests)
+
+	// Notifications share stdout with responses
+	session.SetNotifier(func(msg *mcp.Message) error {
+		dispatcher.send(msg)
+		return nil
+	})
+
+	// Let in-flight requests finish writing their responses before returning
+	defer dispatcher.wait()
+
+	// Create a scanner for reading from stdin
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStdioMessageBytes)
+
+	// Process messages from stdin
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			line := scanner.Text()
+			if line == "" {
+				continue
+			}
+
+			s.wireLogger.Debug("Received message", zap.String("message", line))
+			dispatcher.dispatch(ctx, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("scanner error: %w", err)
+	}
+
+	return nil
+}
+
+// stdioQueueSize caps the number of stdio requests waiting for a worker. Requests beyond it
+// are refused so that the reader keeps up with cancellations.
+const stdioQueueSize = 256
+
+// stdioRequest is a request waiting for a worker, with the context its cancellation stops
+type stdioRequest struct {
+	ctx  context.Context
+	msg  *mcp.Message
+	done context.CancelFunc
+}
+
+// stdioDispatcher runs stdio requests concurrently on a fixed pool of workers
+type stdioDispatcher struct {
+	server *Server
+	writer *messageWriter
+	queue  chan *stdioRequest
+	wg     sync.WaitGroup
+}
+
+// newStdioDispatcher creates a dispatcher and starts maxConcurrent workers, so that at most
+// that many requests run at once
+func newStdioDispatcher(server *Server, writer *messageWriter, maxConcurrent int) *stdioDispatcher {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+
+	d := &stdioDispatcher{
+		server: server,
+		writer: writer,
+		queue:  make(chan *stdioRequest, stdioQueueSize),
+	}
+
+	d.wg.Add(maxConcurrent)
+	for i := 0; i < maxConcurrent; i++ {
+		go d.work()
+	}
+	return d
+}
+
+// dispatch decodes a message and schedules it for processing. Notifications are handled
+// inline so that they are never queued behind slow requests. A request is tracked before it
+// is queued, so that notifications/cancelled can stop it while it waits.
+func (d *stdioDispatcher) dispatch(ctx context.Context, line string) {
+	var msg mcp.Message
+	if err := json.Unmarshal([]byte(line), &msg); err != nil {
+		d.server.logger.Error("Failed to unmarshal message", zap.Error(err), mcp.SessionField(ctx))
+		d.send(&mcp.Message{
+			JSONRPC: "2.0",
+			Error: &mcp.Error{
+				Code:    mcp.ParseError,
+				Message: fmt.Sprintf("failed to unmarshal message: %v", err),
+			},
+		})
+		return
+	}
+
+	if msg.ID == nil {
+		d.process(ctx, &msg)
+		return
+	}
+
+	reqCtx, done := d.server.mcpServer.TrackRequest(ctx, msg.ID)
+	select {
+	case d.queue <- &stdioRequest{ctx: reqCtx, msg: &msg, done: done}:
+	default:
+		done()
+		d.send(&mcp.Message{
+			JSONRPC: "2.0",
+			ID:      msg.ID,
+			Error: &mcp.Error{
+				Code:    mcp.ServerError,
+				Message: fmt.Sprintf("too many requests in progress, at most %d may wait", stdioQueueSize),
+			},
+		})
+	}
+}
+
+// work processes queued requests until the queue is closed, skipping requests that were
+// cancelled while they waited
+func (d *stdioDispatcher) work() {
+	defer d.wg.Done()
+
+	for req := range d.queue {
+		if req.ctx.Err() == nil {
+			d.process(req.ctx, req.msg)
+		}
+		req.done()
+	}
+}
+
+// process handles a single message and writes its response, if any
+func (d *stdioDispatcher) process(ctx context.Context, msg *mcp.Message) {
+	response, err := d.server.mcpServer.HandleMessage(ctx, msg)
+	if err != nil {
+		d.server.logger.Error("Failed to process message", zap.Error(err), mcp.SessionField(ctx))
+		response = &mcp.Message{
+			JSONRPC: "2.0",
+			ID:      msg.ID,
+			Error: &mcp.Error{
+				Code:    mcp.InternalError,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	// Notifications and cancelled requests never receive a response
+	if response == nil {
+		return
+	}
+
+	d.send(response)
+}
+
+// send writes a message to the client and logs any failure
+func (d *stdioDispatcher) send(msg *mcp.Message) {
+	data, err := d.writer.WriteMessage(msg)
+	if err != nil {
+		d.server.wireLogger.Error("Failed to send message", zap.Error(err))
+		return
+	}
+
+	d.server.wireLogger.Debug("Sent message", zap.String("message", string(data)))
+}
+
+// wait stops accepting requests and blocks until the queued ones have completed or been
+// skipped
+func (d *stdioDispatcher) wait() {
+	close(d.queue)
+	d.wg.Wait()
+}
+
+// messageWriter serialises newline-delimited JSON messages onto a shared writer
+type messageWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// newMessageWriter creates a message writer for w
+func newMessageWriter(w io.Writer) *messageWriter {
+	return &messageWriter{w: w}
+}
+
+// WriteMessage marshals msg and writes it as a single line, returning the encoded bytes
+func (mw *messageWriter) WriteMessage(msg *mcp.Message) ([]byte, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	if _, err := mw.w.Write(append(data, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to write message: %w", err)
+	}
+
+	return data, nil
+}
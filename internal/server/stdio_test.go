@@ -0,0 +1,161 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newDispatcherTest creates a dispatcher over an initialized session of a bare server and
+// returns it with the session context and the buffer its responses are written to
+func newDispatcherTest(t *testing.T, maxConcurrent int) (*stdioDispatcher, *mcp.Server, context.Context, *bytes.Buffer) {
+	t.Helper()
+
+	s := &Server{
+		mcpServer:  mcp.NewServer("test-server", "1.0.0"),
+		logger:     zap.NewNop(),
+		wireLogger: zap.NewNop(),
+	}
+	session, err := s.mcpServer.CreateSession()
+	require.NoError(t, err)
+	ctx := mcp.ContextWithSession(context.Background(), session)
+
+	response, err := s.mcpServer.HandleMessage(ctx, &mcp.Message{JSONRPC: "2.0", ID: 0, Method: "initialize", Params: map[string]interface{}{}})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+
+	var output bytes.Buffer
+	return newStdioDispatcher(s, newMessageWriter(&output), maxConcurrent), s.mcpServer, ctx, &output
+}
+
+// callLine returns a tools/call request line for the named tool
+func callLine(id int, name string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"tools/call","params":{"name":%q}}`, id, name)
+}
+
+// responseIDs decodes the responses written by a dispatcher and returns their IDs in order
+func responseIDs(t *testing.T, output *bytes.Buffer) []float64 {
+	t.Helper()
+
+	var ids []float64
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var msg mcp.Message
+		require.NoError(t, json.Unmarshal([]byte(line), &msg))
+		ids = append(ids, msg.ID.(float64))
+	}
+	return ids
+}
+
+// registerTool registers a tool on server that runs fn and returns a text result
+func registerTool(server *mcp.Server, name string, fn func(ctx context.Context)) {
+	server.RegisterToolDefinition(&mcp.Tool{Name: name})
+	server.RegisterTool(name, func(ctx context.Context, params mcp.CallToolParams) (*mcp.CallToolResult, error) {
+		fn(ctx)
+		return &mcp.CallToolResult{Content: []mcp.ToolContent{{Type: "text", Text: "ok"}}}, nil
+	})
+}
+
+// TestStdioDispatcherKeepsOrderWithOneWorker tests that a single worker answers requests in
+// the order they arrived
+func TestStdioDispatcherKeepsOrderWithOneWorker(t *testing.T) {
+	dispatcher, server, ctx, output := newDispatcherTest(t, 1)
+
+	var mu sync.Mutex
+	var order []string
+	registerTool(server, "first_tool", func(ctx context.Context) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, "first_tool")
+	})
+	registerTool(server, "second_tool", func(ctx context.Context) {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, "second_tool")
+	})
+
+	dispatcher.dispatch(ctx, callLine(1, "first_tool"))
+	dispatcher.dispatch(ctx, callLine(2, "second_tool"))
+	dispatcher.dispatch(ctx, callLine(3, "first_tool"))
+	dispatcher.wait()
+
+	assert.Equal(t, []string{"first_tool", "second_tool", "first_tool"}, order)
+	assert.Equal(t, []float64{1, 2, 3}, responseIDs(t, output))
+}
+
+// TestStdioDispatcherCapsConcurrency tests that no more than maxConcurrent requests run at once
+// and that the rest wait for a worker rather than being refused
+func TestStdioDispatcherCapsConcurrency(t *testing.T) {
+	dispatcher, server, ctx, output := newDispatcherTest(t, 2)
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	started := make(chan struct{}, 5)
+	release := make(chan struct{})
+	registerTool(server, "slow_tool", func(ctx context.Context) {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+
+		started <- struct{}{}
+		<-release
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	for id := 1; id <= 5; id++ {
+		dispatcher.dispatch(ctx, callLine(id, "slow_tool"))
+	}
+
+	<-started
+	<-started
+	assert.Empty(t, started, "a third request started while two were running")
+
+	close(release)
+	dispatcher.wait()
+
+	assert.Equal(t, 2, peak)
+	assert.ElementsMatch(t, []float64{1, 2, 3, 4, 5}, responseIDs(t, output))
+}
+
+// TestStdioDispatcherCancelWhileQueued tests that notifications/cancelled for a request still
+// waiting for a worker stops it from running or being answered
+func TestStdioDispatcherCancelWhileQueued(t *testing.T) {
+	dispatcher, server, ctx, output := newDispatcherTest(t, 1)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	registerTool(server, "slow_tool", func(ctx context.Context) {
+		close(started)
+		<-release
+	})
+	queuedRan := false
+	registerTool(server, "queued_tool", func(ctx context.Context) {
+		queuedRan = true
+	})
+
+	dispatcher.dispatch(ctx, callLine(1, "slow_tool"))
+	<-started
+	dispatcher.dispatch(ctx, callLine(2, "queued_tool"))
+	dispatcher.dispatch(ctx, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":2}}`)
+
+	close(release)
+	dispatcher.wait()
+
+	assert.False(t, queuedRan)
+	assert.Equal(t, []float64{1}, responseIDs(t, output))
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/config"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/toolsets"
+	"go.uber.org/zap"
+)
+
+// descriptionOverrides merges the translations file and environment overrides with the
+// tool_descriptions and parameter_descriptions settings, which take precedence
+func (s *Server) descriptionOverrides(cfg *config.Config) map[string]string {
+	overrides := make(map[string]string, len(s.translations)+len(cfg.ToolDescriptions))
+	for key, value := range s.translations {
+		overrides[key] = value
+	}
+	for tool, description := range cfg.ToolDescriptions {
+		overrides[mcp.ToolDescriptionKey(tool)] = description
+	}
+	for tool, parameters := range cfg.ParameterDescriptions {
+		for parameter, description := range parameters {
+			overrides[mcp.ParameterDescriptionKey(tool, parameter)] = description
+		}
+	}
+	return overrides
+}
+
+// describeTool applies the description overrides to a tool
+func (s *Server) describeTool(tool mcp.Tool) mcp.Tool {
+	s.liveMu.RLock()
+	described := mcp.ApplyTranslations(tool, s.descriptions)
+	s.liveMu.RUnlock()
+
+	// Keep the confirmation instructions clients need to call destructive tools
+	if described.Description != tool.Description &&
+		strings.HasSuffix(tool.Description, toolsets.ConfirmationNote) &&
+		!strings.HasSuffix(described.Description, toolsets.ConfirmationNote) {
+		described.Description = toolsets.WithConfirmationNote(described.Description)
+	}
+
+	return described
+}
+
+// toolDefinition returns a toolset tool as it is registered with the MCP server, with the
+// instance argument and any description overrides applied
+func (s *Server) toolDefinition(tool mcp.Tool) mcp.Tool {
+	return s.describeTool(s.withInstanceArgument(tool))
+}
+
+// registerServerTool registers a tool the server itself provides rather than a toolset
+func (s *Server) registerServerTool(tool mcp.Tool, handler mcp.ToolHandler) {
+	s.serverTools = append(s.serverTools, tool)
+
+	tool = s.describeTool(tool)
+	s.mcpServer.RegisterToolDefinition(&tool)
+	s.mcpServer.RegisterTool(tool.Name, handler)
+}
+
+// availableToolDefinitions returns every tool the server can register with its current
+// configuration, before description overrides: the server's own tools and the tools of every
+// configured toolset that pass the tool filter and read-only mode
+func (s *Server) availableToolDefinitions() []mcp.Tool {
+	tools := append([]mcp.Tool(nil), s.serverTools...)
+
+	for _, name := range s.getEnabledToolsets() {
+		toolset, err := s.factory.CreateToolset(name)
+		if err != nil {
+			continue
+		}
+		if s.confirmations != nil {
+			toolset = toolsets.NewConfirmingToolset(toolset, s.confirmations)
+		}
+		for _, tool := range s.visibleTools(toolset) {
+			tools = append(tools, s.withInstanceArgument(tool))
+		}
+	}
+
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools
+}
+
+// warnUnmatchedDescriptions warns about description overrides that name no available tool or
+// parameter, which usually means the key has a typo
+func (s *Server) warnUnmatchedDescriptions() {
+	known := make(map[string]bool)
+	for _, tool := range s.availableToolDefinitions() {
+		known[mcp.ToolDescriptionKey(tool.Name)] = true
+		for parameter := range tool.InputSchema.Properties {
+			known[mcp.ParameterDescriptionKey(tool.Name, parameter)] = true
+		}
+	}
+
+	s.liveMu.RLock()
+	var unmatched []string
+	for key := range s.descriptions {
+		if !known[key] {
+			unmatched = append(unmatched, key)
+		}
+	}
+	s.liveMu.RUnlock()
+
+	sort.Strings(unmatched)
+	for _, key := range unmatched {
+		s.logger.Warn("Description override does not match any available tool or parameter", zap.String("key", key))
+	}
+}
+
+// ExportTranslations returns the default descriptions of exactly the tools and parameters the
+// server registers with cfg, keyed as in the translations file. No Jamf Pro connection is made.
+func ExportTranslations(cfg *config.Config, logger *zap.Logger) (map[string]string, error) {
+	toolFilter, err := toolsets.NewToolFilter(cfg.AllowedTools, cfg.DeniedTools)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		config:     cfg,
+		logger:     logger,
+		mcpServer:  mcp.NewServer("jamfpro-mcp-server", "1.0.0"),
+		factory:    toolsets.NewFactory(nil, logger),
+		instances:  make(map[string]*jamfInstance),
+		toolFilter: toolFilter,
+	}
+	for name := range cfg.Instances() {
+		s.instances[name] = &jamfInstance{name: name}
+	}
+	if cfg.RequireConfirmation {
+		s.confirmations = toolsets.NewConfirmationStore(time.Duration(cfg.ConfirmationTTLSeconds) * time.Second)
+	}
+
+	s.registerInstanceTools()
+	if cfg.DynamicToolsets {
+		s.registerDynamicToolsetTools()
+	}
+
+	tools := s.availableToolDefinitions()
+	for i, tool := range tools {
+		tools[i].Description = strings.TrimSuffix(tool.Description, toolsets.ConfirmationNote)
+	}
+
+	return mcp.ToolTranslations(tools), nil
+}
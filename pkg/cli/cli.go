@@ -0,0 +1,253 @@
+// Package cli provides the jamfpro-mcp-server command. A team that keeps private toolsets in
+// its own module builds its server with a main package that imports those toolsets for their
+// registration side effects and then calls Execute.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/config"
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/server"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/toolsets"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// BuildInfo identifies the build in the command's version output
+type BuildInfo struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+// Execute runs the jamfpro-mcp-server command with the toolsets registered so far
+func Execute(build BuildInfo) {
+	if err := NewCommand(build).Execute(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// NewCommand returns the root command. Its help text lists the toolsets registered when it is
+// called, so toolsets must be registered first.
+func NewCommand(build BuildInfo) *cobra.Command {
+	var rootCmd = &cobra.Command{
+		Use:     "jamfpro-mcp-server",
+		Short:   "Jamf Pro MCP (Model Context Protocol) Server",
+		Long:    "A Model Context Protocol server that provides seamless integration with Jamf Pro APIs, enabling advanced automation and interaction capabilities for AI tools and applications.\n\n" + toolsetHelp(),
+		Version: fmt.Sprintf("%s (commit: %s, built: %s)", build.Version, build.Commit, build.Date),
+		Run: func(cmd *cobra.Command, args []string) {
+			runServer(cmd, build)
+		},
+	}
+
+	// Global flags
+	rootCmd.PersistentFlags().String("config", "", "config file path")
+	rootCmd.PersistentFlags().String("log-level", "info", "log level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().StringSlice("toolsets", []string{"all"}, fmt.Sprintf("comma-separated list of toolsets to enable (%s,%s)", strings.Join(toolsets.Names(), ","), toolsets.AllToolsets))
+	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "enable dynamic toolset discovery")
+	rootCmd.PersistentFlags().Bool("read-only", false, "only expose tools that read from Jamf Pro (can also use JAMF_READ_ONLY)")
+	rootCmd.PersistentFlags().Bool("export-translations", false, "export tool descriptions to config file")
+	rootCmd.PersistentFlags().String("transport", "stdio", "transport to serve MCP over: stdio or http (can also use JAMF_TRANSPORT)")
+	rootCmd.PersistentFlags().String("http-address", "127.0.0.1:8080", "listen address for the http transport (can also use JAMF_HTTP_LISTEN_ADDRESS)")
+
+	// Environment variable support
+	rootCmd.PersistentFlags().String("jamf-instance-url", "", "Jamf Pro instance URL (can also use JAMF_INSTANCE_URL)")
+	rootCmd.PersistentFlags().String("jamf-client-id", "", "Jamf Pro client ID (can also use JAMF_CLIENT_ID)")
+	rootCmd.PersistentFlags().String("jamf-client-secret", "", "Jamf Pro client secret (can also use JAMF_CLIENT_SECRET)")
+	rootCmd.PersistentFlags().String("jamf-username", "", "Jamf Pro username for basic auth (can also use JAMF_USERNAME)")
+	rootCmd.PersistentFlags().String("jamf-password", "", "Jamf Pro password for basic auth (can also use JAMF_PASSWORD)")
+	rootCmd.PersistentFlags().String("jamf-client-secret-file", "", "file to read the Jamf Pro client secret from (can also use JAMF_CLIENT_SECRET_FILE)")
+	rootCmd.PersistentFlags().String("jamf-client-secret-command", "", "command that prints the Jamf Pro client secret (can also use JAMF_CLIENT_SECRET_COMMAND)")
+	rootCmd.PersistentFlags().String("jamf-password-file", "", "file to read the Jamf Pro password from (can also use JAMF_PASSWORD_FILE)")
+	rootCmd.PersistentFlags().String("jamf-password-command", "", "command that prints the Jamf Pro password (can also use JAMF_PASSWORD_COMMAND)")
+	rootCmd.PersistentFlags().String("auth-method", "oauth2", "authentication method: oauth2 or basic (can also use JAMF_AUTH_METHOD)")
+
+	return rootCmd
+}
+
+func runServer(cmd *cobra.Command, build BuildInfo) {
+
+	cfg, err := config.Load(cmd)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	logger, logLevel, err := initLogger(cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	if cfg.ExportTranslations {
+		if err := exportTranslations(cfg, logger); err != nil {
+			logger.Fatal("Failed to export translations", zap.Error(err))
+		}
+		return
+	}
+
+	if err := validateToolsets(cfg); err != nil {
+		logger.Fatal("Invalid toolsets", zap.Error(err))
+	}
+
+	logger.Info("Starting Jamf Pro MCP Server",
+		zap.String("version", build.Version),
+		zap.Strings("toolsets", cfg.Toolsets),
+		zap.Bool("dynamic-toolsets", cfg.DynamicToolsets),
+		zap.Bool("read-only", cfg.ReadOnly),
+		zap.String("transport", cfg.Transport),
+		zap.String("auth-method", cfg.AuthMethod),
+	)
+
+	// Create context for graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle signals for graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	// Initialize and start the MCP server
+	mcpServer, err := server.New(cfg, logger)
+	if err != nil {
+		logger.Fatal("Failed to create MCP server", zap.Error(err))
+	}
+
+	// Apply safe configuration changes without a restart
+	if cfg.Watch(func(updated *config.Config, err error) {
+		if err == nil {
+			err = validateToolsets(updated)
+		}
+		if err != nil {
+			logger.Warn("Ignoring invalid configuration change", zap.Error(err))
+			return
+		}
+		if err := mcpServer.Reload(updated); err != nil {
+			logger.Warn("Rejected configuration change", zap.Error(err))
+			return
+		}
+		logLevel.SetLevel(parseLogLevel(updated.LogLevel))
+	}) {
+		logger.Info("Watching configuration file for changes", zap.String("file", cfg.File()))
+	}
+
+	// Start server in a goroutine
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- mcpServer.Start(ctx)
+	}()
+
+	select {
+	case sig := <-sigChan:
+		logger.Info("Received shutdown signal", zap.String("signal", sig.String()))
+		cancel()
+	case err := <-errChan:
+		if err != nil {
+			logger.Error("Server error", zap.Error(err))
+		}
+	}
+
+	logger.Info("Shutting down Jamf Pro MCP Server")
+}
+
+func initLogger(level string) (*zap.Logger, zap.AtomicLevel, error) {
+	zapLevel := zap.NewAtomicLevelAt(parseLogLevel(level))
+
+	config := zap.NewProductionConfig()
+	config.Level = zapLevel
+	config.Development = false
+	config.DisableStacktrace = true
+
+	logger, err := config.Build()
+	return logger, zapLevel, err
+}
+
+// parseLogLevel maps a configured log level to a zap level, defaulting to info
+func parseLogLevel(level string) zapcore.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return zap.DebugLevel
+	case "warn":
+		return zap.WarnLevel
+	case "error":
+		return zap.ErrorLevel
+	default:
+		return zap.InfoLevel
+	}
+}
+
+// validateToolsets checks the toolsets setting and the toolsets of every access profile
+// against the registered toolsets
+func validateToolsets(cfg *config.Config) error {
+	if err := toolsets.ValidateToolsets(cfg.Toolsets); err != nil {
+		return err
+	}
+	for name, profile := range cfg.AccessProfiles {
+		if err := toolsets.ValidateToolsets(profile.Toolsets); err != nil {
+			return fmt.Errorf("access profile %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// toolsetHelp lists the registered toolsets by category for the command's help text
+func toolsetHelp() string {
+	var b strings.Builder
+	b.WriteString("Available toolsets:")
+
+	category := ""
+	for _, registration := range toolsets.Registrations() {
+		if registration.Category != category {
+			category = registration.Category
+			fmt.Fprintf(&b, "\n\n  %s:", category)
+		}
+		fmt.Fprintf(&b, "\n    %-22s %s", registration.Name, registration.Description)
+	}
+	fmt.Fprintf(&b, "\n\n    %-22s %s", toolsets.AllToolsets, "Enable every available toolset")
+
+	return b.String()
+}
+
+func exportTranslations(cfg *config.Config, logger *zap.Logger) error {
+	translations, err := server.ExportTranslations(cfg, logger)
+	if err != nil {
+		return err
+	}
+
+	configFile := mcp.TranslationsFile
+
+	// Keep existing overrides, dropping keys that no longer match a registered tool or parameter
+	if data, err := os.ReadFile(configFile); err == nil {
+		var existing map[string]string
+		if err := json.Unmarshal(data, &existing); err == nil {
+
+			for key, value := range existing {
+				if _, registered := translations[key]; registered {
+					translations[key] = value
+				}
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(translations, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal translations: %w", err)
+	}
+
+	if err := os.WriteFile(configFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	logger.Info("Exported translations",
+		zap.String("file", configFile),
+		zap.Int("descriptions", len(translations)))
+	return nil
+}
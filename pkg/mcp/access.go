@@ -0,0 +1,162 @@
+package mcp
+
+import (
+	"fmt"
+	"path"
+)
+
+// AccessProfile bundles what a class of client is allowed to do. A session is assigned a
+// profile either by the transport, for example from an HTTP bearer token, or during
+// initialize by matching the client's name against Clients.
+type AccessProfile struct {
+	Name string
+
+	// Toolsets limits the session to tools from these toolsets. Tools that belong to no
+	// toolset, such as the dynamic discovery tools, are always available. Empty allows all.
+	Toolsets []string
+
+	// AllowedTools and DeniedTools are glob patterns in path.Match syntax. An empty allow
+	// list allows every tool; the deny list always wins.
+	AllowedTools []string
+	DeniedTools  []string
+
+	// ReadOnly hides and refuses every tool that can change Jamf Pro
+	ReadOnly bool
+
+	// Site is the Jamf Pro site the session is restricted to. The MCP server only carries it;
+	// the tool handlers apply it.
+	Site string
+
+	// Clients are glob patterns matched against ClientInfo.Name during initialize
+	Clients []string
+}
+
+// validate checks that every pattern in the profile is well formed
+func (p *AccessProfile) validate() error {
+	for _, patterns := range [][]string{p.AllowedTools, p.DeniedTools, p.Clients} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("access profile %s: invalid pattern %q: %w", p.Name, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AllowsToolset reports whether the profile permits tools from the named toolset
+func (p *AccessProfile) AllowsToolset(name string) bool {
+	if p == nil || len(p.Toolsets) == 0 {
+		return true
+	}
+	for _, toolset := range p.Toolsets {
+		if toolset == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether the profile permits a tool, and if not, why. A nil profile allows
+// everything.
+func (p *AccessProfile) Allows(tool *Tool) (bool, string) {
+	if p == nil {
+		return true, ""
+	}
+
+	if tool.Toolset != "" && !p.AllowsToolset(tool.Toolset) {
+		return false, fmt.Sprintf("toolset %s is not included", tool.Toolset)
+	}
+
+	if matchesAnyPattern(p.DeniedTools, tool.Name) {
+		return false, "the tool is denied"
+	}
+
+	if len(p.AllowedTools) > 0 && !matchesAnyPattern(p.AllowedTools, tool.Name) {
+		return false, "the tool is not in the allowed list"
+	}
+
+	if p.ReadOnly && !tool.IsReadOnly() {
+		return false, "the profile is read-only"
+	}
+
+	return true, ""
+}
+
+// matchesClient reports whether the profile applies to a client with the given name
+func (p *AccessProfile) matchesClient(clientName string) bool {
+	return matchesAnyPattern(p.Clients, clientName)
+}
+
+// matchesAnyPattern reports whether name matches one of the glob patterns
+func matchesAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SetAccessProfiles configures the access profiles sessions can be assigned. Profiles are
+// matched against client names in the order given. defaultProfile, if not empty, is assigned
+// to sessions that match no profile; otherwise such sessions are unrestricted.
+func (s *Server) SetAccessProfiles(profiles []AccessProfile, defaultProfile string) error {
+	byName := make(map[string]*AccessProfile, len(profiles))
+	ordered := make([]*AccessProfile, 0, len(profiles))
+	for i := range profiles {
+		profile := profiles[i]
+		if profile.Name == "" {
+			return fmt.Errorf("access profile name cannot be empty")
+		}
+		if _, exists := byName[profile.Name]; exists {
+			return fmt.Errorf("duplicate access profile %s", profile.Name)
+		}
+		if err := profile.validate(); err != nil {
+			return err
+		}
+		byName[profile.Name] = &profile
+		ordered = append(ordered, &profile)
+	}
+
+	var fallback *AccessProfile
+	if defaultProfile != "" {
+		var ok bool
+		if fallback, ok = byName[defaultProfile]; !ok {
+			return fmt.Errorf("default access profile %s is not defined", defaultProfile)
+		}
+	}
+
+	s.accessMu.Lock()
+	defer s.accessMu.Unlock()
+
+	s.accessProfiles = ordered
+	s.defaultAccessProfile = fallback
+	return nil
+}
+
+// AccessProfile returns the configured profile with the given name
+func (s *Server) AccessProfile(name string) (*AccessProfile, bool) {
+	s.accessMu.RLock()
+	defer s.accessMu.RUnlock()
+
+	for _, profile := range s.accessProfiles {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return nil, false
+}
+
+// profileForClient returns the first profile whose client patterns match the client name,
+// falling back to the default profile
+func (s *Server) profileForClient(clientInfo ClientInfo) *AccessProfile {
+	s.accessMu.RLock()
+	defer s.accessMu.RUnlock()
+
+	for _, profile := range s.accessProfiles {
+		if profile.matchesClient(clientInfo.Name) {
+			return profile
+		}
+	}
+	return s.defaultAccessProfile
+}
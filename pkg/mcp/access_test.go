@@ -0,0 +1,215 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAccessTestServer creates a server with tools from two toolsets and a toolset-less meta-tool
+func newAccessTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	server := NewServer("test-server", "1.0.0")
+	handler := func(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+		return &CallToolResult{Content: []ToolContent{{Type: "text", Text: "ok"}}}, nil
+	}
+
+	for _, tool := range []Tool{
+		{Name: "get_computers", Toolset: "computers", Access: ToolAccessRead},
+		{Name: "erase_computer", Toolset: "computers", Access: ToolAccessDestructive},
+		{Name: "create_policy", Toolset: "policies", Access: ToolAccessWrite},
+		{Name: "list_available_toolsets", Access: ToolAccessRead},
+	} {
+		server.RegisterToolDefinition(&tool)
+		server.RegisterTool(tool.Name, handler)
+	}
+
+	require.NoError(t, server.SetAccessProfiles([]AccessProfile{
+		{Name: "helpdesk", Toolsets: []string{"computers"}, DeniedTools: []string{"erase_*"}, Clients: []string{"helpdesk-*"}},
+		{Name: "reporting", ReadOnly: true, Clients: []string{"reporter"}},
+	}, "reporting"))
+
+	return server
+}
+
+// initializeAs completes the initialize handshake on session with the given client name
+func initializeAs(t *testing.T, server *Server, session *Session, clientName string) context.Context {
+	t.Helper()
+
+	ctx := ContextWithSession(context.Background(), session)
+	response, err := server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      0,
+		Method:  "initialize",
+		Params:  map[string]interface{}{"clientInfo": map[string]interface{}{"name": clientName, "version": "1.0"}},
+	})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+	return ctx
+}
+
+// listedTools returns the names of the tools a session sees
+func listedTools(t *testing.T, server *Server, ctx context.Context) []string {
+	t.Helper()
+
+	result, err := server.handleListTools(ctx, nil)
+	require.NoError(t, err)
+
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+// TestAccessProfileFromClientName tests that the client name selects a profile that limits tools/list and tools/call
+func TestAccessProfileFromClientName(t *testing.T) {
+	server := newAccessTestServer(t)
+	session, err := server.CreateSession()
+	require.NoError(t, err)
+
+	ctx := initializeAs(t, server, session, "helpdesk-bot")
+	require.NotNil(t, session.AccessProfile())
+	assert.Equal(t, "helpdesk", session.AccessProfile().Name)
+
+	assert.ElementsMatch(t, []string{"get_computers", "list_available_toolsets"}, listedTools(t, server, ctx))
+
+	_, err = server.handleCallTool(ctx, map[string]interface{}{"name": "get_computers"})
+	assert.NoError(t, err)
+
+	_, err = server.handleCallTool(ctx, map[string]interface{}{"name": "erase_computer"})
+	assert.ErrorContains(t, err, "not permitted by access profile helpdesk")
+
+	_, err = server.handleCallTool(ctx, map[string]interface{}{"name": "create_policy"})
+	assert.ErrorContains(t, err, "toolset policies is not included")
+}
+
+// TestDefaultAccessProfile tests that unmatched clients receive the default profile
+func TestDefaultAccessProfile(t *testing.T) {
+	server := newAccessTestServer(t)
+	session, err := server.CreateSession()
+	require.NoError(t, err)
+
+	ctx := initializeAs(t, server, session, "unknown-client")
+	assert.Equal(t, "reporting", session.AccessProfile().Name)
+	assert.ElementsMatch(t, []string{"get_computers", "list_available_toolsets"}, listedTools(t, server, ctx))
+
+	// Without a default profile, unmatched clients are unrestricted
+	require.NoError(t, server.SetAccessProfiles(nil, ""))
+	unrestricted, err := server.CreateSession()
+	require.NoError(t, err)
+	ctx = initializeAs(t, server, unrestricted, "unknown-client")
+	assert.Nil(t, unrestricted.AccessProfile())
+	assert.Len(t, listedTools(t, server, ctx), 4)
+}
+
+// TestInitializeOnlyOnce tests that a session cannot initialize again under another client
+// name to take that client's profile
+func TestInitializeOnlyOnce(t *testing.T) {
+	server := newAccessTestServer(t)
+	session, err := server.CreateSession()
+	require.NoError(t, err)
+	ctx := initializeAs(t, server, session, "reporter")
+
+	response, err := server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "initialize",
+		Params:  map[string]interface{}{"clientInfo": map[string]interface{}{"name": "helpdesk-bot", "version": "1.0"}},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, InvalidRequest, response.Error.Code)
+
+	assert.Equal(t, "reporting", session.AccessProfile().Name)
+	assert.Equal(t, "reporter", session.ClientInfo().Name)
+}
+
+// TestPinnedAccessProfile tests that a profile assigned by the transport survives initialize
+func TestPinnedAccessProfile(t *testing.T) {
+	server := newAccessTestServer(t)
+	session, err := server.CreateSession()
+	require.NoError(t, err)
+
+	reporting, ok := server.AccessProfile("reporting")
+	require.True(t, ok)
+	session.SetAccessProfile(reporting)
+
+	// Claiming to be the help desk bot does not grant its profile
+	initializeAs(t, server, session, "helpdesk-bot")
+	assert.Equal(t, "reporting", session.AccessProfile().Name)
+}
+
+// TestSetAccessProfilesValidation tests that invalid profile configuration is rejected
+func TestSetAccessProfilesValidation(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+
+	assert.Error(t, server.SetAccessProfiles([]AccessProfile{{Name: "a"}, {Name: "a"}}, ""))
+	assert.Error(t, server.SetAccessProfiles([]AccessProfile{{Name: "a", DeniedTools: []string{"["}}}, ""))
+	assert.Error(t, server.SetAccessProfiles([]AccessProfile{{Name: "a"}}, "b"))
+	assert.NoError(t, server.SetAccessProfiles([]AccessProfile{{Name: "a"}}, "a"))
+}
+
+// TestAuthorizeTool tests that AuthorizeTool applies the same checks as tools/call
+func TestAuthorizeTool(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	require.NoError(t, server.SetAccessProfiles([]AccessProfile{
+		{Name: "scripts-only", Toolsets: []string{"scripts"}},
+	}, "scripts-only"))
+	_, ctx := newInitializedSession(t, server)
+
+	handler := func(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+		return &CallToolResult{}, nil
+	}
+	for _, tool := range []Tool{
+		{Name: "get_script_by_id", Access: ToolAccessRead, Toolset: "scripts"},
+		{Name: "get_policy_by_id", Access: ToolAccessRead, Toolset: "policies"},
+	} {
+		server.RegisterToolDefinition(&tool)
+		server.RegisterTool(tool.Name, handler)
+	}
+
+	assert.NoError(t, server.AuthorizeTool(ctx, "get_script_by_id"))
+	assert.ErrorContains(t, server.AuthorizeTool(ctx, "get_policy_by_id"), "access profile scripts-only")
+	assert.ErrorContains(t, server.AuthorizeTool(ctx, "get_computer_by_id"), "tool not found")
+}
+
+// TestToolCallObserver tests that the observer sees every tools/call request, including the
+// ones refused before they reach a tool
+func TestToolCallObserver(t *testing.T) {
+	server := newAccessTestServer(t)
+	var calls []*ToolCall
+	server.SetToolCallObserver(func(ctx context.Context, call *ToolCall) {
+		calls = append(calls, call)
+	})
+
+	session, err := server.CreateSession()
+	require.NoError(t, err)
+	ctx := initializeAs(t, server, session, "helpdesk-desk1")
+
+	for _, name := range []string{"get_computers", "erase_computer", "get_unknown"} {
+		_, err := server.HandleMessage(ctx, &Message{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  "tools/call",
+			Params:  map[string]interface{}{"name": name, "arguments": map[string]interface{}{"id": "7"}},
+		})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, calls, 3)
+	assert.False(t, calls[0].Denied)
+	assert.Equal(t, "ok", calls[0].Result.Content[0].Text)
+	assert.Equal(t, "computers", calls[0].Tool.Toolset)
+
+	assert.True(t, calls[1].Denied)
+	assert.Equal(t, ToolAccessDestructive, calls[1].Tool.Access)
+	assert.ErrorContains(t, calls[1].Err, "access profile helpdesk")
+	assert.Equal(t, "7", calls[1].Params.Arguments["id"])
+
+	assert.True(t, calls[2].Denied)
+	assert.Equal(t, "get_unknown", calls[2].Tool.Name)
+}
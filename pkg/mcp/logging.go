@@ -0,0 +1,278 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NotificationMessage carries a server log entry to a client
+const NotificationMessage = "notifications/message"
+
+// maxRecentLogs is the number of warnings and errors kept for clients that choose a log level
+// after they were logged, such as a failed connection test at startup
+const maxRecentLogs = 50
+
+// sessionFieldKey is the key of the log field that ties an entry to a session
+const sessionFieldKey = "mcp_session"
+
+// loggingLevels lists the MCP log levels from least to most severe
+var loggingLevels = []LoggingLevel{
+	LoggingLevelDebug,
+	LoggingLevelInfo,
+	LoggingLevelNotice,
+	LoggingLevelWarning,
+	LoggingLevelError,
+	LoggingLevelCritical,
+	LoggingLevelAlert,
+	LoggingLevelEmergency,
+}
+
+// SetLevelParams represents the logging/setLevel parameters
+type SetLevelParams struct {
+	Level LoggingLevel `json:"level"`
+}
+
+// severity returns the rank of the level, or -1 for an unknown level
+func (l LoggingLevel) severity() int {
+	return slices.Index(loggingLevels, l)
+}
+
+// loggingLevelOf maps a zap level to the MCP log level
+func loggingLevelOf(level zapcore.Level) LoggingLevel {
+	switch level {
+	case zapcore.DebugLevel:
+		return LoggingLevelDebug
+	case zapcore.InfoLevel:
+		return LoggingLevelInfo
+	case zapcore.WarnLevel:
+		return LoggingLevelWarning
+	case zapcore.ErrorLevel:
+		return LoggingLevelError
+	case zapcore.DPanicLevel:
+		return LoggingLevelCritical
+	case zapcore.PanicLevel:
+		return LoggingLevelAlert
+	default:
+		return LoggingLevelEmergency
+	}
+}
+
+// recentLogs holds the latest warnings and errors, oldest first
+type recentLogs struct {
+	mu      sync.Mutex
+	entries []*LoggingParams
+}
+
+// add records an entry, dropping the oldest once maxRecentLogs are held
+func (r *recentLogs) add(params *LoggingParams) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.entries) == maxRecentLogs {
+		r.entries = slices.Delete(r.entries, 0, 1)
+	}
+	r.entries = append(r.entries, params)
+}
+
+// since returns the recorded entries at or above level
+func (r *recentLogs) since(level LoggingLevel) []*LoggingParams {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []*LoggingParams
+	for _, params := range r.entries {
+		if params.Level.severity() >= level.severity() {
+			entries = append(entries, params)
+		}
+	}
+	return entries
+}
+
+// handleSetLevel handles the logging/setLevel method. The first time a session without an
+// access profile chooses a level it is also sent the recent server-wide warnings and errors at
+// or above that level.
+func (s *Server) handleSetLevel(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	session := s.sessionFromContext(ctx)
+	if !session.IsInitialized() {
+		return nil, ErrNotInitialized
+	}
+
+	var setLevelParams SetLevelParams
+	if err := decodeParams(params, &setLevelParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal setLevel params: %w", err)
+	}
+	if setLevelParams.Level.severity() < 0 {
+		return nil, fmt.Errorf("unknown log level: %q", setLevelParams.Level)
+	}
+
+	previous := session.setLogLevel(setLevelParams.Level)
+	if previous == "" && session.AccessProfile() == nil {
+		for _, entry := range s.recentLogs.since(setLevelParams.Level) {
+			s.notify(ctx, NotificationMessage, entry)
+		}
+	}
+
+	return map[string]interface{}{}, nil
+}
+
+// loggingEnabled reports whether an entry at level would be kept or sent to any session
+func (s *Server) loggingEnabled(level LoggingLevel) bool {
+	if level.severity() >= LoggingLevelWarning.severity() {
+		return true
+	}
+
+	for _, session := range s.allSessions() {
+		if accepts(session, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// LogMessage queues a log entry for the sessions allowed to see it that chose a level at or
+// below the entry's level with logging/setLevel. An entry caused by a session's request is
+// sent to that session alone. Other entries describe the whole server and are sent only to
+// sessions without an access profile, since they may mention other sessions' calls; the
+// warnings and errors among them are also kept for such sessions that choose a level later.
+func (s *Server) LogMessage(session *Session, params *LoggingParams) {
+	if session != nil {
+		if accepts(session, params.Level) {
+			session.post(&Message{JSONRPC: "2.0", Method: NotificationMessage, Params: params})
+		}
+		return
+	}
+
+	if params.Level.severity() >= LoggingLevelWarning.severity() {
+		s.recentLogs.add(params)
+	}
+
+	for _, session := range s.allSessions() {
+		if session.AccessProfile() == nil && accepts(session, params.Level) {
+			session.post(&Message{JSONRPC: "2.0", Method: NotificationMessage, Params: params})
+		}
+	}
+}
+
+// SessionField returns a log field that ties an entry to the session in ctx, so that the entry
+// is sent to that client alone. The field is not written to the server's own log. Code that
+// handles a request adds it to everything it logs about the request.
+func SessionField(ctx context.Context) zap.Field {
+	session := SessionFromContext(ctx)
+	if session == nil {
+		return zap.Skip()
+	}
+	return zap.Field{Key: sessionFieldKey, Type: zapcore.SkipType, Interface: session}
+}
+
+// accepts reports whether the session asked for log entries at level
+func accepts(session *Session, level LoggingLevel) bool {
+	chosen := session.LogLevel()
+	return chosen != "" && session.IsInitialized() && level.severity() >= chosen.severity()
+}
+
+// allSessions returns the default session and every open session
+func (s *Server) allSessions() []*Session {
+	s.sessionsMu.RLock()
+	defer s.sessionsMu.RUnlock()
+
+	sessions := make([]*Session, 0, len(s.sessions)+1)
+	sessions = append(sessions, s.defaultSession)
+	for _, session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+// logCore is a zapcore.Core that sends entries to clients as log notifications in addition
+// to writing them to the wrapped core
+type logCore struct {
+	zapcore.Core
+	server *Server
+	fields []zapcore.Field
+}
+
+// ForwardLogs returns a logger option that sends log entries to clients through
+// LogMessage, to the session named by a SessionField or server-wide. The wrapped core keeps
+// its own level, so a client asking for debug entries does not change what the server writes
+// to its own log. Entries are only queued here; the sessions deliver them in the background.
+// Entries logged while sending a message must not be forwarded, or each notification would
+// log another.
+func ForwardLogs(server *Server) zap.Option {
+	return zap.WrapCore(func(inner zapcore.Core) zapcore.Core {
+		return &logCore{Core: inner, server: server}
+	})
+}
+
+// Enabled reports whether the wrapped core or any client wants entries at level
+func (c *logCore) Enabled(level zapcore.Level) bool {
+	return c.Core.Enabled(level) || c.server.loggingEnabled(loggingLevelOf(level))
+}
+
+// With keeps the fields of a child logger for the notifications it sends
+func (c *logCore) With(fields []zapcore.Field) zapcore.Core {
+	return &logCore{
+		Core:   c.Core.With(fields),
+		server: c.server,
+		fields: append(slices.Clip(c.fields), fields...),
+	}
+}
+
+// Check adds this core, rather than the wrapped one, so that Write sees every entry
+func (c *logCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write writes the entry to the wrapped core if it is enabled there and sends it to clients
+func (c *logCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	var err error
+	if c.Core.Enabled(entry.Level) {
+		err = c.Core.Write(entry, fields)
+	}
+
+	level := loggingLevelOf(entry.Level)
+	if c.server.loggingEnabled(level) {
+		c.server.LogMessage(c.session(fields), &LoggingParams{
+			Level:  level,
+			Logger: entry.LoggerName,
+			Data:   c.logData(entry, fields),
+		})
+	}
+	return err
+}
+
+// session returns the session named by a SessionField of the entry or its logger, or nil for
+// a server-wide entry
+func (c *logCore) session(fields []zapcore.Field) *Session {
+	for _, group := range [][]zapcore.Field{fields, c.fields} {
+		for _, field := range group {
+			if field.Key == sessionFieldKey && field.Type == zapcore.SkipType {
+				if session, ok := field.Interface.(*Session); ok {
+					return session
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// logData returns the message and fields of an entry as a JSON object
+func (c *logCore) logData(entry zapcore.Entry, fields []zapcore.Field) map[string]interface{} {
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(encoder)
+	}
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+
+	encoder.Fields["message"] = entry.Message
+	return encoder.Fields
+}
@@ -0,0 +1,190 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// receiveLogs reads the next n messages delivered to a session, which must be log notifications
+func receiveLogs(t *testing.T, messages <-chan *Message, n int) []*LoggingParams {
+	t.Helper()
+
+	logs := make([]*LoggingParams, 0, n)
+	for _, msg := range receiveMessages(t, messages, n) {
+		require.Equal(t, NotificationMessage, msg.Method)
+		logs = append(logs, msg.Params.(*LoggingParams))
+	}
+	return logs
+}
+
+// setLevel sends logging/setLevel and returns the error response
+func setLevel(t *testing.T, ctx context.Context, server *Server, level string) *Error {
+	t.Helper()
+
+	response, err := server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "logging/setLevel",
+		Params:  map[string]interface{}{"level": level},
+	})
+	require.NoError(t, err)
+	return response.Error
+}
+
+// TestSetLevelRejectsUnknownLevel tests that only MCP log levels are accepted
+func TestSetLevelRejectsUnknownLevel(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	session, ctx := newInitializedSession(t, server)
+
+	rpcErr := setLevel(t, ctx, server, "verbose")
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, InvalidParams, rpcErr.Code)
+	assert.Equal(t, LoggingLevel(""), session.LogLevel())
+
+	require.Nil(t, setLevel(t, ctx, server, "notice"))
+	assert.Equal(t, LoggingLevelNotice, session.LogLevel())
+}
+
+// TestForwardLogs tests that log entries reach each session at or above its chosen level,
+// with the logger name and fields, while the wrapped core keeps its own level
+func TestForwardLogs(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	quiet, quietCtx := newInitializedSession(t, server)
+	verbose, verboseCtx := newInitializedSession(t, server)
+	silent, _ := newInitializedSession(t, server)
+	quietLogs := recordMessages(quiet)
+	verboseLogs := recordMessages(verbose)
+	silentLogs := recordMessages(silent)
+
+	require.Nil(t, setLevel(t, quietCtx, server, "warning"))
+	require.Nil(t, setLevel(t, verboseCtx, server, "debug"))
+
+	observed, written := observer.New(zapcore.InfoLevel)
+	logger := zap.New(observed, ForwardLogs(server)).Named("computers").With(zap.String("instance", "primary"))
+
+	logger.Debug("Listing computers")
+	logger.Warn("Failed to test Jamf Pro connection", zap.Error(errors.New("connection refused")))
+
+	warning := receiveLogs(t, quietLogs, 1)[0]
+	assert.Equal(t, LoggingLevelWarning, warning.Level)
+	assert.Equal(t, "computers", warning.Logger)
+	assert.Equal(t, map[string]interface{}{
+		"message":  "Failed to test Jamf Pro connection",
+		"instance": "primary",
+		"error":    "connection refused",
+	}, warning.Data)
+
+	assert.Equal(t, LoggingLevelDebug, receiveLogs(t, verboseLogs, 2)[0].Level)
+	assertNoMessages(t, quietLogs)
+	assertNoMessages(t, silentLogs)
+
+	// The debug entry a client asked for is not written to the server's own log
+	require.Equal(t, 1, written.Len())
+	assert.Equal(t, "Failed to test Jamf Pro connection", written.All()[0].Message)
+}
+
+// TestForwardLogsToRequestingSession tests that entries about a session's request reach that
+// session alone, and that server-wide entries and replays skip sessions with an access profile
+func TestForwardLogsToRequestingSession(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	logger := zap.New(zapcore.NewNopCore(), ForwardLogs(server))
+	logger.Warn("Failed to test Jamf Pro connection")
+
+	owner, ownerCtx := newInitializedSession(t, server)
+	other, otherCtx := newInitializedSession(t, server)
+	restricted, restrictedCtx := newInitializedSession(t, server)
+	restricted.SetAccessProfile(&AccessProfile{Name: "helpdesk"})
+	ownerLogs := recordMessages(owner)
+	otherLogs := recordMessages(other)
+	restrictedLogs := recordMessages(restricted)
+
+	// Unrestricted sessions are sent the recent warnings, restricted sessions are not
+	require.Nil(t, setLevel(t, ownerCtx, server, "debug"))
+	require.Nil(t, setLevel(t, otherCtx, server, "debug"))
+	require.Nil(t, setLevel(t, restrictedCtx, server, "debug"))
+	receiveLogs(t, ownerLogs, 1)
+	receiveLogs(t, otherLogs, 1)
+	assertNoMessages(t, restrictedLogs)
+
+	logger.Debug("Executing get_computer_by_id tool", SessionField(restrictedCtx), zap.Any("arguments", map[string]interface{}{"id": "7"}))
+	logger.With(SessionField(ownerCtx)).Error("Tool execution failed")
+	logger.Info("Reloaded configuration")
+
+	restrictedReceived := receiveLogs(t, restrictedLogs, 1)
+	assert.Equal(t, "Executing get_computer_by_id tool", restrictedReceived[0].Data.(map[string]interface{})["message"])
+	assert.NotContains(t, restrictedReceived[0].Data, sessionFieldKey)
+
+	ownerReceived := receiveLogs(t, ownerLogs, 2)
+	assert.Equal(t, "Tool execution failed", ownerReceived[0].Data.(map[string]interface{})["message"])
+	assert.Equal(t, "Reloaded configuration", ownerReceived[1].Data.(map[string]interface{})["message"])
+
+	assert.Equal(t, "Reloaded configuration", receiveLogs(t, otherLogs, 1)[0].Data.(map[string]interface{})["message"])
+
+	assertNoMessages(t, restrictedLogs)
+	assertNoMessages(t, ownerLogs)
+	assertNoMessages(t, otherLogs)
+}
+
+// TestLogMessageDoesNotWaitForClients tests that a stalled client does not block logging and
+// that entries beyond its queue are dropped
+func TestLogMessageDoesNotWaitForClients(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	session, ctx := newInitializedSession(t, server)
+	require.Nil(t, setLevel(t, ctx, server, "debug"))
+
+	unblock := make(chan struct{})
+	delivered := make(chan *Message, outboxSize*2)
+	session.SetNotifier(func(msg *Message) error {
+		<-unblock
+		delivered <- msg
+		return nil
+	})
+
+	logger := zap.New(zapcore.NewNopCore(), ForwardLogs(server)).With(SessionField(ctx))
+	for i := 0; i < outboxSize*2; i++ {
+		logger.Debug("Listing computers")
+	}
+
+	// At most the queue and the entry held by the stalled notifier are delivered
+	close(unblock)
+	received := len(receiveMessages(t, delivered, outboxSize))
+	for drained := false; !drained; {
+		select {
+		case <-delivered:
+			received++
+		case <-time.After(50 * time.Millisecond):
+			drained = true
+		}
+	}
+	assert.LessOrEqual(t, received, outboxSize+1)
+}
+
+// TestSetLevelReplaysRecentLogs tests that a session choosing a level for the first time is
+// sent the earlier warnings and errors at or above it
+func TestSetLevelReplaysRecentLogs(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	logger := zap.New(zapcore.NewNopCore(), ForwardLogs(server))
+
+	logger.Info("Starting")
+	logger.Warn("Failed to test Jamf Pro connection")
+	logger.Error("Failed to initialize resource provider")
+
+	session, ctx := newInitializedSession(t, server)
+	logs := recordMessages(session)
+
+	require.Nil(t, setLevel(t, ctx, server, "error"))
+	assert.Equal(t, LoggingLevelError, receiveLogs(t, logs, 1)[0].Level)
+	assertNoMessages(t, logs)
+
+	// Lowering the level later does not replay the entries again
+	require.Nil(t, setLevel(t, ctx, server, "debug"))
+	assertNoMessages(t, logs)
+}
@@ -0,0 +1,182 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Notification methods defined by the MCP specification
+const (
+	NotificationInitialized = "notifications/initialized"
+	NotificationCancelled   = "notifications/cancelled"
+	NotificationProgress    = "notifications/progress"
+
+	NotificationToolsListChanged = "notifications/tools/list_changed"
+)
+
+// RequestMeta represents the _meta field attached to a request
+type RequestMeta struct {
+	ProgressToken interface{} `json:"progressToken,omitempty"`
+}
+
+// CancelledParams represents the notifications/cancelled parameters
+type CancelledParams struct {
+	RequestID interface{} `json:"requestId"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// ProgressParams represents the notifications/progress parameters
+type ProgressParams struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Progress      float64     `json:"progress"`
+	Total         float64     `json:"total,omitempty"`
+	Message       string      `json:"message,omitempty"`
+}
+
+// Notifier delivers a server-initiated message to a client
+type Notifier func(msg *Message) error
+
+type notifierContextKey struct{}
+
+// ContextWithNotifier returns a copy of ctx whose server-initiated messages are delivered
+// through n instead of the session notifier. Transports use this to route notifications
+// about a request onto that request's response stream.
+func ContextWithNotifier(ctx context.Context, n Notifier) context.Context {
+	return context.WithValue(ctx, notifierContextKey{}, n)
+}
+
+// notify sends a notification to the client that owns ctx. Notifications are dropped
+// when the transport has no way to deliver them.
+func (s *Server) notify(ctx context.Context, method string, params interface{}) error {
+	notifier, _ := ctx.Value(notifierContextKey{}).(Notifier)
+	if notifier == nil {
+		notifier = s.sessionFromContext(ctx).getNotifier()
+	}
+	if notifier == nil {
+		return nil
+	}
+
+	return notifier(&Message{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	})
+}
+
+// NotifyToolsListChanged tells every initialized session that the set of tools has changed.
+// The session that owns ctx is notified through notify, so a client that changed the tools
+// over an HTTP request stream hears about it on that stream. Other sessions are notified
+// through their queues, so a stalled client does not hold up the caller.
+func (s *Server) NotifyToolsListChanged(ctx context.Context) {
+	caller := s.sessionFromContext(ctx)
+
+	for _, session := range s.allSessions() {
+		if !session.IsInitialized() {
+			continue
+		}
+
+		if session == caller {
+			s.notify(ctx, NotificationToolsListChanged, nil)
+			continue
+		}
+
+		session.post(&Message{JSONRPC: "2.0", Method: NotificationToolsListChanged})
+	}
+}
+
+// handleNotification handles a message that carries no ID and therefore expects no response
+func (s *Server) handleNotification(ctx context.Context, msg *Message) error {
+	switch msg.Method {
+	case NotificationCancelled:
+		var params CancelledParams
+		if err := decodeParams(msg.Params, &params); err != nil {
+			return fmt.Errorf("failed to unmarshal cancelled params: %w", err)
+		}
+		s.sessionFromContext(ctx).cancelRequest(params.RequestID)
+	}
+
+	// notifications/initialized and unknown notifications need no action
+	return nil
+}
+
+type trackedRequestContextKey struct{}
+
+// TrackRequest returns a context for the request with the given ID that notifications/cancelled
+// from the session in ctx cancels, and a function that releases it once the request is done.
+// Transports that queue requests call it when a request arrives, so that a cancellation sent
+// while the request waits is not lost; HandleMessage then reuses the tracked context.
+func (s *Server) TrackRequest(ctx context.Context, id interface{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	session := s.sessionFromContext(ctx)
+	key := session.trackRequest(id, cancel)
+	ctx = context.WithValue(ctx, trackedRequestContextKey{}, key)
+
+	return ctx, func() {
+		session.untrackRequest(key)
+		cancel()
+	}
+}
+
+// isTrackedRequest reports whether ctx was returned by TrackRequest for the request with id
+func isTrackedRequest(ctx context.Context, id interface{}) bool {
+	key, _ := ctx.Value(trackedRequestContextKey{}).(string)
+	return key != "" && key == requestKey(id)
+}
+
+type progressContextKey struct{}
+
+// progressReporter emits notifications/progress for a single request
+type progressReporter struct {
+	server *Server
+	ctx    context.Context
+	token  interface{}
+}
+
+// contextWithProgress attaches a progress reporter for the given token to ctx
+func (s *Server) contextWithProgress(ctx context.Context, token interface{}) context.Context {
+	reporter := &progressReporter{server: s, token: token}
+	ctx = context.WithValue(ctx, progressContextKey{}, reporter)
+	reporter.ctx = ctx
+	return ctx
+}
+
+// ReportProgress sends a progress notification for the request carried by ctx. It is a
+// no-op when the client did not ask for progress updates. A total of zero means unknown.
+func ReportProgress(ctx context.Context, progress, total float64, message string) {
+	reporter, _ := ctx.Value(progressContextKey{}).(*progressReporter)
+	if reporter == nil || ctx.Err() != nil {
+		return
+	}
+
+	reporter.server.notify(reporter.ctx, NotificationProgress, &ProgressParams{
+		ProgressToken: reporter.token,
+		Progress:      progress,
+		Total:         total,
+		Message:       message,
+	})
+}
+
+// requestKey normalises a JSON-RPC request ID so numeric and string IDs can be used as map keys
+func requestKey(id interface{}) string {
+	data, err := json.Marshal(id)
+	if err != nil {
+		return fmt.Sprintf("%v", id)
+	}
+	return string(data)
+}
+
+// decodeParams converts loosely typed params into the given struct
+func decodeParams(params interface{}, out interface{}) error {
+	if params == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, out)
+}
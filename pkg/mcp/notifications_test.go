@@ -0,0 +1,231 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newInitializedSession creates a session on server and completes the initialize handshake
+func newInitializedSession(t *testing.T, server *Server) (*Session, context.Context) {
+	t.Helper()
+
+	session, err := server.CreateSession()
+	require.NoError(t, err)
+
+	ctx := ContextWithSession(context.Background(), session)
+	response, err := server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      0,
+		Method:  "initialize",
+		Params:  map[string]interface{}{"protocolVersion": "2025-03-26"},
+	})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+
+	return session, ctx
+}
+
+// TestNotificationsReceiveNoResponse tests that id-less messages never produce a response
+func TestNotificationsReceiveNoResponse(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	_, ctx := newInitializedSession(t, server)
+
+	for _, method := range []string{NotificationInitialized, NotificationCancelled, "notifications/unknown"} {
+		response, err := server.HandleMessage(ctx, &Message{JSONRPC: "2.0", Method: method})
+		assert.NoError(t, err, method)
+		assert.Nil(t, response, method)
+	}
+}
+
+// TestCancelledNotificationCancelsToolHandler tests that notifications/cancelled stops the matching request
+func TestCancelledNotificationCancelsToolHandler(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	_, ctx := newInitializedSession(t, server)
+
+	started := make(chan struct{})
+	server.RegisterToolDefinition(&Tool{Name: "slow_tool"})
+	server.RegisterTool("slow_tool", func(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	type outcome struct {
+		response *Message
+		err      error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		response, err := server.HandleMessage(ctx, &Message{
+			JSONRPC: "2.0",
+			ID:      "call-1",
+			Method:  "tools/call",
+			Params:  map[string]interface{}{"name": "slow_tool"},
+		})
+		done <- outcome{response, err}
+	}()
+
+	<-started
+	response, err := server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		Method:  NotificationCancelled,
+		Params:  map[string]interface{}{"requestId": "call-1", "reason": "user aborted"},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, response)
+
+	select {
+	case result := <-done:
+		assert.NoError(t, result.err)
+		assert.Nil(t, result.response, "cancelled requests must not be answered")
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool handler was not cancelled")
+	}
+}
+
+// TestCancelTrackedRequestBeforeItStarts tests that a request tracked when it arrives can be
+// cancelled while it waits, and is then never run
+func TestCancelTrackedRequestBeforeItStarts(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	_, ctx := newInitializedSession(t, server)
+
+	called := false
+	server.RegisterToolDefinition(&Tool{Name: "queued_tool"})
+	server.RegisterTool("queued_tool", func(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+		called = true
+		return &CallToolResult{}, nil
+	})
+
+	reqCtx, done := server.TrackRequest(ctx, 7)
+	defer done()
+
+	_, err := server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		Method:  NotificationCancelled,
+		Params:  map[string]interface{}{"requestId": 7},
+	})
+	require.NoError(t, err)
+	require.Error(t, reqCtx.Err())
+
+	response, err := server.HandleMessage(reqCtx, &Message{
+		JSONRPC: "2.0",
+		ID:      7,
+		Method:  "tools/call",
+		Params:  map[string]interface{}{"name": "queued_tool"},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, response)
+	assert.False(t, called)
+}
+
+// TestProgressNotifications tests that progress is reported only when the client supplies a token
+func TestProgressNotifications(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	session, ctx := newInitializedSession(t, server)
+
+	var (
+		mu   sync.Mutex
+		sent []*Message
+	)
+	session.SetNotifier(func(msg *Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, msg)
+		return nil
+	})
+
+	server.RegisterToolDefinition(&Tool{Name: "reporting_tool"})
+	server.RegisterTool("reporting_tool", func(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+		ReportProgress(ctx, 1, 2, "halfway")
+		ReportProgress(ctx, 2, 2, "done")
+		return &CallToolResult{Content: []ToolContent{{Type: "text", Text: "ok"}}}, nil
+	})
+
+	// Without a progress token nothing is emitted
+	response, err := server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "tools/call",
+		Params:  map[string]interface{}{"name": "reporting_tool"},
+	})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+	assert.Empty(t, sent)
+
+	response, err = server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      2,
+		Method:  "tools/call",
+		Params: map[string]interface{}{
+			"name":  "reporting_tool",
+			"_meta": map[string]interface{}{"progressToken": "token-1"},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+
+	require.Len(t, sent, 2)
+	assert.Equal(t, NotificationProgress, sent[0].Method)
+	assert.Nil(t, sent[0].ID)
+
+	params, ok := sent[1].Params.(*ProgressParams)
+	require.True(t, ok)
+	assert.Equal(t, "token-1", params.ProgressToken)
+	assert.Equal(t, float64(2), params.Progress)
+	assert.Equal(t, float64(2), params.Total)
+	assert.Equal(t, "done", params.Message)
+}
+
+// TestContextNotifierTakesPrecedence tests that a request-scoped notifier overrides the session notifier
+func TestContextNotifierTakesPrecedence(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	session, ctx := newInitializedSession(t, server)
+
+	var sessionCount, requestCount int
+	session.SetNotifier(func(msg *Message) error {
+		sessionCount++
+		return nil
+	})
+	ctx = ContextWithNotifier(ctx, func(msg *Message) error {
+		requestCount++
+		return nil
+	})
+
+	require.NoError(t, server.notify(ctx, NotificationProgress, &ProgressParams{ProgressToken: 1}))
+	assert.Equal(t, 0, sessionCount)
+	assert.Equal(t, 1, requestCount)
+}
+
+// TestNotifyToolsListChanged tests that every initialized session with a notifier is told about tool changes
+func TestNotifyToolsListChanged(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	first, firstCtx := newInitializedSession(t, server)
+	second, _ := newInitializedSession(t, server)
+	uninitialized, err := server.CreateSession()
+	require.NoError(t, err)
+
+	firstMessages := recordMessages(first)
+	secondMessages := make(chan *Message, 2)
+	release := second.SetNotifier(func(msg *Message) error {
+		secondMessages <- msg
+		return nil
+	})
+	uninitializedMessages := recordMessages(uninitialized)
+
+	server.NotifyToolsListChanged(firstCtx)
+	for _, messages := range []<-chan *Message{firstMessages, secondMessages} {
+		assert.Equal(t, NotificationToolsListChanged, receiveMessages(t, messages, 1)[0].Method)
+	}
+	assertNoMessages(t, uninitializedMessages)
+
+	// Released notifiers no longer receive messages
+	release()
+	server.NotifyToolsListChanged(firstCtx)
+	receiveMessages(t, firstMessages, 1)
+	assertNoMessages(t, secondMessages)
+}
@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// DefaultPageSize is the number of items returned per page of tools/list and resources/list
+// unless SetPageSize chooses another
+const DefaultPageSize = 100
+
+// ErrInvalidCursor is returned for a cursor the server did not issue for the list being read
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// pageCursor is the content of an opaque cursor. It names the last item of the previous page
+// rather than an offset, so that a page is not skipped or repeated when items are registered
+// or removed between requests.
+type pageCursor struct {
+	List  string `json:"list"`
+	After string `json:"after"`
+}
+
+// encodeCursor returns the opaque cursor of the page that follows the item named after
+func encodeCursor(list, after string) string {
+	data, _ := json.Marshal(pageCursor{List: list, After: after})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor returns the name of the last item of the previous page of list
+func decodeCursor(list, cursor string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", ErrInvalidCursor
+	}
+
+	var decoded pageCursor
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.List != list || decoded.After == "" {
+		return "", ErrInvalidCursor
+	}
+	return decoded.After, nil
+}
+
+// paginate sorts items by key and returns the page that starts after cursor, with the cursor
+// of the next page or nil if it is the last. A page size of zero or less returns every item
+// after the cursor.
+func paginate[T any](list string, items []T, key func(T) string, cursor string, pageSize int) ([]T, *string, error) {
+	sort.Slice(items, func(i, j int) bool { return key(items[i]) < key(items[j]) })
+
+	start := 0
+	if cursor != "" {
+		after, err := decodeCursor(list, cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		start = sort.Search(len(items), func(i int) bool { return key(items[i]) > after })
+	}
+
+	items = items[start:]
+	if pageSize <= 0 || len(items) <= pageSize {
+		return items, nil, nil
+	}
+
+	next := encodeCursor(list, key(items[pageSize-1]))
+	return items[:pageSize], &next, nil
+}
+
+// listErrorCode returns the JSON-RPC error code for a failed list request
+func listErrorCode(err error) int {
+	if errors.Is(err, ErrInvalidCursor) {
+		return InvalidParams
+	}
+	return InternalError
+}
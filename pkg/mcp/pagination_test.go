@@ -0,0 +1,136 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listAllTools follows nextCursor through every page of tools/list
+func listAllTools(t *testing.T, ctx context.Context, server *Server) ([]string, int) {
+	t.Helper()
+
+	var names []string
+	pages := 0
+	params := map[string]interface{}{}
+	for {
+		response, err := server.HandleMessage(ctx, &Message{JSONRPC: "2.0", ID: pages + 1, Method: "tools/list", Params: params})
+		require.NoError(t, err)
+		require.Nil(t, response.Error)
+		result, ok := response.Result.(*ListToolsResult)
+		require.True(t, ok)
+
+		pages++
+		for _, tool := range result.Tools {
+			names = append(names, tool.Name)
+		}
+		if result.NextCursor == nil {
+			return names, pages
+		}
+		params = map[string]interface{}{"cursor": *result.NextCursor}
+	}
+}
+
+// TestListToolsPagination tests that tools/list returns sorted pages linked by opaque cursors
+func TestListToolsPagination(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	server.SetPageSize(2)
+	_, ctx := newInitializedSession(t, server)
+
+	handler := func(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+		return &CallToolResult{}, nil
+	}
+	for _, name := range []string{"get_e", "get_b", "get_d", "get_a", "get_c"} {
+		tool := Tool{Name: name, Access: ToolAccessRead}
+		server.RegisterToolDefinition(&tool)
+		server.RegisterTool(name, handler)
+	}
+
+	names, pages := listAllTools(t, ctx, server)
+	assert.Equal(t, []string{"get_a", "get_b", "get_c", "get_d", "get_e"}, names)
+	assert.Equal(t, 3, pages)
+
+	// A page size of zero returns every tool at once
+	server.SetPageSize(0)
+	names, pages = listAllTools(t, ctx, server)
+	assert.Len(t, names, 5)
+	assert.Equal(t, 1, pages)
+}
+
+// TestListToolsCursorSurvivesChanges tests that removing a tool between pages neither skips
+// nor repeats the remaining tools
+func TestListToolsCursorSurvivesChanges(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	server.SetPageSize(2)
+	_, ctx := newInitializedSession(t, server)
+
+	handler := func(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+		return &CallToolResult{}, nil
+	}
+	for i := 1; i <= 4; i++ {
+		tool := Tool{Name: fmt.Sprintf("get_%d", i), Access: ToolAccessRead}
+		server.RegisterToolDefinition(&tool)
+		server.RegisterTool(tool.Name, handler)
+	}
+
+	response, err := server.HandleMessage(ctx, &Message{JSONRPC: "2.0", ID: 1, Method: "tools/list"})
+	require.NoError(t, err)
+	first := response.Result.(*ListToolsResult)
+	require.NotNil(t, first.NextCursor)
+
+	server.UnregisterTool("get_2")
+
+	response, err = server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      2,
+		Method:  "tools/list",
+		Params:  map[string]interface{}{"cursor": *first.NextCursor},
+	})
+	require.NoError(t, err)
+	second := response.Result.(*ListToolsResult)
+	require.Len(t, second.Tools, 2)
+	assert.Equal(t, "get_3", second.Tools[0].Name)
+	assert.Equal(t, "get_4", second.Tools[1].Name)
+	assert.Nil(t, second.NextCursor)
+}
+
+// TestListInvalidCursor tests that unknown cursors, including a cursor of another list, are
+// rejected as invalid parameters
+func TestListInvalidCursor(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	server.SetResourceProvider(NewFileResourceProvider(t.TempDir()))
+	_, ctx := newInitializedSession(t, server)
+
+	for method, cursor := range map[string]string{
+		"tools/list":     "not-a-cursor",
+		"resources/list": encodeCursor("tools", "get_computers"),
+	} {
+		response, err := server.HandleMessage(ctx, &Message{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  method,
+			Params:  map[string]interface{}{"cursor": cursor},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, response.Error, method)
+		assert.Equal(t, InvalidParams, response.Error.Code, method)
+	}
+}
+
+// TestGetRegisteredToolsIsSorted tests that registered tool names are kept in order as tools
+// are registered and removed
+func TestGetRegisteredToolsIsSorted(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	handler := func(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+		return &CallToolResult{}, nil
+	}
+	for _, name := range []string{"get_c", "get_a", "get_d", "get_b", "get_a"} {
+		server.RegisterTool(name, handler)
+	}
+	server.UnregisterTool("get_d")
+
+	assert.Equal(t, []string{"get_a", "get_b", "get_c"}, server.GetRegisteredTools())
+}
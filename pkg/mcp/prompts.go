@@ -0,0 +1,326 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// ListPromptsParams represents the list prompts request parameters
+type ListPromptsParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ListPromptsResult represents the list prompts response
+type ListPromptsResult struct {
+	Prompts    []Prompt `json:"prompts"`
+	NextCursor *string  `json:"nextCursor,omitempty"`
+}
+
+// Prompt represents an MCP prompt
+type Prompt struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Arguments   []PromptArgument `json:"arguments,omitempty"`
+}
+
+// PromptArgument represents an argument accepted by a prompt
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// GetPromptParams represents the get prompt request parameters
+type GetPromptParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// GetPromptResult represents the get prompt response
+type GetPromptResult struct {
+	Description string          `json:"description,omitempty"`
+	Messages    []PromptMessage `json:"messages"`
+}
+
+// PromptMessage represents a single message of a rendered prompt
+type PromptMessage struct {
+	Role    string        `json:"role"`
+	Content PromptContent `json:"content"`
+}
+
+// PromptContent represents the content of a prompt message
+type PromptContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Prompt argument types understood by PromptTemplate
+const (
+	PromptArgumentString  = "string"
+	PromptArgumentInteger = "integer"
+	PromptArgumentBoolean = "boolean"
+)
+
+// PromptTemplate describes a prompt whose messages are Go text/template strings rendered
+// with the prompt arguments. It is also the on-disk format of prompt template files.
+type PromptTemplate struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	Arguments   []PromptTemplateArgument `json:"arguments,omitempty"`
+	Messages    []PromptTemplateMessage  `json:"messages"`
+}
+
+// PromptTemplateArgument describes a typed prompt argument. Values always arrive as strings
+// and are validated against Type and Enum before the template is rendered.
+type PromptTemplateArgument struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Type        string   `json:"type,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+}
+
+// PromptTemplateMessage is a message template with its role
+type PromptTemplateMessage struct {
+	Role string `json:"role"`
+	Text string `json:"text"`
+}
+
+// compiledPrompt is a registered prompt template ready to render
+type compiledPrompt struct {
+	definition PromptTemplate
+	messages   []*template.Template
+}
+
+// PromptRegistry holds the prompts offered by the server
+type PromptRegistry struct {
+	mu      sync.RWMutex
+	order   []string
+	prompts map[string]*compiledPrompt
+}
+
+// NewPromptRegistry creates an empty prompt registry
+func NewPromptRegistry() *PromptRegistry {
+	return &PromptRegistry{
+		prompts: make(map[string]*compiledPrompt),
+	}
+}
+
+// RegisterTemplate validates and compiles a prompt template. A template with the same name
+// as an existing prompt replaces it, which lets teams override the built-in prompts.
+func (r *PromptRegistry) RegisterTemplate(tmpl PromptTemplate) error {
+	if tmpl.Name == "" {
+		return fmt.Errorf("prompt name is required")
+	}
+	if len(tmpl.Messages) == 0 {
+		return fmt.Errorf("prompt %s has no messages", tmpl.Name)
+	}
+
+	for _, arg := range tmpl.Arguments {
+		if arg.Name == "" {
+			return fmt.Errorf("prompt %s has an argument without a name", tmpl.Name)
+		}
+		switch arg.Type {
+		case "", PromptArgumentString, PromptArgumentInteger, PromptArgumentBoolean:
+		default:
+			return fmt.Errorf("prompt %s argument %s has unsupported type: %s", tmpl.Name, arg.Name, arg.Type)
+		}
+	}
+
+	compiled := &compiledPrompt{definition: tmpl}
+	for i, msg := range tmpl.Messages {
+		if msg.Role != "user" && msg.Role != "assistant" {
+			return fmt.Errorf("prompt %s message %d has invalid role: %s", tmpl.Name, i, msg.Role)
+		}
+
+		t, err := template.New(fmt.Sprintf("%s[%d]", tmpl.Name, i)).Option("missingkey=zero").Parse(msg.Text)
+		if err != nil {
+			return fmt.Errorf("failed to parse prompt %s: %w", tmpl.Name, err)
+		}
+		compiled.messages = append(compiled.messages, t)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.prompts[tmpl.Name]; !exists {
+		r.order = append(r.order, tmpl.Name)
+	}
+	r.prompts[tmpl.Name] = compiled
+
+	return nil
+}
+
+// RegisterDirectory registers every *.json prompt template file in a directory
+func (r *PromptRegistry) RegisterDirectory(dirPath string) error {
+	info, err := os.Stat(dirPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("directory does not exist: %s", dirPath)
+		}
+		return err
+	}
+
+	if !info.IsDir() {
+		return fmt.Errorf("path is not a directory: %s", dirPath)
+	}
+
+	return filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || !strings.EqualFold(filepath.Ext(path), ".json") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt template %s: %w", path, err)
+		}
+
+		var tmpl PromptTemplate
+		if err := json.Unmarshal(data, &tmpl); err != nil {
+			return fmt.Errorf("failed to parse prompt template %s: %w", path, err)
+		}
+
+		if err := r.RegisterTemplate(tmpl); err != nil {
+			return fmt.Errorf("invalid prompt template %s: %w", path, err)
+		}
+
+		return nil
+	})
+}
+
+// ListPrompts returns the registered prompts in registration order
+func (r *PromptRegistry) ListPrompts() []Prompt {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prompts := make([]Prompt, 0, len(r.order))
+	for _, name := range r.order {
+		definition := r.prompts[name].definition
+
+		prompt := Prompt{
+			Name:        definition.Name,
+			Description: definition.Description,
+		}
+		for _, arg := range definition.Arguments {
+			prompt.Arguments = append(prompt.Arguments, PromptArgument{
+				Name:        arg.Name,
+				Description: describePromptArgument(arg),
+				Required:    arg.Required,
+			})
+		}
+
+		prompts = append(prompts, prompt)
+	}
+
+	return prompts
+}
+
+// GetPrompt validates the arguments and renders the named prompt
+func (r *PromptRegistry) GetPrompt(name string, arguments map[string]string) (*GetPromptResult, error) {
+	r.mu.RLock()
+	compiled, ok := r.prompts[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("prompt not found: %s", name)
+	}
+
+	values := make(map[string]string, len(compiled.definition.Arguments))
+	for _, arg := range compiled.definition.Arguments {
+		value := strings.TrimSpace(arguments[arg.Name])
+		if value == "" {
+			if arg.Required {
+				return nil, fmt.Errorf("missing required argument: %s", arg.Name)
+			}
+			continue
+		}
+
+		value, err := validatePromptArgument(arg, value)
+		if err != nil {
+			return nil, err
+		}
+		values[arg.Name] = value
+	}
+
+	result := &GetPromptResult{
+		Description: compiled.definition.Description,
+		Messages:    make([]PromptMessage, 0, len(compiled.messages)),
+	}
+
+	for i, t := range compiled.messages {
+		var text strings.Builder
+		if err := t.Execute(&text, values); err != nil {
+			return nil, fmt.Errorf("failed to render prompt %s: %w", name, err)
+		}
+
+		result.Messages = append(result.Messages, PromptMessage{
+			Role: compiled.definition.Messages[i].Role,
+			Content: PromptContent{
+				Type: "text",
+				Text: strings.TrimSpace(text.String()),
+			},
+		})
+	}
+
+	return result, nil
+}
+
+// validatePromptArgument checks a supplied value against the argument's declared type and
+// returns it in canonical form, so templates can compare booleans against "true"
+func validatePromptArgument(arg PromptTemplateArgument, value string) (string, error) {
+	switch arg.Type {
+	case PromptArgumentInteger:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("argument %s must be an integer", arg.Name)
+		}
+		value = strconv.Itoa(n)
+	case PromptArgumentBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("argument %s must be a boolean", arg.Name)
+		}
+		value = strconv.FormatBool(b)
+	}
+
+	if len(arg.Enum) > 0 {
+		for _, allowed := range arg.Enum {
+			if value == allowed {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("argument %s must be one of: %s", arg.Name, strings.Join(arg.Enum, ", "))
+	}
+
+	return value, nil
+}
+
+// describePromptArgument appends type hints to an argument description, since MCP clients
+// only ever see prompt arguments as strings
+func describePromptArgument(arg PromptTemplateArgument) string {
+	var hints []string
+	if arg.Type != "" && arg.Type != PromptArgumentString {
+		hints = append(hints, arg.Type)
+	}
+	if len(arg.Enum) > 0 {
+		hints = append(hints, "one of: "+strings.Join(arg.Enum, ", "))
+	}
+
+	if len(hints) == 0 {
+		return arg.Description
+	}
+	if arg.Description == "" {
+		return strings.Join(hints, "; ")
+	}
+	return fmt.Sprintf("%s (%s)", arg.Description, strings.Join(hints, "; "))
+}
@@ -0,0 +1,189 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPromptTemplate returns a small prompt template used across the prompt tests
+func testPromptTemplate() PromptTemplate {
+	return PromptTemplate{
+		Name:        "greet_device",
+		Description: "Greet a device",
+		Arguments: []PromptTemplateArgument{
+			{Name: "device", Description: "Device name", Required: true},
+			{Name: "count", Type: PromptArgumentInteger},
+			{Name: "loud", Type: PromptArgumentBoolean},
+			{Name: "tone", Enum: []string{"formal", "casual"}},
+		},
+		Messages: []PromptTemplateMessage{
+			{Role: "user", Text: "Hello {{.device}}{{if .count}} x{{.count}}{{end}}{{if eq .loud \"true\"}}!{{end}}{{if .tone}} ({{.tone}}){{end}}"},
+		},
+	}
+}
+
+// TestPromptRegistry tests registering, listing and rendering prompt templates
+func TestPromptRegistry(t *testing.T) {
+	registry := NewPromptRegistry()
+	require.NoError(t, registry.RegisterTemplate(testPromptTemplate()))
+
+	t.Run("ListPrompts", func(t *testing.T) {
+		prompts := registry.ListPrompts()
+		require.Len(t, prompts, 1)
+		assert.Equal(t, "greet_device", prompts[0].Name)
+		require.Len(t, prompts[0].Arguments, 4)
+		assert.True(t, prompts[0].Arguments[0].Required)
+		assert.Equal(t, "integer", prompts[0].Arguments[1].Description)
+		assert.Equal(t, "one of: formal, casual", prompts[0].Arguments[3].Description)
+	})
+
+	t.Run("GetPrompt", func(t *testing.T) {
+		result, err := registry.GetPrompt("greet_device", map[string]string{
+			"device": "MacBook",
+			"count":  "3",
+			"loud":   "1",
+			"tone":   "casual",
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Messages, 1)
+		assert.Equal(t, "user", result.Messages[0].Role)
+		assert.Equal(t, "text", result.Messages[0].Content.Type)
+		assert.Equal(t, "Hello MacBook x3! (casual)", result.Messages[0].Content.Text)
+	})
+
+	t.Run("OptionalArgumentsOmitted", func(t *testing.T) {
+		result, err := registry.GetPrompt("greet_device", map[string]string{"device": "iPad"})
+		require.NoError(t, err)
+		assert.Equal(t, "Hello iPad", result.Messages[0].Content.Text)
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		_, err := registry.GetPrompt("greet_device", map[string]string{})
+		assert.ErrorContains(t, err, "missing required argument: device")
+
+		_, err = registry.GetPrompt("greet_device", map[string]string{"device": "x", "count": "many"})
+		assert.ErrorContains(t, err, "must be an integer")
+
+		_, err = registry.GetPrompt("greet_device", map[string]string{"device": "x", "loud": "maybe"})
+		assert.ErrorContains(t, err, "must be a boolean")
+
+		_, err = registry.GetPrompt("greet_device", map[string]string{"device": "x", "tone": "rude"})
+		assert.ErrorContains(t, err, "must be one of")
+
+		_, err = registry.GetPrompt("unknown", nil)
+		assert.ErrorContains(t, err, "prompt not found")
+	})
+
+	t.Run("InvalidTemplates", func(t *testing.T) {
+		assert.Error(t, registry.RegisterTemplate(PromptTemplate{Name: "empty"}))
+		assert.Error(t, registry.RegisterTemplate(PromptTemplate{
+			Name:     "bad_role",
+			Messages: []PromptTemplateMessage{{Role: "system", Text: "hi"}},
+		}))
+		assert.Error(t, registry.RegisterTemplate(PromptTemplate{
+			Name:     "bad_syntax",
+			Messages: []PromptTemplateMessage{{Role: "user", Text: "{{.unclosed"}},
+		}))
+		assert.Error(t, registry.RegisterTemplate(PromptTemplate{
+			Name:      "bad_type",
+			Arguments: []PromptTemplateArgument{{Name: "n", Type: "float"}},
+			Messages:  []PromptTemplateMessage{{Role: "user", Text: "hi"}},
+		}))
+	})
+}
+
+// TestPromptRegistryRegisterDirectory tests loading prompt templates from a directory
+func TestPromptRegistryRegisterDirectory(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"check_in.json":       `{"name": "check_in", "messages": [{"role": "user", "text": "Check in {{.serial}}"}], "arguments": [{"name": "serial", "required": true}]}`,
+		"nested/restart.json": `{"name": "restart", "messages": [{"role": "user", "text": "Restart it"}]}`,
+		"README.md":           "not a prompt",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(tempDir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+
+	registry := NewPromptRegistry()
+	require.NoError(t, registry.RegisterTemplate(PromptTemplate{
+		Name:     "check_in",
+		Messages: []PromptTemplateMessage{{Role: "user", Text: "built-in"}},
+	}))
+	require.NoError(t, registry.RegisterDirectory(tempDir))
+
+	prompts := registry.ListPrompts()
+	require.Len(t, prompts, 2)
+
+	// Templates from the directory override prompts with the same name
+	result, err := registry.GetPrompt("check_in", map[string]string{"serial": "C02XYZ"})
+	require.NoError(t, err)
+	assert.Equal(t, "Check in C02XYZ", result.Messages[0].Content.Text)
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "broken.json"), []byte("{"), 0644))
+		assert.ErrorContains(t, NewPromptRegistry().RegisterDirectory(tempDir), "broken.json")
+	})
+
+	t.Run("MissingDirectory", func(t *testing.T) {
+		assert.Error(t, NewPromptRegistry().RegisterDirectory(filepath.Join(tempDir, "missing")))
+	})
+}
+
+// TestPromptsProtocol tests the prompts/list and prompts/get methods
+func TestPromptsProtocol(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+
+	registry := NewPromptRegistry()
+	require.NoError(t, registry.RegisterTemplate(testPromptTemplate()))
+	server.SetPromptRegistry(registry)
+
+	session, err := server.CreateSession()
+	require.NoError(t, err)
+	ctx := ContextWithSession(context.Background(), session)
+
+	response, err := server.HandleMessage(ctx, &Message{JSONRPC: "2.0", ID: 1, Method: "initialize"})
+	require.NoError(t, err)
+	result, ok := response.Result.(*InitializeResult)
+	require.True(t, ok)
+	assert.NotNil(t, result.Capabilities.Prompts)
+
+	response, err = server.HandleMessage(ctx, &Message{JSONRPC: "2.0", ID: 2, Method: "prompts/list"})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+	listResult, ok := response.Result.(*ListPromptsResult)
+	require.True(t, ok)
+	assert.Len(t, listResult.Prompts, 1)
+
+	response, err = server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      3,
+		Method:  "prompts/get",
+		Params: map[string]interface{}{
+			"name":      "greet_device",
+			"arguments": map[string]interface{}{"device": "Mac mini"},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+	getResult, ok := response.Result.(*GetPromptResult)
+	require.True(t, ok)
+	assert.Equal(t, "Hello Mac mini", getResult.Messages[0].Content.Text)
+
+	response, err = server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      4,
+		Method:  "prompts/get",
+		Params:  map[string]interface{}{"name": "greet_device"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, InvalidParams, response.Error.Code)
+}
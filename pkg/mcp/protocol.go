@@ -0,0 +1,1030 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MCP Protocol types based on the Model Context Protocol specification
+
+// Message represents a base MCP message
+type Message struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      interface{} `json:"id,omitempty"`
+	Method  string      `json:"method,omitempty"`
+	Params  interface{} `json:"params,omitempty"`
+	Result  interface{} `json:"result,omitempty"`
+	Error   *Error      `json:"error,omitempty"`
+}
+
+// Error represents an MCP error
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Standard MCP error codes
+const (
+	ParseError           = -32700
+	InvalidRequest       = -32600
+	MethodNotFound       = -32601
+	InvalidParams        = -32602
+	InternalError        = -32603
+	ServerError          = -32000
+	ResourceNotFound     = -32001
+	InvalidResourceURI   = -32002
+	ResourceAccessDenied = -32003
+	ToolNotFound         = -32004
+	ToolExecutionError   = -32005
+)
+
+// ErrAlreadyInitialized is returned for an initialize request on a session that has already
+// completed the handshake
+var ErrAlreadyInitialized = errors.New("session is already initialized")
+
+// ErrNotInitialized is returned for requests on a session that has not completed the handshake
+var ErrNotInitialized = errors.New("server not initialized")
+
+// InitializeParams represents the initialize request parameters
+type InitializeParams struct {
+	ProtocolVersion string                 `json:"protocolVersion"`
+	Capabilities    ClientCapabilities     `json:"capabilities"`
+	ClientInfo      ClientInfo             `json:"clientInfo"`
+	Meta            map[string]interface{} `json:"meta,omitempty"`
+}
+
+// ClientCapabilities represents client capabilities
+type ClientCapabilities struct {
+	Experimental map[string]interface{} `json:"experimental,omitempty"`
+	Sampling     *SamplingCapabilities  `json:"sampling,omitempty"`
+}
+
+// SamplingCapabilities represents sampling capabilities
+type SamplingCapabilities struct{}
+
+// ClientInfo represents client information
+type ClientInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// InitializeResult represents the initialize response
+type InitializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+	ServerInfo      ServerInfo         `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+// ServerCapabilities represents server capabilities
+type ServerCapabilities struct {
+	Experimental map[string]interface{} `json:"experimental,omitempty"`
+	Logging      *LoggingCapabilities   `json:"logging,omitempty"`
+	Prompts      *PromptsCapabilities   `json:"prompts,omitempty"`
+	Resources    *ResourcesCapabilities `json:"resources,omitempty"`
+	Tools        *ToolsCapabilities     `json:"tools,omitempty"`
+}
+
+// LoggingCapabilities represents logging capabilities
+type LoggingCapabilities struct{}
+
+// PromptsCapabilities represents prompts capabilities
+type PromptsCapabilities struct {
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// ResourcesCapabilities represents resources capabilities
+type ResourcesCapabilities struct {
+	Subscribe   bool `json:"subscribe,omitempty"`
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// ToolsCapabilities represents tools capabilities
+type ToolsCapabilities struct {
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// ServerInfo represents server information
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ListToolsParams represents the list tools request parameters
+type ListToolsParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ListToolsResult represents the list tools response
+type ListToolsResult struct {
+	Tools      []Tool  `json:"tools"`
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// Tool represents an MCP tool
+type Tool struct {
+	Name         string                 `json:"name"`
+	Description  string                 `json:"description"`
+	InputSchema  ToolInputSchema        `json:"inputSchema"`
+	OutputSchema map[string]interface{} `json:"outputSchema,omitempty"`
+	Annotations  *ToolAnnotations       `json:"annotations,omitempty"`
+	Meta         map[string]interface{} `json:"meta,omitempty"`
+	Access       ToolAccess             `json:"-"`
+	Toolset      string                 `json:"-"`
+}
+
+// ToolAccess classifies what a tool does to the Jamf Pro instance
+type ToolAccess string
+
+const (
+	// ToolAccessRead tools only query data
+	ToolAccessRead ToolAccess = "read"
+	// ToolAccessWrite tools create or modify objects
+	ToolAccessWrite ToolAccess = "write"
+	// ToolAccessDestructive tools delete objects or send irreversible device commands
+	ToolAccessDestructive ToolAccess = "destructive"
+)
+
+// IsReadOnly reports whether the tool only reads data. Unclassified tools are treated as
+// mutating so that read-only mode fails closed.
+func (t *Tool) IsReadOnly() bool {
+	return t.Access == ToolAccessRead
+}
+
+// ToolAnnotations describes a tool's behaviour to clients. The hints are advisory: clients use
+// them to decide which calls need the user's approval, but must not rely on them for safety.
+// The helpers below set openWorldHint to false because tools only act on the objects of the
+// configured Jamf Pro instance.
+type ToolAnnotations struct {
+	Title           string `json:"title,omitempty"`
+	ReadOnlyHint    *bool  `json:"readOnlyHint,omitempty"`
+	DestructiveHint *bool  `json:"destructiveHint,omitempty"`
+	IdempotentHint  *bool  `json:"idempotentHint,omitempty"`
+	OpenWorldHint   *bool  `json:"openWorldHint,omitempty"`
+}
+
+// ReadOnlyAnnotations returns the annotations of a tool that only queries data
+func ReadOnlyAnnotations(title string) *ToolAnnotations {
+	return &ToolAnnotations{
+		Title:         title,
+		ReadOnlyHint:  boolPtr(true),
+		OpenWorldHint: boolPtr(false),
+	}
+}
+
+// WriteAnnotations returns the annotations of a tool that only adds to Jamf Pro, such as one
+// that creates objects
+func WriteAnnotations(title string, idempotent bool) *ToolAnnotations {
+	return &ToolAnnotations{
+		Title:           title,
+		ReadOnlyHint:    boolPtr(false),
+		DestructiveHint: boolPtr(false),
+		IdempotentHint:  boolPtr(idempotent),
+		OpenWorldHint:   boolPtr(false),
+	}
+}
+
+// UpdateAnnotations returns the annotations of a tool that modifies existing objects in place.
+// An update overwrites the values it replaces, so it is destructive, but repeating it with the
+// same arguments has no further effect.
+func UpdateAnnotations(title string) *ToolAnnotations {
+	return &ToolAnnotations{
+		Title:           title,
+		ReadOnlyHint:    boolPtr(false),
+		DestructiveHint: boolPtr(true),
+		IdempotentHint:  boolPtr(true),
+		OpenWorldHint:   boolPtr(false),
+	}
+}
+
+// DestructiveAnnotations returns the annotations of a tool that deletes objects or sends
+// irreversible device commands
+func DestructiveAnnotations(title string, idempotent bool) *ToolAnnotations {
+	return &ToolAnnotations{
+		Title:           title,
+		ReadOnlyHint:    boolPtr(false),
+		DestructiveHint: boolPtr(true),
+		IdempotentHint:  boolPtr(idempotent),
+		OpenWorldHint:   boolPtr(false),
+	}
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+// ToolInputSchema represents the tool input schema
+type ToolInputSchema struct {
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+}
+
+// CallToolParams represents the call tool request parameters
+type CallToolParams struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Meta      *RequestMeta           `json:"_meta,omitempty"`
+}
+
+// CallToolResult represents the call tool response
+type CallToolResult struct {
+	Content           []ToolContent          `json:"content"`
+	StructuredContent interface{}            `json:"structuredContent,omitempty"`
+	IsError           bool                   `json:"isError,omitempty"`
+	Meta              map[string]interface{} `json:"meta,omitempty"`
+}
+
+// ToolContent represents tool content
+type ToolContent struct {
+	Type string      `json:"type"`
+	Text string      `json:"text,omitempty"`
+	Data interface{} `json:"data,omitempty"`
+	Name string      `json:"name,omitempty"`
+}
+
+// ListResourcesParams represents the list resources request parameters
+type ListResourcesParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ListResourcesResult represents the list resources response
+type ListResourcesResult struct {
+	Resources  []Resource `json:"resources"`
+	NextCursor *string    `json:"nextCursor,omitempty"`
+}
+
+// Resource represents an MCP resource
+type Resource struct {
+	URI         string                 `json:"uri"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	MimeType    string                 `json:"mimeType,omitempty"`
+	Meta        map[string]interface{} `json:"meta,omitempty"`
+}
+
+// ListResourceTemplatesParams represents the list resource templates request parameters
+type ListResourceTemplatesParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ListResourceTemplatesResult represents the list resource templates response
+type ListResourceTemplatesResult struct {
+	ResourceTemplates []ResourceTemplate `json:"resourceTemplates"`
+	NextCursor        *string            `json:"nextCursor,omitempty"`
+}
+
+// ResourceTemplate describes a family of resources by an RFC 6570 URI template, such as
+// jamf://computers/{id}
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ReadResourceParams represents the read resource request parameters
+type ReadResourceParams struct {
+	URI string `json:"uri"`
+}
+
+// ReadResourceResult represents the read resource response
+type ReadResourceResult struct {
+	Contents []ResourceContent      `json:"contents"`
+	Meta     map[string]interface{} `json:"meta,omitempty"`
+}
+
+// ResourceContent represents resource content
+type ResourceContent struct {
+	URI      string      `json:"uri"`
+	MimeType string      `json:"mimeType,omitempty"`
+	Text     string      `json:"text,omitempty"`
+	Blob     string      `json:"blob,omitempty"`
+	Meta     interface{} `json:"meta,omitempty"`
+}
+
+// LoggingLevel represents logging levels
+type LoggingLevel string
+
+const (
+	LoggingLevelDebug     LoggingLevel = "debug"
+	LoggingLevelInfo      LoggingLevel = "info"
+	LoggingLevelNotice    LoggingLevel = "notice"
+	LoggingLevelWarning   LoggingLevel = "warning"
+	LoggingLevelError     LoggingLevel = "error"
+	LoggingLevelCritical  LoggingLevel = "critical"
+	LoggingLevelAlert     LoggingLevel = "alert"
+	LoggingLevelEmergency LoggingLevel = "emergency"
+)
+
+// LoggingParams represents the logging message parameters
+type LoggingParams struct {
+	Level  LoggingLevel           `json:"level"`
+	Data   interface{}            `json:"data,omitempty"`
+	Logger string                 `json:"logger,omitempty"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+}
+
+// Handler represents an MCP handler interface
+type Handler interface {
+	HandleMessage(ctx context.Context, msg *Message) (*Message, error)
+}
+
+// Supported MCP protocol versions, newest first
+var supportedProtocolVersions = []string{
+	"2025-06-18",
+	"2025-03-26",
+	"2024-11-05",
+}
+
+// Server represents the MCP server - FIXED with missing fields and methods
+type Server struct {
+	capabilities     ServerCapabilities
+	serverInfo       ServerInfo
+	toolsMu          sync.RWMutex // Guards toolHandlers, toolNames and toolRegistry, which change when toolsets are enabled at runtime
+	toolHandlers     map[string]ToolHandler
+	toolNames        []string         // Names of the tools in toolHandlers, kept sorted so listings are stable
+	toolRegistry     map[string]*Tool // ADDED: Store actual tool definitions
+	resourceProvider ResourceProvider
+	promptRegistry   *PromptRegistry
+	readOnly         bool         // Hides and refuses every tool that is not ToolAccessRead
+	pageSize         atomic.Int64 // Items per page of tools/list and resources/list
+
+	accessMu             sync.RWMutex
+	accessProfiles       []*AccessProfile // Matched against client names in order
+	defaultAccessProfile *AccessProfile   // Assigned when no profile matches; nil leaves the session unrestricted
+
+	sessionsMu     sync.RWMutex
+	sessions       map[string]*Session
+	defaultSession *Session // Used when a message arrives without a session in its context
+
+	subscriptionsMu sync.Mutex
+	subscriptions   map[string]map[*Session]bool // Sessions subscribed to each resource URI
+
+	recentLogs recentLogs // Warnings and errors replayed to clients that choose a log level
+
+	toolCallObserver ToolCallObserver // Told about every tools/call request, or nil
+}
+
+// ToolHandler represents a tool handler function
+type ToolHandler func(ctx context.Context, params CallToolParams) (*CallToolResult, error)
+
+// ToolCall describes a tools/call request after it has been answered or refused
+type ToolCall struct {
+	Tool    Tool
+	Params  CallToolParams
+	Started time.Time
+
+	// Denied is set when the call never reached the tool because the tool is unknown or not
+	// permitted by read-only mode or the session's access profile; Err then says why
+	Denied bool
+
+	Result *CallToolResult
+	Err    error
+}
+
+// ToolCallObserver is called with every tools/call request once it is finished, including
+// requests that were refused, for example to audit them
+type ToolCallObserver func(ctx context.Context, call *ToolCall)
+
+// NewServer creates a new MCP server - FIXED to initialize all fields
+func NewServer(name, version string) *Server {
+	server := &Server{
+		capabilities: ServerCapabilities{
+			Tools: &ToolsCapabilities{
+				ListChanged: true,
+			},
+			Resources: &ResourcesCapabilities{
+				Subscribe:   false,
+				ListChanged: true,
+			},
+			Logging: &LoggingCapabilities{},
+		},
+		serverInfo: ServerInfo{
+			Name:    name,
+			Version: version,
+		},
+		toolHandlers:   make(map[string]ToolHandler),
+		toolRegistry:   make(map[string]*Tool), // ADDED: Initialize tool registry
+		sessions:       make(map[string]*Session),
+		defaultSession: newSession(""),
+		subscriptions:  make(map[string]map[*Session]bool),
+	}
+	server.pageSize.Store(DefaultPageSize)
+	return server
+}
+
+// CreateSession creates and registers a new client session
+func (s *Server) CreateSession() (*Session, error) {
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	session := newSession(id)
+
+	s.sessionsMu.Lock()
+	s.sessions[id] = session
+	s.sessionsMu.Unlock()
+
+	return session, nil
+}
+
+// GetSession returns the session with the given ID
+func (s *Server) GetSession(id string) (*Session, bool) {
+	s.sessionsMu.RLock()
+	defer s.sessionsMu.RUnlock()
+	session, ok := s.sessions[id]
+	return session, ok
+}
+
+// CloseSession removes the session with the given ID
+func (s *Server) CloseSession(id string) {
+	s.sessionsMu.Lock()
+	session, ok := s.sessions[id]
+	delete(s.sessions, id)
+	s.sessionsMu.Unlock()
+
+	if ok {
+		s.removeSessionSubscriptions(session)
+		session.close()
+	}
+}
+
+// Close closes every session, including the default one, which stops the goroutines that
+// deliver their queued messages. Transports call it when the server shuts down.
+func (s *Server) Close() {
+	s.sessionsMu.Lock()
+	sessions := s.sessions
+	s.sessions = make(map[string]*Session)
+	s.sessionsMu.Unlock()
+
+	for _, session := range sessions {
+		s.removeSessionSubscriptions(session)
+		session.close()
+	}
+	s.defaultSession.close()
+}
+
+// sessionFromContext returns the session carried by ctx, falling back to the default session
+func (s *Server) sessionFromContext(ctx context.Context) *Session {
+	if session := SessionFromContext(ctx); session != nil {
+		return session
+	}
+	return s.defaultSession
+}
+
+// SetResourceProvider sets the resource provider for the server and advertises resource
+// subscriptions if the provider supports them
+func (s *Server) SetResourceProvider(provider ResourceProvider) {
+	s.resourceProvider = provider
+	_, subscribe := provider.(ResourceSubscriber)
+	s.capabilities.Resources.Subscribe = subscribe
+}
+
+// SetToolCallObserver sets the function told about every tools/call request
+func (s *Server) SetToolCallObserver(observer ToolCallObserver) {
+	s.toolCallObserver = observer
+}
+
+// SetPromptRegistry sets the prompts offered by the server and advertises the prompts capability
+func (s *Server) SetPromptRegistry(registry *PromptRegistry) {
+	s.promptRegistry = registry
+	s.capabilities.Prompts = &PromptsCapabilities{}
+}
+
+// SetReadOnly enables or disables read-only mode
+func (s *Server) SetReadOnly(readOnly bool) {
+	s.readOnly = readOnly
+}
+
+// SetPageSize sets the number of items per page of tools/list and resources/list. Zero
+// returns every item in one page.
+func (s *Server) SetPageSize(pageSize int) {
+	s.pageSize.Store(int64(pageSize))
+}
+
+// GetRegisteredTools returns the names of the registered tools in alphabetical order
+func (s *Server) GetRegisteredTools() []string {
+	s.toolsMu.RLock()
+	defer s.toolsMu.RUnlock()
+
+	return slices.Clone(s.toolNames)
+}
+
+// RegisterTool registers a tool handler
+func (s *Server) RegisterTool(name string, handler ToolHandler) {
+	s.toolsMu.Lock()
+	defer s.toolsMu.Unlock()
+
+	if _, exists := s.toolHandlers[name]; !exists {
+		i, _ := slices.BinarySearch(s.toolNames, name)
+		s.toolNames = slices.Insert(s.toolNames, i, name)
+	}
+	s.toolHandlers[name] = handler
+}
+
+// RegisterToolDefinition registers a tool definition - ADDED to store schemas
+func (s *Server) RegisterToolDefinition(tool *Tool) {
+	s.toolsMu.Lock()
+	defer s.toolsMu.Unlock()
+
+	if s.toolRegistry == nil {
+		s.toolRegistry = make(map[string]*Tool)
+	}
+	toolCopy := *tool // Make a copy to avoid pointer issues
+	s.toolRegistry[tool.Name] = &toolCopy
+}
+
+// UnregisterTool removes a tool's handler and definition
+func (s *Server) UnregisterTool(name string) {
+	s.toolsMu.Lock()
+	defer s.toolsMu.Unlock()
+
+	if _, exists := s.toolHandlers[name]; exists {
+		i, _ := slices.BinarySearch(s.toolNames, name)
+		s.toolNames = slices.Delete(s.toolNames, i, i+1)
+	}
+	delete(s.toolHandlers, name)
+	delete(s.toolRegistry, name)
+}
+
+// HandleMessage handles an incoming MCP message. Notifications, which carry no ID, are
+// processed without producing a response, as are requests cancelled by the client.
+func (s *Server) HandleMessage(ctx context.Context, msg *Message) (*Message, error) {
+	if msg.ID == nil {
+		return nil, s.handleNotification(ctx, msg)
+	}
+
+	// Give every request its own context so notifications/cancelled can stop it, unless the
+	// transport already did when the request arrived
+	if !isTrackedRequest(ctx, msg.ID) {
+		var done context.CancelFunc
+		ctx, done = s.TrackRequest(ctx, msg.ID)
+		defer done()
+	}
+
+	// A request cancelled while it was queued is never started
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+
+	response := &Message{
+		JSONRPC: "2.0",
+		ID:      msg.ID,
+	}
+
+	switch msg.Method {
+	case "initialize":
+		result, err := s.handleInitialize(ctx, msg.Params)
+		if err != nil {
+			response.Error = &Error{
+				Code:    initializeErrorCode(err),
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+	case "tools/list":
+		result, err := s.handleListTools(ctx, msg.Params)
+		if err != nil {
+			response.Error = &Error{
+				Code:    listErrorCode(err),
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+	case "tools/call":
+		result, err := s.handleCallTool(ctx, msg.Params)
+		if err != nil {
+			response.Error = &Error{
+				Code:    ToolExecutionError,
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+	case "resources/list":
+		result, err := s.handleListResources(ctx, msg.Params)
+		if err != nil {
+			response.Error = &Error{
+				Code:    listErrorCode(err),
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+	case "resources/templates/list":
+		result, err := s.handleListResourceTemplates(ctx, msg.Params)
+		if err != nil {
+			response.Error = &Error{
+				Code:    listErrorCode(err),
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+	case "resources/subscribe":
+		result, err := s.handleSubscribe(ctx, msg.Params)
+		if err != nil {
+			response.Error = &Error{
+				Code:    resourceErrorCode(err),
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+	case "resources/unsubscribe":
+		result, err := s.handleUnsubscribe(ctx, msg.Params)
+		if err != nil {
+			response.Error = &Error{
+				Code:    InternalError,
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+	case "resources/read":
+		result, err := s.handleReadResource(ctx, msg.Params)
+		if err != nil {
+			response.Error = &Error{
+				Code:    resourceErrorCode(err),
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+	case "prompts/list":
+		result, err := s.handleListPrompts(ctx, msg.Params)
+		if err != nil {
+			response.Error = &Error{
+				Code:    InternalError,
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+	case "prompts/get":
+		result, err := s.handleGetPrompt(ctx, msg.Params)
+		if err != nil {
+			response.Error = &Error{
+				Code:    InvalidParams,
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+	case "logging/setLevel":
+		result, err := s.handleSetLevel(ctx, msg.Params)
+		if err != nil {
+			response.Error = &Error{
+				Code:    InvalidParams,
+				Message: err.Error(),
+			}
+		} else {
+			response.Result = result
+		}
+
+	default:
+		response.Error = &Error{
+			Code:    MethodNotFound,
+			Message: fmt.Sprintf("method not found: %s", msg.Method),
+		}
+	}
+
+	// The client is no longer waiting for a cancelled request
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+
+	return response, nil
+}
+
+func (s *Server) handleInitialize(ctx context.Context, params interface{}) (*InitializeResult, error) {
+	var initParams InitializeParams
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal params: %w", err)
+		}
+
+		if err := json.Unmarshal(data, &initParams); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal initialize params: %w", err)
+		}
+	}
+
+	// The profile chosen from the first client name stays with the session, so a client
+	// cannot initialize again under another name to widen its access
+	session := s.sessionFromContext(ctx)
+	if !session.markInitialized(initParams.ClientInfo, s.profileForClient) {
+		return nil, ErrAlreadyInitialized
+	}
+
+	return &InitializeResult{
+		ProtocolVersion: negotiateProtocolVersion(initParams.ProtocolVersion),
+		Capabilities:    s.capabilities,
+		ServerInfo:      s.serverInfo,
+		Instructions: "This server provides access to Jamf Pro APIs for managing Apple devices, mobile devices, policies, scripts, configuration profiles, and more. " +
+			"Use the available tools to interact with your Jamf Pro environment. Authentication is handled automatically based on the server configuration.",
+	}, nil
+}
+
+// initializeErrorCode returns the JSON-RPC error code for a failed initialize request
+func initializeErrorCode(err error) int {
+	if errors.Is(err, ErrAlreadyInitialized) {
+		return InvalidRequest
+	}
+	return InternalError
+}
+
+// negotiateProtocolVersion returns the requested protocol version if it is supported,
+// otherwise the newest version this server implements
+func negotiateProtocolVersion(requested string) string {
+	for _, version := range supportedProtocolVersions {
+		if version == requested {
+			return version
+		}
+	}
+	return supportedProtocolVersions[0]
+}
+
+func (s *Server) handleListTools(ctx context.Context, params interface{}) (*ListToolsResult, error) {
+	if !s.sessionFromContext(ctx).IsInitialized() {
+		return nil, ErrNotInitialized
+	}
+
+	var listParams ListToolsParams
+	if err := decodeParams(params, &listParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal list tools params: %w", err)
+	}
+
+	profile := s.sessionFromContext(ctx).AccessProfile()
+
+	s.toolsMu.RLock()
+	tools := make([]Tool, 0, len(s.toolNames))
+	for _, name := range s.toolNames {
+		tool := s.getToolDefinition(name)
+		if tool == nil {
+			continue
+		}
+
+		// Read-only mode hides every tool that can change Jamf Pro
+		if s.readOnly && !tool.IsReadOnly() {
+			continue
+		}
+
+		if allowed, _ := profile.Allows(tool); !allowed {
+			continue
+		}
+
+		tools = append(tools, *tool)
+	}
+	s.toolsMu.RUnlock()
+
+	page, nextCursor, err := paginate("tools", tools, func(tool Tool) string { return tool.Name }, listParams.Cursor, int(s.pageSize.Load()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListToolsResult{
+		Tools:      page,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+func (s *Server) handleCallTool(ctx context.Context, params interface{}) (*CallToolResult, error) {
+	if !s.sessionFromContext(ctx).IsInitialized() {
+		return nil, ErrNotInitialized
+	}
+
+	var callParams CallToolParams
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &callParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal call tool params: %w", err)
+	}
+
+	call := &ToolCall{Params: callParams, Started: time.Now()}
+	if s.toolCallObserver != nil {
+		defer func() { s.toolCallObserver(ctx, call) }()
+	}
+
+	handler, tool, err := s.authorizeTool(ctx, callParams.Name)
+	call.Tool = *tool
+	if err != nil {
+		call.Denied, call.Err = true, err
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		call.Err = fmt.Errorf("request cancelled: %w", err)
+		return nil, call.Err
+	}
+
+	handlerCtx := ctx
+	if callParams.Meta != nil && callParams.Meta.ProgressToken != nil {
+		handlerCtx = s.contextWithProgress(ctx, callParams.Meta.ProgressToken)
+	}
+
+	call.Result, call.Err = handler(handlerCtx, callParams)
+	return call.Result, call.Err
+}
+
+// AuthorizeTool returns an error unless the session in ctx may call the named tool: the tool
+// must be registered and permitted by read-only mode and the session's access profile
+func (s *Server) AuthorizeTool(ctx context.Context, name string) error {
+	_, _, err := s.authorizeTool(ctx, name)
+	return err
+}
+
+// authorizeTool checks that the session in ctx may call the named tool and returns its
+// handler. The tool's definition is returned even when the call is refused.
+func (s *Server) authorizeTool(ctx context.Context, name string) (ToolHandler, *Tool, error) {
+	s.toolsMu.RLock()
+	handler, exists := s.toolHandlers[name]
+	tool := s.getToolDefinition(name)
+	s.toolsMu.RUnlock()
+	if !exists {
+		return nil, &Tool{Name: name}, fmt.Errorf("tool not found: %s", name)
+	}
+
+	if s.readOnly && !tool.IsReadOnly() {
+		return nil, tool, fmt.Errorf("tool %s modifies Jamf Pro and is disabled in read-only mode", name)
+	}
+
+	if profile := s.sessionFromContext(ctx).AccessProfile(); profile != nil {
+		if allowed, reason := profile.Allows(tool); !allowed {
+			return nil, tool, fmt.Errorf("tool %s is not permitted by access profile %s: %s", name, profile.Name, reason)
+		}
+	}
+
+	return handler, tool, nil
+}
+
+// getToolDefinition returns the tool definition for a given tool name - FIXED to use registry.
+// Callers must hold toolsMu.
+func (s *Server) getToolDefinition(name string) *Tool {
+	// Try to get from registry first
+	if tool, exists := s.toolRegistry[name]; exists {
+		return tool
+	}
+
+	// Fallback to basic definition
+	return &Tool{
+		Name:        name,
+		Description: fmt.Sprintf("Tool: %s", name),
+		InputSchema: ToolInputSchema{
+			Type:       "object",
+			Properties: make(map[string]interface{}),
+			Required:   []string{},
+		},
+	}
+}
+
+// handleListResources handles the resources/list method
+func (s *Server) handleListResources(ctx context.Context, params interface{}) (*ListResourcesResult, error) {
+	if !s.sessionFromContext(ctx).IsInitialized() {
+		return nil, ErrNotInitialized
+	}
+
+	if s.resourceProvider == nil {
+		return &ListResourcesResult{
+			Resources: []Resource{},
+		}, nil
+	}
+
+	var listParams ListResourcesParams
+	if err := decodeParams(params, &listParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal list resources params: %w", err)
+	}
+
+	resources, err := s.resourceProvider.ListResources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+
+	page, nextCursor, err := paginate("resources", resources, func(resource Resource) string { return resource.URI }, listParams.Cursor, int(s.pageSize.Load()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListResourcesResult{
+		Resources:  page,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// handleReadResource handles the resources/read method
+func (s *Server) handleReadResource(ctx context.Context, params interface{}) (*ReadResourceResult, error) {
+	if !s.sessionFromContext(ctx).IsInitialized() {
+		return nil, ErrNotInitialized
+	}
+
+	if s.resourceProvider == nil {
+		return nil, fmt.Errorf("resource provider not configured")
+	}
+
+	var readParams ReadResourceParams
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &readParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal read resource params: %w", err)
+	}
+
+	return s.resourceProvider.ReadResource(ctx, readParams.URI)
+}
+
+// handleListResourceTemplates handles the resources/templates/list method
+func (s *Server) handleListResourceTemplates(ctx context.Context, params interface{}) (*ListResourceTemplatesResult, error) {
+	if !s.sessionFromContext(ctx).IsInitialized() {
+		return nil, ErrNotInitialized
+	}
+
+	if s.resourceProvider == nil {
+		return &ListResourceTemplatesResult{
+			ResourceTemplates: []ResourceTemplate{},
+		}, nil
+	}
+
+	var listParams ListResourceTemplatesParams
+	if err := decodeParams(params, &listParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal list resource templates params: %w", err)
+	}
+
+	templates, err := s.resourceProvider.ListResourceTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource templates: %w", err)
+	}
+	if templates == nil {
+		templates = []ResourceTemplate{}
+	}
+
+	page, nextCursor, err := paginate("resourceTemplates", templates, func(template ResourceTemplate) string { return template.URITemplate }, listParams.Cursor, int(s.pageSize.Load()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListResourceTemplatesResult{
+		ResourceTemplates: page,
+		NextCursor:        nextCursor,
+	}, nil
+}
+
+// handleListPrompts handles the prompts/list method
+func (s *Server) handleListPrompts(ctx context.Context, params interface{}) (*ListPromptsResult, error) {
+	if !s.sessionFromContext(ctx).IsInitialized() {
+		return nil, ErrNotInitialized
+	}
+
+	if s.promptRegistry == nil {
+		return &ListPromptsResult{
+			Prompts: []Prompt{},
+		}, nil
+	}
+
+	return &ListPromptsResult{
+		Prompts: s.promptRegistry.ListPrompts(),
+	}, nil
+}
+
+// handleGetPrompt handles the prompts/get method
+func (s *Server) handleGetPrompt(ctx context.Context, params interface{}) (*GetPromptResult, error) {
+	if !s.sessionFromContext(ctx).IsInitialized() {
+		return nil, ErrNotInitialized
+	}
+
+	if s.promptRegistry == nil {
+		return nil, fmt.Errorf("prompts not configured")
+	}
+
+	var getParams GetPromptParams
+	if err := decodeParams(params, &getParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal get prompt params: %w", err)
+	}
+
+	return s.promptRegistry.GetPrompt(getParams.Name, getParams.Arguments)
+}
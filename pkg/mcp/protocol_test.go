@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReadOnlyMode tests that read-only mode hides and refuses mutating tools
+func TestReadOnlyMode(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	server.SetReadOnly(true)
+	_, ctx := newInitializedSession(t, server)
+
+	handler := func(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+		return &CallToolResult{Content: []ToolContent{{Type: "text", Text: params.Name}}}, nil
+	}
+	for _, tool := range []Tool{
+		{Name: "get_device", Access: ToolAccessRead},
+		{Name: "update_device", Access: ToolAccessWrite},
+		{Name: "erase_device", Access: ToolAccessDestructive},
+		{Name: "unclassified_tool"},
+	} {
+		server.RegisterToolDefinition(&tool)
+		server.RegisterTool(tool.Name, handler)
+	}
+
+	response, err := server.HandleMessage(ctx, &Message{JSONRPC: "2.0", ID: 1, Method: "tools/list"})
+	require.NoError(t, err)
+	result, ok := response.Result.(*ListToolsResult)
+	require.True(t, ok)
+	require.Len(t, result.Tools, 1)
+	assert.Equal(t, "get_device", result.Tools[0].Name)
+
+	for _, name := range []string{"update_device", "erase_device", "unclassified_tool"} {
+		response, err := server.HandleMessage(ctx, &Message{
+			JSONRPC: "2.0",
+			ID:      2,
+			Method:  "tools/call",
+			Params:  map[string]interface{}{"name": name},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, response.Error, name)
+		assert.Contains(t, response.Error.Message, "read-only mode", name)
+	}
+
+	response, err = server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      3,
+		Method:  "tools/call",
+		Params:  map[string]interface{}{"name": "get_device"},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, response.Error)
+
+	// Leaving read-only mode exposes every tool again
+	server.SetReadOnly(false)
+	response, err = server.HandleMessage(ctx, &Message{JSONRPC: "2.0", ID: 4, Method: "tools/list"})
+	require.NoError(t, err)
+	assert.Len(t, response.Result.(*ListToolsResult).Tools, 4)
+}
+
+// TestUnregisterTool tests that an unregistered tool is neither listed nor callable
+func TestUnregisterTool(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	_, ctx := newInitializedSession(t, server)
+
+	handler := func(ctx context.Context, params CallToolParams) (*CallToolResult, error) {
+		return &CallToolResult{Content: []ToolContent{{Type: "text", Text: params.Name}}}, nil
+	}
+	for _, tool := range []Tool{{Name: "get_device"}, {Name: "get_policy"}} {
+		server.RegisterToolDefinition(&tool)
+		server.RegisterTool(tool.Name, handler)
+	}
+
+	server.UnregisterTool("get_policy")
+	assert.Equal(t, []string{"get_device"}, server.GetRegisteredTools())
+
+	response, err := server.HandleMessage(ctx, &Message{JSONRPC: "2.0", ID: 1, Method: "tools/list"})
+	require.NoError(t, err)
+	require.Len(t, response.Result.(*ListToolsResult).Tools, 1)
+
+	response, err = server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      2,
+		Method:  "tools/call",
+		Params:  map[string]interface{}{"name": "get_policy"},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, response.Error)
+}
@@ -1,23 +1,104 @@
 package mcp
 
 import (
+	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"mime"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+
+	"github.com/fsnotify/fsnotify"
 )
 
-// ResourceProvider defines an interface for providing MCP resources
+// ErrResourceNotFound is returned by a provider for a URI it does not serve
+var ErrResourceNotFound = errors.New("resource not found")
+
+// ErrResourceAccessDenied is returned by a provider for a resource the session may not read
+var ErrResourceAccessDenied = errors.New("resource access denied")
+
+// resourceErrorCode returns the JSON-RPC error code for a failed resources/read or
+// resources/subscribe request
+func resourceErrorCode(err error) int {
+	switch {
+	case errors.Is(err, ErrResourceNotFound):
+		return ResourceNotFound
+	case errors.Is(err, ErrResourceAccessDenied):
+		return ResourceAccessDenied
+	case errors.Is(err, ErrNotInitialized), errors.Is(err, ErrSubscriptionsUnsupported):
+		return InvalidRequest
+	default:
+		return InternalError
+	}
+}
+
+// ResourceProvider defines an interface for providing MCP resources. The context carries the
+// session of the request, so providers can apply its access profile.
 type ResourceProvider interface {
 	// ListResources returns a list of available resources
-	ListResources() ([]Resource, error)
+	ListResources(ctx context.Context) ([]Resource, error)
+
+	// ListResourceTemplates returns the URI templates of resources that are read on demand
+	// rather than listed
+	ListResourceTemplates(ctx context.Context) ([]ResourceTemplate, error)
+
+	// ReadResource reads the content of a resource identified by URI, returning an error
+	// wrapping ErrResourceNotFound if the provider does not serve the URI
+	ReadResource(ctx context.Context, uri string) (*ReadResourceResult, error)
+}
+
+// MultiResourceProvider combines several providers into one. Resources and templates are
+// listed from every provider, and a URI is read from the first provider that serves it.
+type MultiResourceProvider struct {
+	providers []ResourceProvider
+}
+
+// NewMultiResourceProvider creates a provider that serves the resources of every provider
+func NewMultiResourceProvider(providers ...ResourceProvider) *MultiResourceProvider {
+	return &MultiResourceProvider{providers: providers}
+}
+
+// ListResources returns the resources of every provider
+func (m *MultiResourceProvider) ListResources(ctx context.Context) ([]Resource, error) {
+	var resources []Resource
+	for _, provider := range m.providers {
+		listed, err := provider.ListResources(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, listed...)
+	}
+	return resources, nil
+}
+
+// ListResourceTemplates returns the resource templates of every provider
+func (m *MultiResourceProvider) ListResourceTemplates(ctx context.Context) ([]ResourceTemplate, error) {
+	var templates []ResourceTemplate
+	for _, provider := range m.providers {
+		listed, err := provider.ListResourceTemplates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, listed...)
+	}
+	return templates, nil
+}
 
-	// ReadResource reads the content of a resource identified by URI
-	ReadResource(uri string) (*ReadResourceResult, error)
+// ReadResource reads a resource from the first provider that serves its URI
+func (m *MultiResourceProvider) ReadResource(ctx context.Context, uri string) (*ReadResourceResult, error) {
+	for _, provider := range m.providers {
+		result, err := provider.ReadResource(ctx, uri)
+		if errors.Is(err, ErrResourceNotFound) {
+			continue
+		}
+		return result, err
+	}
+	return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
 }
 
 // FileResourceProvider provides access to file system resources
@@ -88,8 +169,8 @@ func (p *FileResourceProvider) RegisterDirectory(uriPrefix, dirPath string) erro
 	})
 }
 
-// ListResources returns a list of registered resources
-func (p *FileResourceProvider) ListResources() ([]Resource, error) {
+// ListResources returns the registered resources sorted by URI
+func (p *FileResourceProvider) ListResources(ctx context.Context) ([]Resource, error) {
 	resources := make([]Resource, 0, len(p.resourceURIs))
 
 	for uri, path := range p.resourceURIs {
@@ -120,14 +201,20 @@ func (p *FileResourceProvider) ListResources() ([]Resource, error) {
 		})
 	}
 
+	sort.Slice(resources, func(i, j int) bool { return resources[i].URI < resources[j].URI })
 	return resources, nil
 }
 
+// ListResourceTemplates returns no templates, since every file is listed
+func (p *FileResourceProvider) ListResourceTemplates(ctx context.Context) ([]ResourceTemplate, error) {
+	return nil, nil
+}
+
 // ReadResource reads the content of a resource
-func (p *FileResourceProvider) ReadResource(uri string) (*ReadResourceResult, error) {
+func (p *FileResourceProvider) ReadResource(ctx context.Context, uri string) (*ReadResourceResult, error) {
 	path, ok := p.resourceURIs[uri]
 	if !ok {
-		return nil, fmt.Errorf("resource not found: %s", uri)
+		return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
 	}
 
 	file, err := os.Open(path)
@@ -197,6 +284,76 @@ func (p *FileResourceProvider) ReadResource(uri string) (*ReadResourceResult, er
 	}, nil
 }
 
+// Subscribe accepts subscriptions to registered files; changes are reported by Watch
+func (p *FileResourceProvider) Subscribe(ctx context.Context, uri string) error {
+	if _, ok := p.resourceURIs[uri]; !ok {
+		return fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
+	}
+	return nil
+}
+
+// Unsubscribe does nothing, since Watch reports changes to every registered file
+func (p *FileResourceProvider) Unsubscribe(uri string) {}
+
+// Watch watches the directories of the registered files until ctx is done and calls onUpdate
+// with the URI of each registered file that is written, replaced or removed. Files must be
+// registered before Watch is called.
+func (p *FileResourceProvider) Watch(ctx context.Context, onUpdate func(uri string)) error {
+	uris := make(map[string][]string, len(p.resourceURIs))
+	for uri, path := range p.resourceURIs {
+		path = filepath.Clean(path)
+		uris[path] = append(uris[path], uri)
+	}
+	if len(uris) == 0 {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	// Watching directories rather than files also catches editors that save by replacing
+	watched := make(map[string]bool)
+	for path := range uris {
+		dir := filepath.Dir(path)
+		if watched[dir] {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+		watched[dir] = true
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op == fsnotify.Chmod {
+					continue
+				}
+				for _, uri := range uris[filepath.Clean(event.Name)] {
+					onUpdate(uri)
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
 // Common resource URIs
 const (
 	ResourceURIPrefix = "file://"
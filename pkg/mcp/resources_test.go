@@ -1,8 +1,11 @@
 package mcp
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -44,7 +47,7 @@ func TestFileResourceProvider(t *testing.T) {
 		provider.RegisterResource("file:///test1.txt", filepath.Join(tempDir, "test1.txt"), "Test file 1")
 
 		// List resources
-		resources, err := provider.ListResources()
+		resources, err := provider.ListResources(context.Background())
 		require.NoError(t, err)
 
 		// Should have 1 resource
@@ -63,7 +66,7 @@ func TestFileResourceProvider(t *testing.T) {
 		require.NoError(t, err)
 
 		// List resources
-		resources, err := provider.ListResources()
+		resources, err := provider.ListResources(context.Background())
 		require.NoError(t, err)
 
 		// Should have 2 resources (from the subdirectory)
@@ -90,7 +93,7 @@ func TestFileResourceProvider(t *testing.T) {
 
 		// Test reading a text file
 		t.Run("TextFile", func(t *testing.T) {
-			result, err := provider.ReadResource("file:///test1.txt")
+			result, err := provider.ReadResource(context.Background(), "file:///test1.txt")
 			require.NoError(t, err)
 
 			assert.Len(t, result.Contents, 1)
@@ -102,7 +105,7 @@ func TestFileResourceProvider(t *testing.T) {
 
 		// Test reading a JSON file
 		t.Run("JSONFile", func(t *testing.T) {
-			result, err := provider.ReadResource("file:///test2.json")
+			result, err := provider.ReadResource(context.Background(), "file:///test2.json")
 			require.NoError(t, err)
 
 			assert.Len(t, result.Contents, 1)
@@ -113,7 +116,7 @@ func TestFileResourceProvider(t *testing.T) {
 
 		// Test reading a non-existent resource
 		t.Run("NonExistentResource", func(t *testing.T) {
-			_, err := provider.ReadResource("file:///non-existent.txt")
+			_, err := provider.ReadResource(context.Background(), "file:///non-existent.txt")
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), "resource not found")
 		})
@@ -156,3 +159,73 @@ func TestBuildResourceURI(t *testing.T) {
 		})
 	}
 }
+
+// templateProvider serves one resource template from memory for testing
+type templateProvider struct {
+	prefix string
+}
+
+func (p *templateProvider) ListResources(ctx context.Context) ([]Resource, error) {
+	return nil, nil
+}
+
+func (p *templateProvider) ListResourceTemplates(ctx context.Context) ([]ResourceTemplate, error) {
+	return []ResourceTemplate{{URITemplate: p.prefix + "{id}", Name: "Object"}}, nil
+}
+
+func (p *templateProvider) ReadResource(ctx context.Context, uri string) (*ReadResourceResult, error) {
+	if !strings.HasPrefix(uri, p.prefix) {
+		return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
+	}
+	return &ReadResourceResult{Contents: []ResourceContent{{URI: uri, Text: "object " + strings.TrimPrefix(uri, p.prefix)}}}, nil
+}
+
+// TestMultiResourceProvider tests that files and templated resources are served side by side
+func TestMultiResourceProvider(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0o644))
+	files := NewFileResourceProvider(dir)
+	files.RegisterResource("file:///notes.txt", filepath.Join(dir, "notes.txt"), "Notes")
+
+	server := NewServer("test-server", "1.0.0")
+	server.SetResourceProvider(NewMultiResourceProvider(files, &templateProvider{prefix: "jamf://policies/"}))
+	_, ctx := newInitializedSession(t, server)
+
+	response, err := server.HandleMessage(ctx, &Message{JSONRPC: "2.0", ID: 1, Method: "resources/list"})
+	require.NoError(t, err)
+	resources := response.Result.(*ListResourcesResult).Resources
+	require.Len(t, resources, 1)
+	assert.Equal(t, "file:///notes.txt", resources[0].URI)
+
+	response, err = server.HandleMessage(ctx, &Message{JSONRPC: "2.0", ID: 2, Method: "resources/templates/list"})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+	templates := response.Result.(*ListResourceTemplatesResult).ResourceTemplates
+	require.Len(t, templates, 1)
+	assert.Equal(t, "jamf://policies/{id}", templates[0].URITemplate)
+
+	for uri, text := range map[string]string{
+		"file:///notes.txt":  "notes",
+		"jamf://policies/12": "object 12",
+	} {
+		response, err := server.HandleMessage(ctx, &Message{
+			JSONRPC: "2.0",
+			ID:      3,
+			Method:  "resources/read",
+			Params:  map[string]interface{}{"uri": uri},
+		})
+		require.NoError(t, err)
+		require.Nil(t, response.Error, uri)
+		assert.Equal(t, text, response.Result.(*ReadResourceResult).Contents[0].Text, uri)
+	}
+
+	response, err = server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      4,
+		Method:  "resources/read",
+		Params:  map[string]interface{}{"uri": "jamf://scripts/1/contents"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, response.Error)
+	assert.Equal(t, ResourceNotFound, response.Error.Code)
+}
@@ -0,0 +1,238 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// outboxSize is the number of server-initiated messages queued for a session. Messages posted
+// while the queue is full are dropped.
+const outboxSize = 256
+
+// Session holds the per-client state of a single MCP connection
+type Session struct {
+	id string
+
+	mu          sync.RWMutex
+	clientInfo  ClientInfo
+	initialized bool
+	profile     *AccessProfile
+	pinned      bool
+	notifier    Notifier
+	notifierGen uint64
+	logLevel    LoggingLevel
+	inFlight    map[string]context.CancelFunc
+	done        chan struct{}
+	closeOnce   sync.Once
+
+	// outbox holds server-initiated messages until the delivery goroutine passes them to the
+	// notifier, so that a slow client never blocks the code that posts to it
+	outbox chan *Message
+}
+
+// newSession creates a session with the given ID
+func newSession(id string) *Session {
+	session := &Session{
+		id:       id,
+		inFlight: make(map[string]context.CancelFunc),
+		done:     make(chan struct{}),
+		outbox:   make(chan *Message, outboxSize),
+	}
+	go session.deliver()
+	return session
+}
+
+// ID returns the session identifier
+func (s *Session) ID() string {
+	return s.id
+}
+
+// ClientInfo returns the client information supplied during initialize
+func (s *Session) ClientInfo() ClientInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.clientInfo
+}
+
+// IsInitialized reports whether the client has completed the initialize handshake
+func (s *Session) IsInitialized() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.initialized
+}
+
+// markInitialized records the client information, assigns the access profile chosen for it
+// unless the transport pinned one, and flags the session as initialized. It reports false
+// and changes nothing if the session was already initialized.
+func (s *Session) markInitialized(clientInfo ClientInfo, profileFor func(ClientInfo) *AccessProfile) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.initialized {
+		return false
+	}
+
+	s.clientInfo = clientInfo
+	s.initialized = true
+
+	// Profiles pinned by the transport take precedence over the self-reported client name
+	if !s.pinned {
+		s.profile = profileFor(clientInfo)
+	}
+	return true
+}
+
+// AccessProfile returns the profile that limits this session, or nil if it is unrestricted
+func (s *Session) AccessProfile() *AccessProfile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.profile
+}
+
+// SetAccessProfile assigns a profile to the session and pins it so that the client name
+// sent during initialize cannot replace it. Transports call this for authenticated clients.
+func (s *Session) SetAccessProfile(profile *AccessProfile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profile = profile
+	s.pinned = true
+}
+
+// AccessProfilePinned reports whether the transport has assigned the session's profile
+func (s *Session) AccessProfilePinned() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.pinned
+}
+
+// Done returns a channel that is closed when the session is closed
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// close marks the session as closed
+func (s *Session) close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+// SetNotifier sets the channel used to deliver server-initiated messages to this session.
+// The returned function removes the notifier again unless it has since been replaced.
+func (s *Session) SetNotifier(n Notifier) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.notifierGen++
+	s.notifier = n
+
+	gen := s.notifierGen
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.notifierGen == gen {
+			s.notifier = nil
+		}
+	}
+}
+
+// getNotifier returns the session notifier, which may be nil
+func (s *Session) getNotifier() Notifier {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.notifier
+}
+
+// LogLevel returns the level chosen with logging/setLevel, or "" if the client has not
+// asked for log messages
+func (s *Session) LogLevel() LoggingLevel {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.logLevel
+}
+
+// setLogLevel records the level chosen by the client and returns the previous one
+func (s *Session) setLogLevel(level LoggingLevel) LoggingLevel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.logLevel
+	s.logLevel = level
+	return previous
+}
+
+// post queues a server-initiated message for the session notifier without blocking. It
+// reports false if the message was dropped because the client is not keeping up.
+func (s *Session) post(msg *Message) bool {
+	select {
+	case s.outbox <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// deliver passes posted messages to the notifier one at a time until the session is closed.
+// Messages posted while the session has no notifier are dropped.
+func (s *Session) deliver() {
+	for {
+		select {
+		case msg := <-s.outbox:
+			if notifier := s.getNotifier(); notifier != nil {
+				notifier(msg)
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// trackRequest registers the cancel function of an in-flight request and returns its key
+func (s *Session) trackRequest(id interface{}, cancel context.CancelFunc) string {
+	key := requestKey(id)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlight[key] = cancel
+	return key
+}
+
+// untrackRequest forgets a completed request
+func (s *Session) untrackRequest(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.inFlight, key)
+}
+
+// cancelRequest cancels the in-flight request with the given ID, reporting whether it was found
+func (s *Session) cancelRequest(id interface{}) bool {
+	s.mu.RLock()
+	cancel, ok := s.inFlight[requestKey(id)]
+	s.mu.RUnlock()
+
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+type sessionContextKey struct{}
+
+// ContextWithSession returns a copy of ctx carrying the given session
+func ContextWithSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, session)
+}
+
+// SessionFromContext returns the session carried by ctx, or nil if there is none
+func SessionFromContext(ctx context.Context) *Session {
+	session, _ := ctx.Value(sessionContextKey{}).(*Session)
+	return session
+}
+
+// newSessionID generates a cryptographically random session identifier
+func newSessionID() (string, error) {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	return hex.EncodeToString(buf[:]), nil
+}
@@ -0,0 +1,137 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSessionLifecycle tests creating, looking up and closing sessions
+func TestSessionLifecycle(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+
+	session, err := server.CreateSession()
+	require.NoError(t, err)
+	assert.NotEmpty(t, session.ID())
+
+	found, ok := server.GetSession(session.ID())
+	assert.True(t, ok)
+	assert.Same(t, session, found)
+
+	server.CloseSession(session.ID())
+	_, ok = server.GetSession(session.ID())
+	assert.False(t, ok)
+}
+
+// TestSessionInitializationIsPerSession tests that initialize only affects the calling session
+func TestSessionInitializationIsPerSession(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+
+	first, err := server.CreateSession()
+	require.NoError(t, err)
+	second, err := server.CreateSession()
+	require.NoError(t, err)
+
+	firstCtx := ContextWithSession(context.Background(), first)
+	secondCtx := ContextWithSession(context.Background(), second)
+
+	response, err := server.HandleMessage(firstCtx, &Message{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "initialize",
+		Params: map[string]interface{}{
+			"protocolVersion": "2024-11-05",
+			"clientInfo": map[string]interface{}{
+				"name":    "test-client",
+				"version": "0.1.0",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+
+	result, ok := response.Result.(*InitializeResult)
+	require.True(t, ok)
+	assert.Equal(t, "2024-11-05", result.ProtocolVersion)
+
+	assert.True(t, first.IsInitialized())
+	assert.Equal(t, "test-client", first.ClientInfo().Name)
+	assert.False(t, second.IsInitialized())
+
+	// The uninitialized session must not be able to list tools
+	response, err = server.HandleMessage(secondCtx, &Message{JSONRPC: "2.0", ID: 2, Method: "tools/list"})
+	require.NoError(t, err)
+	require.NotNil(t, response.Error)
+	assert.Contains(t, response.Error.Message, "not initialized")
+}
+
+// TestNegotiateProtocolVersion tests protocol version negotiation
+func TestNegotiateProtocolVersion(t *testing.T) {
+	assert.Equal(t, "2024-11-05", negotiateProtocolVersion("2024-11-05"))
+	assert.Equal(t, supportedProtocolVersions[0], negotiateProtocolVersion("1999-01-01"))
+	assert.Equal(t, supportedProtocolVersions[0], negotiateProtocolVersion(""))
+}
+
+// deliveryTimeout is how long tests wait for a queued message to reach the session notifier
+const deliveryTimeout = time.Second
+
+// recordMessages returns a channel that receives every message delivered to the session
+func recordMessages(session *Session) <-chan *Message {
+	messages := make(chan *Message, outboxSize)
+	session.SetNotifier(func(msg *Message) error {
+		messages <- msg
+		return nil
+	})
+	return messages
+}
+
+// receiveMessages reads the next n messages delivered to a session, failing if they do not
+// all arrive in time
+func receiveMessages(t *testing.T, messages <-chan *Message, n int) []*Message {
+	t.Helper()
+
+	received := make([]*Message, 0, n)
+	for len(received) < n {
+		select {
+		case msg := <-messages:
+			received = append(received, msg)
+		case <-time.After(deliveryTimeout):
+			require.FailNowf(t, "message not delivered", "received %d of %d messages", len(received), n)
+		}
+	}
+	return received
+}
+
+// assertNoMessages checks that nothing more is delivered to a session within a short wait
+func assertNoMessages(t *testing.T, messages <-chan *Message) {
+	t.Helper()
+
+	select {
+	case msg := <-messages:
+		assert.Failf(t, "unexpected message", "session was sent %s", msg.Method)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestCloseClosesEverySession tests that closing the server closes every session, including
+// the default one, which stops their delivery goroutines
+func TestCloseClosesEverySession(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	session, err := server.CreateSession()
+	require.NoError(t, err)
+
+	server.Close()
+
+	for _, closed := range []*Session{session, server.defaultSession} {
+		select {
+		case <-closed.Done():
+		default:
+			t.Fatalf("session %q was not closed", closed.ID())
+		}
+	}
+	_, ok := server.GetSession(session.ID())
+	assert.False(t, ok)
+}
@@ -0,0 +1,187 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// NotificationResourcesUpdated tells a subscribed client that a resource has changed
+const NotificationResourcesUpdated = "notifications/resources/updated"
+
+// ErrSubscriptionsUnsupported is returned for resources/subscribe when the resource provider
+// cannot watch resources, or a provider has subscriptions turned off
+var ErrSubscriptionsUnsupported = errors.New("resource subscriptions are not supported")
+
+// SubscribeParams represents the resources/subscribe and resources/unsubscribe parameters
+type SubscribeParams struct {
+	URI string `json:"uri"`
+}
+
+// ResourceUpdatedParams represents the notifications/resources/updated parameters
+type ResourceUpdatedParams struct {
+	URI string `json:"uri"`
+}
+
+// ResourceSubscriber is implemented by resource providers that can watch their resources for
+// changes. Subscribe is called each time a session subscribes to a URI and Unsubscribe each
+// time a session stops, so providers can count subscribers. Changes are reported with
+// Server.NotifyResourceUpdated, or Server.NotifySessionResourceUpdated for each session that
+// should hear about them.
+type ResourceSubscriber interface {
+	// Subscribe starts watching uri for the session in ctx, returning an error wrapping
+	// ErrResourceNotFound if the provider does not serve the URI
+	Subscribe(ctx context.Context, uri string) error
+
+	// Unsubscribe stops watching uri for one session
+	Unsubscribe(uri string)
+}
+
+// Subscribe routes a subscription to the first provider that serves the URI
+func (m *MultiResourceProvider) Subscribe(ctx context.Context, uri string) error {
+	for _, provider := range m.providers {
+		subscriber, ok := provider.(ResourceSubscriber)
+		if !ok {
+			continue
+		}
+		err := subscriber.Subscribe(ctx, uri)
+		if errors.Is(err, ErrResourceNotFound) {
+			continue
+		}
+		return err
+	}
+	return fmt.Errorf("%w: %s", ErrResourceNotFound, uri)
+}
+
+// Unsubscribe passes the unsubscription to every provider, which ignore URIs they do not serve
+func (m *MultiResourceProvider) Unsubscribe(uri string) {
+	for _, provider := range m.providers {
+		if subscriber, ok := provider.(ResourceSubscriber); ok {
+			subscriber.Unsubscribe(uri)
+		}
+	}
+}
+
+// handleSubscribe handles the resources/subscribe method
+func (s *Server) handleSubscribe(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	if !s.sessionFromContext(ctx).IsInitialized() {
+		return nil, ErrNotInitialized
+	}
+
+	subscriber, ok := s.resourceProvider.(ResourceSubscriber)
+	if !ok {
+		return nil, ErrSubscriptionsUnsupported
+	}
+
+	var subscribeParams SubscribeParams
+	if err := decodeParams(params, &subscribeParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subscribe params: %w", err)
+	}
+
+	session := s.sessionFromContext(ctx)
+
+	s.subscriptionsMu.Lock()
+	subscribed := s.subscriptions[subscribeParams.URI][session]
+	s.subscriptionsMu.Unlock()
+	if subscribed {
+		return map[string]interface{}{}, nil
+	}
+
+	// Providers may read the resource to check access, so the lock is not held meanwhile
+	if err := subscriber.Subscribe(ctx, subscribeParams.URI); err != nil {
+		return nil, err
+	}
+
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+
+	if s.subscriptions[subscribeParams.URI][session] {
+		// A concurrent request subscribed the session first
+		subscriber.Unsubscribe(subscribeParams.URI)
+		return map[string]interface{}{}, nil
+	}
+	if s.subscriptions[subscribeParams.URI] == nil {
+		s.subscriptions[subscribeParams.URI] = make(map[*Session]bool)
+	}
+	s.subscriptions[subscribeParams.URI][session] = true
+
+	return map[string]interface{}{}, nil
+}
+
+// handleUnsubscribe handles the resources/unsubscribe method
+func (s *Server) handleUnsubscribe(ctx context.Context, params interface{}) (map[string]interface{}, error) {
+	if !s.sessionFromContext(ctx).IsInitialized() {
+		return nil, ErrNotInitialized
+	}
+
+	var unsubscribeParams SubscribeParams
+	if err := decodeParams(params, &unsubscribeParams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal unsubscribe params: %w", err)
+	}
+
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+
+	s.removeSubscription(unsubscribeParams.URI, s.sessionFromContext(ctx))
+	return map[string]interface{}{}, nil
+}
+
+// removeSubscription drops one session's subscription to uri. Callers must hold
+// subscriptionsMu.
+func (s *Server) removeSubscription(uri string, session *Session) {
+	sessions := s.subscriptions[uri]
+	if !sessions[session] {
+		return
+	}
+
+	delete(sessions, session)
+	if len(sessions) == 0 {
+		delete(s.subscriptions, uri)
+	}
+	if subscriber, ok := s.resourceProvider.(ResourceSubscriber); ok {
+		subscriber.Unsubscribe(uri)
+	}
+}
+
+// removeSessionSubscriptions drops every subscription of a closed session
+func (s *Server) removeSessionSubscriptions(session *Session) {
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+
+	for uri := range s.subscriptions {
+		s.removeSubscription(uri, session)
+	}
+}
+
+// ResourceSubscribers returns the initialized sessions subscribed to uri
+func (s *Server) ResourceSubscribers(uri string) []*Session {
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+
+	sessions := make([]*Session, 0, len(s.subscriptions[uri]))
+	for session := range s.subscriptions[uri] {
+		if session.IsInitialized() {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// NotifyResourceUpdated tells every session subscribed to uri that the resource has changed.
+// It only queues the notifications and never waits for a client.
+func (s *Server) NotifyResourceUpdated(uri string) {
+	for _, session := range s.ResourceSubscribers(uri) {
+		s.NotifySessionResourceUpdated(session, uri)
+	}
+}
+
+// NotifySessionResourceUpdated tells one session that uri has changed, for providers whose
+// subscribers see a resource differently. Like NotifyResourceUpdated it only queues the
+// notification.
+func (s *Server) NotifySessionResourceUpdated(session *Session, uri string) {
+	session.post(&Message{
+		JSONRPC: "2.0",
+		Method:  NotificationResourcesUpdated,
+		Params:  &ResourceUpdatedParams{URI: uri},
+	})
+}
@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// subscribingProvider is a templateProvider that counts the subscribers of each URI
+type subscribingProvider struct {
+	templateProvider
+
+	mu          sync.Mutex
+	subscribers map[string]int
+}
+
+func newSubscribingProvider(prefix string) *subscribingProvider {
+	return &subscribingProvider{
+		templateProvider: templateProvider{prefix: prefix},
+		subscribers:      make(map[string]int),
+	}
+}
+
+func (p *subscribingProvider) Subscribe(ctx context.Context, uri string) error {
+	if _, err := p.ReadResource(ctx, uri); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subscribers[uri]++
+	return nil
+}
+
+func (p *subscribingProvider) Unsubscribe(uri string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.subscribers[uri]--; p.subscribers[uri] <= 0 {
+		delete(p.subscribers, uri)
+	}
+}
+
+func (p *subscribingProvider) count(uri string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.subscribers[uri]
+}
+
+// receiveUpdates reads the next n messages delivered to a session, which must be
+// resources/updated notifications, and returns their URIs
+func receiveUpdates(t *testing.T, messages <-chan *Message, n int) []string {
+	t.Helper()
+
+	uris := make([]string, 0, n)
+	for _, msg := range receiveMessages(t, messages, n) {
+		require.Equal(t, NotificationResourcesUpdated, msg.Method)
+		uris = append(uris, msg.Params.(*ResourceUpdatedParams).URI)
+	}
+	return uris
+}
+
+// subscribe sends resources/subscribe or resources/unsubscribe and returns the error response
+func subscribe(t *testing.T, ctx context.Context, server *Server, method, uri string) *Error {
+	t.Helper()
+
+	response, err := server.HandleMessage(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  map[string]interface{}{"uri": uri},
+	})
+	require.NoError(t, err)
+	return response.Error
+}
+
+// TestResourceSubscriptions tests that subscribed sessions, and only those, are notified of
+// changes and that providers see one subscriber per session
+func TestResourceSubscriptions(t *testing.T) {
+	provider := newSubscribingProvider("jamf://scripts/")
+	server := NewServer("test-server", "1.0.0")
+	server.SetResourceProvider(NewMultiResourceProvider(provider))
+	assert.True(t, server.capabilities.Resources.Subscribe)
+
+	first, firstCtx := newInitializedSession(t, server)
+	second, secondCtx := newInitializedSession(t, server)
+	_, otherCtx := newInitializedSession(t, server)
+	firstUpdates := recordMessages(first)
+	secondUpdates := recordMessages(second)
+
+	uri := "jamf://scripts/7"
+	require.Nil(t, subscribe(t, firstCtx, server, "resources/subscribe", uri))
+	require.Nil(t, subscribe(t, firstCtx, server, "resources/subscribe", uri))
+	require.Nil(t, subscribe(t, secondCtx, server, "resources/subscribe", uri))
+	require.Nil(t, subscribe(t, otherCtx, server, "resources/subscribe", "jamf://scripts/8"))
+	assert.Equal(t, 2, provider.count(uri))
+
+	server.NotifyResourceUpdated(uri)
+	assert.Equal(t, []string{uri}, receiveUpdates(t, firstUpdates, 1))
+	assert.Equal(t, []string{uri}, receiveUpdates(t, secondUpdates, 1))
+
+	require.Nil(t, subscribe(t, firstCtx, server, "resources/unsubscribe", uri))
+	assert.Equal(t, 1, provider.count(uri))
+
+	server.NotifyResourceUpdated(uri)
+	assert.Equal(t, []string{uri}, receiveUpdates(t, secondUpdates, 1))
+	assertNoMessages(t, firstUpdates)
+
+	// Closing a session drops its subscriptions
+	server.CloseSession(second.ID())
+	assert.Equal(t, 0, provider.count(uri))
+	assert.Equal(t, 1, provider.count("jamf://scripts/8"))
+}
+
+// TestSubscribeUnknownResource tests that subscribing to a URI no provider serves fails
+func TestSubscribeUnknownResource(t *testing.T) {
+	server := NewServer("test-server", "1.0.0")
+	server.SetResourceProvider(NewMultiResourceProvider(newSubscribingProvider("jamf://scripts/")))
+	_, ctx := newInitializedSession(t, server)
+
+	rpcErr := subscribe(t, ctx, server, "resources/subscribe", "jamf://policies/1")
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, ResourceNotFound, rpcErr.Code)
+}
+
+// failingSubscriber is a templateProvider whose subscriptions fail with err
+type failingSubscriber struct {
+	templateProvider
+	err error
+}
+
+func (p *failingSubscriber) Subscribe(ctx context.Context, uri string) error {
+	return p.err
+}
+
+func (p *failingSubscriber) Unsubscribe(uri string) {}
+
+// TestSubscribeErrorCodes tests that failed subscriptions are reported with a code that says
+// why rather than always as a missing resource
+func TestSubscribeErrorCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider ResourceProvider
+		code     int
+	}{
+		{name: "access denied", provider: &failingSubscriber{err: fmt.Errorf("%w: jamf://scripts/7", ErrResourceAccessDenied)}, code: ResourceAccessDenied},
+		{name: "subscriptions disabled", provider: &failingSubscriber{err: fmt.Errorf("%w: polling is off", ErrSubscriptionsUnsupported)}, code: InvalidRequest},
+		{name: "provider failure", provider: &failingSubscriber{err: errors.New("Jamf Pro is unavailable")}, code: InternalError},
+		{name: "no subscription support", provider: &templateProvider{prefix: "jamf://scripts/"}, code: InvalidRequest},
+	}
+
+	for _, tt := range tests {
+		server := NewServer("test-server", "1.0.0")
+		server.SetResourceProvider(tt.provider)
+		_, ctx := newInitializedSession(t, server)
+
+		rpcErr := subscribe(t, ctx, server, "resources/subscribe", "jamf://scripts/7")
+		if assert.NotNil(t, rpcErr, tt.name) {
+			assert.Equal(t, tt.code, rpcErr.Code, tt.name)
+		}
+	}
+
+	// Sessions must complete the handshake first
+	server := NewServer("test-server", "1.0.0")
+	server.SetResourceProvider(newSubscribingProvider("jamf://scripts/"))
+	session, err := server.CreateSession()
+	require.NoError(t, err)
+
+	rpcErr := subscribe(t, ContextWithSession(context.Background(), session), server, "resources/subscribe", "jamf://scripts/7")
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, InvalidRequest, rpcErr.Code)
+}
+
+// TestFileResourceProviderWatch tests that changes to a registered file are reported by URI
+func TestFileResourceProviderWatch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "script.sh")
+	require.NoError(t, os.WriteFile(path, []byte("echo one"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.sh"), []byte("echo other"), 0o644))
+
+	provider := NewFileResourceProvider(dir)
+	provider.RegisterResource("file:///script.sh", path, "Script")
+	require.NoError(t, provider.Subscribe(context.Background(), "file:///script.sh"))
+	assert.ErrorIs(t, provider.Subscribe(context.Background(), "file:///missing.sh"), ErrResourceNotFound)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates := make(chan string, 10)
+	require.NoError(t, provider.Watch(ctx, func(uri string) { updates <- uri }))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.sh"), []byte("echo changed"), 0o644))
+	require.NoError(t, os.WriteFile(path, []byte("echo two"), 0o644))
+
+	select {
+	case uri := <-updates:
+		assert.Equal(t, "file:///script.sh", uri)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no update reported for the changed file")
+	}
+}
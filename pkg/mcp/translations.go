@@ -0,0 +1,114 @@
+package mcp
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// TranslationsFile is the file that tool and parameter description overrides are read from
+const TranslationsFile = "jamfpro-mcp-server-config.json"
+
+// translationEnvPrefix prefixes translation keys set through environment variables
+const translationEnvPrefix = "JAMF_MCP_"
+
+// ToolDescriptionKey returns the translation key of a tool's description, such as
+// TOOL_GET_COMPUTERS_DESCRIPTION for get_computers
+func ToolDescriptionKey(toolName string) string {
+	return "TOOL_" + strings.ToUpper(toolName) + "_DESCRIPTION"
+}
+
+// ParameterDescriptionKey returns the translation key of a tool parameter's description, such
+// as TOOL_GET_COMPUTERS_PARAM_PAGE_SIZE_DESCRIPTION for page_size of get_computers
+func ParameterDescriptionKey(toolName, parameter string) string {
+	return "TOOL_" + strings.ToUpper(toolName) + "_PARAM_" + strings.ToUpper(parameter) + "_DESCRIPTION"
+}
+
+// ToolTranslations returns the descriptions of tools and their parameters keyed by translation
+// key, which is the format of the translations file
+func ToolTranslations(tools []Tool) map[string]string {
+	translations := make(map[string]string)
+	for _, tool := range tools {
+		translations[ToolDescriptionKey(tool.Name)] = tool.Description
+		for parameter, schema := range tool.InputSchema.Properties {
+			if description := parameterDescription(schema); description != "" {
+				translations[ParameterDescriptionKey(tool.Name, parameter)] = description
+			}
+		}
+	}
+	return translations
+}
+
+// LoadTranslations loads tool description overrides from the translations file and from
+// JAMF_MCP_TOOL_*_DESCRIPTION environment variables, which take precedence
+func LoadTranslations() map[string]string {
+	translations := make(map[string]string)
+
+	// Try to load from config file
+	if data, err := os.ReadFile(TranslationsFile); err == nil {
+		var configTranslations map[string]string
+		if err := json.Unmarshal(data, &configTranslations); err == nil {
+			for key, value := range configTranslations {
+				translations[key] = value
+			}
+		}
+	}
+
+	// Override with environment variables
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		key := strings.TrimPrefix(name, translationEnvPrefix)
+		if key == name || !strings.HasPrefix(key, "TOOL_") || !strings.HasSuffix(key, "_DESCRIPTION") || value == "" {
+			continue
+		}
+		translations[key] = value
+	}
+
+	return translations
+}
+
+// ApplyTranslations returns a copy of tool with its description and parameter descriptions
+// replaced by any matching translations
+func ApplyTranslations(tool Tool, translations map[string]string) Tool {
+	if description, ok := translations[ToolDescriptionKey(tool.Name)]; ok && description != "" {
+		tool.Description = description
+	}
+
+	var properties map[string]interface{}
+	for parameter, schema := range tool.InputSchema.Properties {
+		description, ok := translations[ParameterDescriptionKey(tool.Name, parameter)]
+		fields, isObject := schema.(map[string]interface{})
+		if !ok || description == "" || !isObject {
+			continue
+		}
+
+		// Copy before changing so the toolset's own definition is left alone
+		if properties == nil {
+			properties = make(map[string]interface{}, len(tool.InputSchema.Properties))
+			for key, value := range tool.InputSchema.Properties {
+				properties[key] = value
+			}
+		}
+		translated := make(map[string]interface{}, len(fields))
+		for key, value := range fields {
+			translated[key] = value
+		}
+		translated["description"] = description
+		properties[parameter] = translated
+	}
+	if properties != nil {
+		tool.InputSchema.Properties = properties
+	}
+
+	return tool
+}
+
+// parameterDescription returns the description in a parameter's schema, if any
+func parameterDescription(schema interface{}) string {
+	fields, ok := schema.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	description, _ := fields["description"].(string)
+	return description
+}
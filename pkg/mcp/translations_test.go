@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func translationTestTool() Tool {
+	return Tool{
+		Name:        "get_computers",
+		Description: "List computers",
+		InputSchema: ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"page_size": map[string]interface{}{
+					"type":        "integer",
+					"description": "Results per page",
+				},
+				"filter": map[string]interface{}{
+					"type": "string",
+				},
+			},
+		},
+	}
+}
+
+// TestToolTranslations tests that translation keys follow the registered tool and parameter names
+func TestToolTranslations(t *testing.T) {
+	translations := ToolTranslations([]Tool{translationTestTool()})
+
+	assert.Equal(t, map[string]string{
+		"TOOL_GET_COMPUTERS_DESCRIPTION":                 "List computers",
+		"TOOL_GET_COMPUTERS_PARAM_PAGE_SIZE_DESCRIPTION": "Results per page",
+	}, translations)
+}
+
+// TestApplyTranslations tests that overrides replace descriptions without changing the original tool
+func TestApplyTranslations(t *testing.T) {
+	tool := translationTestTool()
+
+	translated := ApplyTranslations(tool, map[string]string{
+		ToolDescriptionKey("get_computers"):                "List every managed Mac",
+		ParameterDescriptionKey("get_computers", "filter"): "RSQL filter",
+		ParameterDescriptionKey("get_other", "page_size"):  "Ignored",
+	})
+
+	assert.Equal(t, "List every managed Mac", translated.Description)
+	filter, ok := translated.InputSchema.Properties["filter"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "RSQL filter", filter["description"])
+	assert.Equal(t, "string", filter["type"])
+
+	pageSize, ok := translated.InputSchema.Properties["page_size"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "Results per page", pageSize["description"])
+
+	// The original definition is untouched
+	assert.Equal(t, "List computers", tool.Description)
+	_, hasDescription := tool.InputSchema.Properties["filter"].(map[string]interface{})["description"]
+	assert.False(t, hasDescription)
+}
+
+// TestLoadTranslationsFromEnvironment tests that environment variables override descriptions
+func TestLoadTranslationsFromEnvironment(t *testing.T) {
+	t.Setenv("JAMF_MCP_TOOL_GET_COMPUTERS_DESCRIPTION", "From the environment")
+	t.Setenv("JAMF_MCP_UNRELATED", "ignored")
+
+	translations := LoadTranslations()
+
+	assert.Equal(t, "From the environment", translations["TOOL_GET_COMPUTERS_DESCRIPTION"])
+	assert.NotContains(t, translations, "UNRELATED")
+}
@@ -8,7 +8,7 @@ import (
 	"strings"
 
 	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
-	"github.com/deploymenttheory/jamfpro-mcp-server/internal/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
 	"go.uber.org/zap"
 )
 
@@ -17,6 +17,17 @@ type ComputerInventoryToolset struct {
 	*BaseToolset
 }
 
+func init() {
+	Register(Registration{
+		Name:        "computer-inventory",
+		Description: "Computer inventory, FileVault, recovery lock and device commands through the Jamf Pro API",
+		Category:    CategoryDeviceManagement,
+		Constructor: func(client JamfProClient, logger *zap.Logger) Toolset {
+			return NewComputerInventoryToolset(client, logger)
+		},
+	})
+}
+
 // NewComputerInventoryToolset creates a new computer inventory toolset
 func NewComputerInventoryToolset(client JamfProClient, logger *zap.Logger) *ComputerInventoryToolset {
 	base := NewBaseToolset(
@@ -55,8 +66,11 @@ func (c *ComputerInventoryToolset) addTools() {
 func (c *ComputerInventoryToolset) addBasicInventoryTools() {
 	// Get Computers Inventory
 	c.AddTool(mcp.Tool{
-		Name:        "get_computers_inventory",
-		Description: "Retrieve computer inventory information for all computers with optional filtering, sorting, and section selection",
+		Name:         "get_computers_inventory",
+		Description:  "Retrieve computer inventory information for all computers with optional filtering, sorting, and section selection",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List Computer Inventory"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseComputerInventoryList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -102,8 +116,11 @@ func (c *ComputerInventoryToolset) addBasicInventoryTools() {
 
 	// Get Computer Inventory by ID
 	c.AddTool(mcp.Tool{
-		Name:        "get_computer_inventory_by_id",
-		Description: "Retrieve detailed inventory information for a specific computer by its ID",
+		Name:         "get_computer_inventory_by_id",
+		Description:  "Retrieve detailed inventory information for a specific computer by its ID",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Computer Inventory by ID"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceComputerInventory{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -118,8 +135,11 @@ func (c *ComputerInventoryToolset) addBasicInventoryTools() {
 
 	// Get Computer Inventory by Name
 	c.AddTool(mcp.Tool{
-		Name:        "get_computer_inventory_by_name",
-		Description: "Retrieve detailed inventory information for a specific computer by its name",
+		Name:         "get_computer_inventory_by_name",
+		Description:  "Retrieve detailed inventory information for a specific computer by its name",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Computer Inventory by Name"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceComputerInventory{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -134,8 +154,11 @@ func (c *ComputerInventoryToolset) addBasicInventoryTools() {
 
 	// Update Computer Inventory
 	c.AddTool(mcp.Tool{
-		Name:        "update_computer_inventory",
-		Description: "Update computer inventory information using PATCH method. Only specified fields will be updated.",
+		Name:         "update_computer_inventory",
+		Description:  "Update computer inventory information using PATCH method. Only specified fields will be updated.",
+		Access:       mcp.ToolAccessWrite,
+		Annotations:  mcp.UpdateAnnotations("Update Computer Inventory"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceComputerInventory{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -301,6 +324,8 @@ func (c *ComputerInventoryToolset) addBasicInventoryTools() {
 	c.AddTool(mcp.Tool{
 		Name:        "delete_computer_inventory",
 		Description: "Delete a computer's inventory information by its ID (removes computer from inventory)",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Delete Computer Inventory", true),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -312,14 +337,39 @@ func (c *ComputerInventoryToolset) addBasicInventoryTools() {
 			Required: []string{"id"},
 		},
 	})
+
+	// Delete Computers Inventory
+	c.AddTool(mcp.Tool{
+		Name:        "delete_computers_inventory",
+		Description: "Delete the inventory information of several computers by their IDs (removes the computers from inventory), stopping at the first failure",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Delete Computers Inventory", true),
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"ids": map[string]interface{}{
+					"type":        "array",
+					"description": "The IDs of the computers to delete from inventory",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+					"minItems": 1,
+				},
+			},
+			Required: []string{"ids"},
+		},
+	})
 }
 
 // addFileVaultTools adds FileVault-related tools
 func (c *ComputerInventoryToolset) addFileVaultTools() {
 	// Get Computers FileVault Inventory
 	c.AddTool(mcp.Tool{
-		Name:        "get_computers_filevault_inventory",
-		Description: "Retrieve FileVault encryption information for all computers",
+		Name:         "get_computers_filevault_inventory",
+		Description:  "Retrieve FileVault encryption information for all computers",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List FileVault Inventory"),
+		OutputSchema: OutputSchemaFor(&jamfpro.FileVaultInventoryList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -349,8 +399,11 @@ func (c *ComputerInventoryToolset) addFileVaultTools() {
 
 	// Get Computer FileVault Inventory by ID
 	c.AddTool(mcp.Tool{
-		Name:        "get_computer_filevault_inventory_by_id",
-		Description: "Retrieve FileVault encryption information for a specific computer by its ID",
+		Name:         "get_computer_filevault_inventory_by_id",
+		Description:  "Retrieve FileVault encryption information for a specific computer by its ID. The personal recovery key is masked unless reveal_secrets is set",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get FileVault Inventory by ID"),
+		OutputSchema: OutputSchemaFor(&jamfpro.FileVaultInventory{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -358,6 +411,7 @@ func (c *ComputerInventoryToolset) addFileVaultTools() {
 					"type":        "string",
 					"description": "The ID of the computer to retrieve FileVault information for",
 				},
+				RevealSecretsArgument: revealSecretsProperty(),
 			},
 			Required: []string{"id"},
 		},
@@ -365,8 +419,11 @@ func (c *ComputerInventoryToolset) addFileVaultTools() {
 
 	// Get Computer Recovery Lock Password
 	c.AddTool(mcp.Tool{
-		Name:        "get_computer_recovery_lock_password",
-		Description: "Retrieve the recovery lock password for a specific computer by its ID",
+		Name:         "get_computer_recovery_lock_password",
+		Description:  "Retrieve the recovery lock password for a specific computer by its ID. The password is masked unless reveal_secrets is set",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Recovery Lock Password"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseRecoveryLockPassword{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -374,6 +431,7 @@ func (c *ComputerInventoryToolset) addFileVaultTools() {
 					"type":        "string",
 					"description": "The ID of the computer to retrieve recovery lock password for",
 				},
+				RevealSecretsArgument: revealSecretsProperty(),
 			},
 			Required: []string{"id"},
 		},
@@ -386,6 +444,8 @@ func (c *ComputerInventoryToolset) addDeviceManagementTools() {
 	c.AddTool(mcp.Tool{
 		Name:        "remove_computer_mdm_profile",
 		Description: "Remove the MDM profile from a computer, effectively unenrolling it from management",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Remove Computer MDM Profile", false),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -402,6 +462,8 @@ func (c *ComputerInventoryToolset) addDeviceManagementTools() {
 	c.AddTool(mcp.Tool{
 		Name:        "erase_computer",
 		Description: "Erase a computer by sending a remote wipe command. This will completely wipe the device.",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Erase Computer", false),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -423,8 +485,11 @@ func (c *ComputerInventoryToolset) addDeviceManagementTools() {
 func (c *ComputerInventoryToolset) addAttachmentTools() {
 	// Upload Attachment to Computer
 	c.AddTool(mcp.Tool{
-		Name:        "upload_computer_attachment",
-		Description: "Upload a file attachment to a computer. API supports single file upload only.",
+		Name:         "upload_computer_attachment",
+		Description:  "Upload a file attachment to a computer. API supports single file upload only.",
+		Access:       mcp.ToolAccessWrite,
+		Annotations:  mcp.WriteAnnotations("Upload Computer Attachment", false),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseUploadAttachment{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -445,6 +510,8 @@ func (c *ComputerInventoryToolset) addAttachmentTools() {
 	c.AddTool(mcp.Tool{
 		Name:        "delete_computer_attachment",
 		Description: "Delete a specific attachment from a computer",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Delete Computer Attachment", true),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -462,9 +529,73 @@ func (c *ComputerInventoryToolset) addAttachmentTools() {
 	})
 }
 
+// PreviewTool describes the computers targeted by a destructive inventory tool
+func (c *ComputerInventoryToolset) PreviewTool(ctx context.Context, toolName string, arguments map[string]interface{}) (*ActionPreview, error) {
+	idArgument := "id"
+	switch toolName {
+	case "erase_computer", "remove_computer_mdm_profile", "delete_computer_inventory":
+	case "delete_computer_attachment":
+		idArgument = "computer_id"
+	case "delete_computers_inventory":
+		return c.previewComputers(toolName, arguments)
+	default:
+		return nil, nil
+	}
+
+	id, err := GetStringArgument(arguments, idArgument, true)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := c.previewComputer(id)
+	if err != nil {
+		return nil, err
+	}
+	preview.Action = toolName
+	preview.TargetType = "computer"
+	return preview, nil
+}
+
+// previewComputers describes every computer targeted by a bulk inventory tool
+func (c *ComputerInventoryToolset) previewComputers(toolName string, arguments map[string]interface{}) (*ActionPreview, error) {
+	ids, err := GetStringSliceArgument(arguments, "ids", true)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &ActionPreview{
+		Action:     toolName,
+		TargetType: "computers",
+		Targets:    make([]ActionPreview, 0, len(ids)),
+	}
+	for _, id := range ids {
+		target, err := c.previewComputer(id)
+		if err != nil {
+			return nil, err
+		}
+		preview.Targets = append(preview.Targets, *target)
+	}
+	return preview, nil
+}
+
+// previewComputer describes one computer by its inventory record
+func (c *ComputerInventoryToolset) previewComputer(id string) (*ActionPreview, error) {
+	inventory, err := c.GetClient().GetComputerInventoryByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get computer inventory for ID %s: %w", id, err)
+	}
+
+	return &ActionPreview{
+		ID:           id,
+		Name:         inventory.General.Name,
+		SerialNumber: inventory.Hardware.SerialNumber,
+		Username:     inventory.UserAndLocation.Username,
+	}, nil
+}
+
 // ExecuteTool executes a computer inventory-related tool
 func (c *ComputerInventoryToolset) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
-	c.GetLogger().Debug("Executing computer inventory tool", zap.String("tool", toolName))
+	c.SessionLogger(ctx).Debug("Executing computer inventory tool", zap.String("tool", toolName))
 
 	switch toolName {
 	// Basic inventory operations
@@ -478,6 +609,8 @@ func (c *ComputerInventoryToolset) ExecuteTool(ctx context.Context, toolName str
 		return c.updateComputerInventory(ctx, arguments)
 	case "delete_computer_inventory":
 		return c.deleteComputerInventory(ctx, arguments)
+	case "delete_computers_inventory":
+		return c.deleteComputersInventory(ctx, arguments)
 
 	// FileVault operations
 	case "get_computers_filevault_inventory":
@@ -532,16 +665,27 @@ func (c *ComputerInventoryToolset) getComputersInventory(ctx context.Context, ar
 		params.Set("section", strings.Join(sections, ","))
 	}
 
+	mcp.ReportProgress(ctx, 0, 2, "Requesting computers inventory from Jamf Pro")
+
 	inventory, err := c.GetClient().GetComputersInventory(params)
 	if err != nil {
 		return "", fmt.Errorf("failed to get computers inventory: %w", err)
 	}
 
-	response, err := FormatJSONResponse(inventory)
+	// Large inventory pulls can outlive the client's interest in them
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	mcp.ReportProgress(ctx, 1, 2, fmt.Sprintf("Formatting %d inventory records", inventory.TotalCount))
+
+	response, err := FormatJSONResponse(ctx, inventory)
 	if err != nil {
 		return "", err
 	}
 
+	mcp.ReportProgress(ctx, 2, 2, fmt.Sprintf("Formatted %d inventory records", inventory.TotalCount))
+
 	return fmt.Sprintf("Found %d computers in inventory:\n\n%s", inventory.TotalCount, response), nil
 }
 
@@ -556,7 +700,7 @@ func (c *ComputerInventoryToolset) getComputerInventoryByID(ctx context.Context,
 		return "", fmt.Errorf("failed to get computer inventory for ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(inventory)
+	response, err := FormatJSONResponse(ctx, inventory)
 	if err != nil {
 		return "", err
 	}
@@ -575,7 +719,7 @@ func (c *ComputerInventoryToolset) getComputerInventoryByName(ctx context.Contex
 		return "", fmt.Errorf("failed to get computer inventory for name %s: %w", name, err)
 	}
 
-	response, err := FormatJSONResponse(inventory)
+	response, err := FormatJSONResponse(ctx, inventory)
 	if err != nil {
 		return "", err
 	}
@@ -711,7 +855,7 @@ func (c *ComputerInventoryToolset) updateComputerInventory(ctx context.Context,
 		return "", fmt.Errorf("failed to update computer inventory for ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(result)
+	response, err := FormatJSONResponse(ctx, result)
 	if err != nil {
 		return "", err
 	}
@@ -733,6 +877,42 @@ func (c *ComputerInventoryToolset) deleteComputerInventory(ctx context.Context,
 	return fmt.Sprintf("Successfully deleted computer inventory for ID %s", id), nil
 }
 
+func (c *ComputerInventoryToolset) deleteComputersInventory(ctx context.Context, args map[string]interface{}) (string, error) {
+	ids, err := GetStringSliceArgument(args, "ids", true)
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", fmt.Errorf("argument ids must contain at least one ID")
+	}
+
+	total := float64(len(ids))
+	for i, id := range ids {
+		// Stop between deletions once the client is no longer waiting
+		if err := ctx.Err(); err != nil {
+			return "", fmt.Errorf("stopped %s: %w", deletedSoFar(ids[:i], len(ids)), err)
+		}
+
+		mcp.ReportProgress(ctx, float64(i), total, fmt.Sprintf("Deleting computer inventory for ID %s", id))
+
+		if err := c.GetClient().DeleteComputerInventoryByID(id); err != nil {
+			return "", fmt.Errorf("failed to delete computer inventory for ID %s %s: %w", id, deletedSoFar(ids[:i], len(ids)), err)
+		}
+	}
+
+	mcp.ReportProgress(ctx, total, total, fmt.Sprintf("Deleted %d computers from inventory", len(ids)))
+
+	return fmt.Sprintf("Successfully deleted computer inventory for IDs %s", strings.Join(ids, ", ")), nil
+}
+
+// deletedSoFar says how far a bulk delete got before stopping
+func deletedSoFar(deleted []string, total int) string {
+	if len(deleted) == 0 {
+		return fmt.Sprintf("after deleting 0 of %d", total)
+	}
+	return fmt.Sprintf("after deleting %d of %d (IDs %s)", len(deleted), total, strings.Join(deleted, ", "))
+}
+
 // Implementation of FileVault operations
 
 func (c *ComputerInventoryToolset) getComputersFileVaultInventory(ctx context.Context, args map[string]interface{}) (string, error) {
@@ -752,16 +932,27 @@ func (c *ComputerInventoryToolset) getComputersFileVaultInventory(ctx context.Co
 		params.Set("filter", filter)
 	}
 
+	mcp.ReportProgress(ctx, 0, 2, "Requesting computers FileVault inventory from Jamf Pro")
+
 	inventory, err := c.GetClient().GetComputersFileVaultInventory(params)
 	if err != nil {
 		return "", fmt.Errorf("failed to get computers FileVault inventory: %w", err)
 	}
 
-	response, err := FormatJSONResponse(inventory)
+	// Large inventory pulls can outlive the client's interest in them
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	mcp.ReportProgress(ctx, 1, 2, fmt.Sprintf("Formatting %d inventory records", inventory.TotalCount))
+
+	response, err := FormatJSONResponse(ctx, inventory)
 	if err != nil {
 		return "", err
 	}
 
+	mcp.ReportProgress(ctx, 2, 2, fmt.Sprintf("Formatted %d inventory records", inventory.TotalCount))
+
 	return fmt.Sprintf("Found %d computers with FileVault information:\n\n%s", inventory.TotalCount, response), nil
 }
 
@@ -776,7 +967,7 @@ func (c *ComputerInventoryToolset) getComputerFileVaultInventoryByID(ctx context
 		return "", fmt.Errorf("failed to get FileVault inventory for computer ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(inventory)
+	response, err := FormatSecretResponse(ctx, inventory, args)
 	if err != nil {
 		return "", err
 	}
@@ -795,7 +986,7 @@ func (c *ComputerInventoryToolset) getComputerRecoveryLockPassword(ctx context.C
 		return "", fmt.Errorf("failed to get recovery lock password for computer ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(password)
+	response, err := FormatSecretResponse(ctx, password, args)
 	if err != nil {
 		return "", err
 	}
@@ -816,7 +1007,7 @@ func (c *ComputerInventoryToolset) removeComputerMDMProfile(ctx context.Context,
 		return "", fmt.Errorf("failed to remove MDM profile for computer ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(result)
+	response, err := FormatJSONResponse(ctx, result)
 	if err != nil {
 		return "", err
 	}
@@ -862,7 +1053,7 @@ func (c *ComputerInventoryToolset) uploadComputerAttachment(ctx context.Context,
 		return "", fmt.Errorf("failed to upload attachment to computer ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(result)
+	response, err := FormatJSONResponse(ctx, result)
 	if err != nil {
 		return "", err
 	}
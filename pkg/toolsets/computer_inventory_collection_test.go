@@ -2,12 +2,15 @@ package toolsets
 
 import (
 	"context"
+	"errors"
 	"net/url"
 	"testing"
 
 	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -307,6 +310,7 @@ func TestNewComputerInventoryToolset(t *testing.T) {
 	assert.Contains(t, toolNames, "get_computer_inventory_by_name")
 	assert.Contains(t, toolNames, "update_computer_inventory")
 	assert.Contains(t, toolNames, "delete_computer_inventory")
+	assert.Contains(t, toolNames, "delete_computers_inventory")
 
 	// Verify FileVault tools
 	assert.Contains(t, toolNames, "get_computers_filevault_inventory")
@@ -561,3 +565,90 @@ func TestComputerInventoryMissingRequiredArgument(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "required argument")
 }
+
+// TestDeleteComputersInventoryReportsProgress tests that a bulk delete reports progress for each
+// computer and a final step when the client asks for it
+func TestDeleteComputersInventoryReportsProgress(t *testing.T) {
+	mockObj := createMockComputerInventoryClient()
+	toolset := NewComputerInventoryToolset(NewComputerInventoryClientAdapter(mockObj), zap.NewNop())
+	mockObj.On("DeleteComputerInventoryByID", "1").Return(nil)
+	mockObj.On("DeleteComputerInventoryByID", "2").Return(nil)
+
+	server := mcp.NewServer("test-server", "1.0.0")
+	session, err := server.CreateSession()
+	require.NoError(t, err)
+	ctx := mcp.ContextWithSession(context.Background(), session)
+	_, err = server.HandleMessage(ctx, &mcp.Message{JSONRPC: "2.0", ID: 0, Method: "initialize", Params: map[string]interface{}{}})
+	require.NoError(t, err)
+
+	server.RegisterToolDefinition(&mcp.Tool{Name: "delete_computers_inventory"})
+	server.RegisterTool("delete_computers_inventory", func(ctx context.Context, params mcp.CallToolParams) (*mcp.CallToolResult, error) {
+		result, err := toolset.ExecuteTool(ctx, params.Name, params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.CallToolResult{Content: []mcp.ToolContent{{Type: "text", Text: result}}}, nil
+	})
+
+	var progress []*mcp.ProgressParams
+	ctx = mcp.ContextWithNotifier(ctx, func(msg *mcp.Message) error {
+		progress = append(progress, msg.Params.(*mcp.ProgressParams))
+		return nil
+	})
+
+	response, err := server.HandleMessage(ctx, &mcp.Message{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "tools/call",
+		Params: map[string]interface{}{
+			"name":      "delete_computers_inventory",
+			"arguments": map[string]interface{}{"ids": []interface{}{"1", "2"}},
+			"_meta":     map[string]interface{}{"progressToken": "bulk"},
+		},
+	})
+	require.NoError(t, err)
+	require.Nil(t, response.Error)
+
+	require.Len(t, progress, 3)
+	for i, params := range progress {
+		assert.Equal(t, float64(i), params.Progress)
+		assert.Equal(t, float64(2), params.Total)
+	}
+	assert.Equal(t, "Deleted 2 computers from inventory", progress[2].Message)
+	mockObj.AssertExpectations(t)
+}
+
+// TestDeleteComputersInventoryStopsAtFailure tests that a bulk delete stops at the first
+// computer it cannot delete and says how far it got
+func TestDeleteComputersInventoryStopsAtFailure(t *testing.T) {
+	mockObj := createMockComputerInventoryClient()
+	toolset := NewComputerInventoryToolset(NewComputerInventoryClientAdapter(mockObj), zap.NewNop())
+	mockObj.On("DeleteComputerInventoryByID", "1").Return(nil)
+	mockObj.On("DeleteComputerInventoryByID", "2").Return(errors.New("not found"))
+
+	_, err := toolset.ExecuteTool(context.Background(), "delete_computers_inventory", map[string]interface{}{
+		"ids": []interface{}{"1", "2", "3"},
+	})
+
+	assert.EqualError(t, err, "failed to delete computer inventory for ID 2 after deleting 1 of 3 (IDs 1): not found")
+	mockObj.AssertNotCalled(t, "DeleteComputerInventoryByID", "3")
+}
+
+// TestDeleteComputersInventoryReportsCancellation tests that a bulk delete cancelled partway
+// says which computers it had already deleted
+func TestDeleteComputersInventoryReportsCancellation(t *testing.T) {
+	mockObj := createMockComputerInventoryClient()
+	toolset := NewComputerInventoryToolset(NewComputerInventoryClientAdapter(mockObj), zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockObj.On("DeleteComputerInventoryByID", "1").Return(nil)
+	mockObj.On("DeleteComputerInventoryByID", "2").Run(func(mock.Arguments) { cancel() }).Return(nil)
+
+	_, err := toolset.ExecuteTool(ctx, "delete_computers_inventory", map[string]interface{}{
+		"ids": []interface{}{"1", "2", "3"},
+	})
+
+	assert.EqualError(t, err, "stopped after deleting 2 of 3 (IDs 1, 2): context canceled")
+	assert.ErrorIs(t, err, context.Canceled)
+	mockObj.AssertNotCalled(t, "DeleteComputerInventoryByID", "3")
+}
@@ -3,9 +3,10 @@ package toolsets
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
-	"github.com/deploymenttheory/jamfpro-mcp-server/internal/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
 	"go.uber.org/zap"
 )
 
@@ -14,6 +15,17 @@ type ComputersToolset struct {
 	*BaseToolset
 }
 
+func init() {
+	Register(Registration{
+		Name:        "computers",
+		Description: "Computer records and computer groups through the Classic API",
+		Category:    CategoryDeviceManagement,
+		Constructor: func(client JamfProClient, logger *zap.Logger) Toolset {
+			return NewComputersToolset(client, logger)
+		},
+	})
+}
+
 // NewComputersToolset creates a new computers toolset
 func NewComputersToolset(client JamfProClient, logger *zap.Logger) *ComputersToolset {
 	base := NewBaseToolset(
@@ -37,8 +49,11 @@ func NewComputersToolset(client JamfProClient, logger *zap.Logger) *ComputersToo
 func (c *ComputersToolset) addTools() {
 	// Get Computers List
 	c.AddTool(mcp.Tool{
-		Name:        "get_computers",
-		Description: "Retrieve a list of all computers from Jamf Pro (returns basic info: ID and name only)",
+		Name:         "get_computers",
+		Description:  "Retrieve a list of all computers from Jamf Pro (returns basic info: ID and name only)",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List Computers"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseComputersList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
@@ -48,8 +63,11 @@ func (c *ComputersToolset) addTools() {
 
 	// Get Computer by ID (Full Details)
 	c.AddTool(mcp.Tool{
-		Name:        "get_computer_by_id",
-		Description: "Retrieve complete detailed information about a specific computer by its ID (includes all sections: general, location, purchasing, hardware, software, etc.)",
+		Name:         "get_computer_by_id",
+		Description:  "Retrieve complete detailed information about a specific computer by its ID (includes all sections: general, location, purchasing, hardware, software, etc.)",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Computer by ID"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseComputer{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -64,8 +82,11 @@ func (c *ComputersToolset) addTools() {
 
 	// Get Computer by Name (Full Details)
 	c.AddTool(mcp.Tool{
-		Name:        "get_computer_by_name",
-		Description: "Retrieve complete detailed information about a specific computer by its name (includes all sections: general, location, purchasing, hardware, software, etc.)",
+		Name:         "get_computer_by_name",
+		Description:  "Retrieve complete detailed information about a specific computer by its name (includes all sections: general, location, purchasing, hardware, software, etc.)",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Computer by Name"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseComputer{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -80,8 +101,11 @@ func (c *ComputersToolset) addTools() {
 
 	// Get Computer Groups
 	c.AddTool(mcp.Tool{
-		Name:        "get_computer_groups",
-		Description: "Retrieve a list of all computer groups from Jamf Pro",
+		Name:         "get_computer_groups",
+		Description:  "Retrieve a list of all computer groups from Jamf Pro",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List Computer Groups"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseComputerGroupsList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
@@ -91,8 +115,11 @@ func (c *ComputersToolset) addTools() {
 
 	// Get Computer Group by ID
 	c.AddTool(mcp.Tool{
-		Name:        "get_computer_group_by_id",
-		Description: "Retrieve detailed information about a specific computer group by its ID",
+		Name:         "get_computer_group_by_id",
+		Description:  "Retrieve detailed information about a specific computer group by its ID",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Computer Group by ID"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceComputerGroup{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -107,8 +134,11 @@ func (c *ComputersToolset) addTools() {
 
 	// Create Computer
 	c.AddTool(mcp.Tool{
-		Name:        "create_computer",
-		Description: "Create a new computer record in Jamf Pro",
+		Name:         "create_computer",
+		Description:  "Create a new computer record in Jamf Pro",
+		Access:       mcp.ToolAccessWrite,
+		Annotations:  mcp.WriteAnnotations("Create Computer", false),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseComputer{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -241,8 +271,11 @@ func (c *ComputersToolset) addTools() {
 
 	// Update Computer by ID
 	c.AddTool(mcp.Tool{
-		Name:        "update_computer_by_id",
-		Description: "Update computer information by ID. Can update general info, location, purchasing, and other details",
+		Name:         "update_computer_by_id",
+		Description:  "Update computer information by ID. Can update general info, location, purchasing, and other details",
+		Access:       mcp.ToolAccessWrite,
+		Annotations:  mcp.UpdateAnnotations("Update Computer by ID"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseComputer{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -367,8 +400,11 @@ func (c *ComputersToolset) addTools() {
 
 	// Update Computer by Name
 	c.AddTool(mcp.Tool{
-		Name:        "update_computer_by_name",
-		Description: "Update computer information by name. Can update general info, location, purchasing, and other details",
+		Name:         "update_computer_by_name",
+		Description:  "Update computer information by name. Can update general info, location, purchasing, and other details",
+		Access:       mcp.ToolAccessWrite,
+		Annotations:  mcp.UpdateAnnotations("Update Computer by Name"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseComputer{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -495,6 +531,8 @@ func (c *ComputersToolset) addTools() {
 	c.AddTool(mcp.Tool{
 		Name:        "delete_computer_by_id",
 		Description: "Delete a computer from Jamf Pro by its ID",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Delete Computer by ID", true),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -511,6 +549,8 @@ func (c *ComputersToolset) addTools() {
 	c.AddTool(mcp.Tool{
 		Name:        "delete_computer_by_name",
 		Description: "Delete a computer from Jamf Pro by its name",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Delete Computer by Name", true),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -525,8 +565,11 @@ func (c *ComputersToolset) addTools() {
 
 	// Get Computer Template
 	c.AddTool(mcp.Tool{
-		Name:        "get_computer_template",
-		Description: "Get a reference template for a computer resource showing all available fields",
+		Name:         "get_computer_template",
+		Description:  "Get a reference template for a computer resource showing all available fields",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Computer Template"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseComputer{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
@@ -535,9 +578,47 @@ func (c *ComputersToolset) addTools() {
 	})
 }
 
+// PreviewTool describes the computer targeted by a destructive computer tool
+func (c *ComputersToolset) PreviewTool(ctx context.Context, toolName string, arguments map[string]interface{}) (*ActionPreview, error) {
+	var (
+		computer *jamfpro.ResponseComputer
+		err      error
+	)
+
+	switch toolName {
+	case "delete_computer_by_id":
+		id, argErr := GetStringArgument(arguments, "id", true)
+		if argErr != nil {
+			return nil, argErr
+		}
+		computer, err = c.GetClient().GetComputerByID(id)
+	case "delete_computer_by_name":
+		name, argErr := GetStringArgument(arguments, "name", true)
+		if argErr != nil {
+			return nil, argErr
+		}
+		computer, err = c.GetClient().GetComputerByName(name)
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get computer: %w", err)
+	}
+
+	return &ActionPreview{
+		Action:       toolName,
+		TargetType:   "computer",
+		ID:           strconv.Itoa(computer.General.ID),
+		Name:         computer.General.Name,
+		SerialNumber: computer.General.SerialNumber,
+		Username:     computer.Location.Username,
+	}, nil
+}
+
 // ExecuteTool executes a computer-related tool
 func (c *ComputersToolset) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
-	c.GetLogger().Debug("Executing computers tool", zap.String("tool", toolName))
+	c.SessionLogger(ctx).Debug("Executing computers tool", zap.String("tool", toolName))
 
 	switch toolName {
 	case "get_computers":
@@ -576,7 +657,7 @@ func (c *ComputersToolset) getComputers(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("failed to get computers: %w", err)
 	}
 
-	response, err := FormatJSONResponse(computers)
+	response, err := FormatJSONResponse(ctx, computers)
 	if err != nil {
 		return "", err
 	}
@@ -596,7 +677,7 @@ func (c *ComputersToolset) getComputerByID(ctx context.Context, args map[string]
 		return "", fmt.Errorf("failed to get computer with ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(computer)
+	response, err := FormatJSONResponse(ctx, computer)
 	if err != nil {
 		return "", err
 	}
@@ -616,7 +697,7 @@ func (c *ComputersToolset) getComputerByName(ctx context.Context, args map[strin
 		return "", fmt.Errorf("failed to get computer with name %s: %w", name, err)
 	}
 
-	response, err := FormatJSONResponse(computer)
+	response, err := FormatJSONResponse(ctx, computer)
 	if err != nil {
 		return "", err
 	}
@@ -631,7 +712,7 @@ func (c *ComputersToolset) getComputerGroups(ctx context.Context) (string, error
 		return "", fmt.Errorf("failed to get computer groups: %w", err)
 	}
 
-	response, err := FormatJSONResponse(groups)
+	response, err := FormatJSONResponse(ctx, groups)
 	if err != nil {
 		return "", err
 	}
@@ -651,7 +732,7 @@ func (c *ComputersToolset) getComputerGroupByID(ctx context.Context, args map[st
 		return "", fmt.Errorf("failed to get computer group with ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(group)
+	response, err := FormatJSONResponse(ctx, group)
 	if err != nil {
 		return "", err
 	}
@@ -771,7 +852,7 @@ func (c *ComputersToolset) createComputer(ctx context.Context, args map[string]i
 		return "", fmt.Errorf("failed to create computer '%s': %w", name, err)
 	}
 
-	response, err := FormatJSONResponse(result)
+	response, err := FormatJSONResponse(ctx, result)
 	if err != nil {
 		return "", err
 	}
@@ -893,7 +974,7 @@ func (c *ComputersToolset) updateComputerByID(ctx context.Context, args map[stri
 		return "", fmt.Errorf("failed to update computer with ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(result)
+	response, err := FormatJSONResponse(ctx, result)
 	if err != nil {
 		return "", err
 	}
@@ -1015,7 +1096,7 @@ func (c *ComputersToolset) updateComputerByName(ctx context.Context, args map[st
 		return "", fmt.Errorf("failed to update computer with name %s: %w", name, err)
 	}
 
-	response, err := FormatJSONResponse(result)
+	response, err := FormatJSONResponse(ctx, result)
 	if err != nil {
 		return "", err
 	}
@@ -1148,7 +1229,7 @@ func (c *ComputersToolset) GetComputerTemplate() *jamfpro.ResponseComputer {
 
 func (c *ComputersToolset) getComputerTemplate(ctx context.Context) (string, error) {
 	template := c.GetComputerTemplate()
-	response, err := FormatJSONResponse(template)
+	response, err := FormatJSONResponse(ctx, template)
 	if err != nil {
 		return "", fmt.Errorf("failed to format computer template: %w", err)
 	}
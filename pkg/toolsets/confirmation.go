@@ -0,0 +1,269 @@
+package toolsets
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+)
+
+// ConfirmationTokenArgument is the argument a client passes to confirm a destructive action
+const ConfirmationTokenArgument = "confirmation_token"
+
+// ActionPreview describes the object a destructive tool is about to act on. Tools acting on
+// several objects describe each of them in Targets.
+type ActionPreview struct {
+	Action       string          `json:"action,omitempty"`
+	TargetType   string          `json:"target_type,omitempty"`
+	ID           string          `json:"id,omitempty"`
+	Name         string          `json:"name,omitempty"`
+	SerialNumber string          `json:"serial_number,omitempty"`
+	Username     string          `json:"username,omitempty"`
+	Targets      []ActionPreview `json:"targets,omitempty"`
+}
+
+// Previewer is implemented by toolsets that can describe the target of a destructive tool
+// before it runs. PreviewTool returns nil for tools it has no preview for.
+type Previewer interface {
+	PreviewTool(ctx context.Context, toolName string, arguments map[string]interface{}) (*ActionPreview, error)
+}
+
+// pendingConfirmation is an issued token and the exact call it authorises
+type pendingConfirmation struct {
+	binding string
+	expires time.Time
+}
+
+// ConfirmationStore issues short-lived, single-use tokens that authorise one specific call
+type ConfirmationStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]pendingConfirmation
+}
+
+// NewConfirmationStore creates a store whose tokens expire after ttl
+func NewConfirmationStore(ttl time.Duration) *ConfirmationStore {
+	return &ConfirmationStore{
+		ttl:     ttl,
+		now:     time.Now,
+		pending: make(map[string]pendingConfirmation),
+	}
+}
+
+// TTL returns how long issued tokens remain valid
+func (c *ConfirmationStore) TTL() time.Duration {
+	return c.ttl
+}
+
+// Issue creates a token for the given call
+func (c *ConfirmationStore) Issue(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	binding, err := confirmationBinding(ctx, toolName, arguments)
+	if err != nil {
+		return "", err
+	}
+
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", fmt.Errorf("failed to generate confirmation token: %w", err)
+	}
+	token := hex.EncodeToString(buf[:])
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expireLocked()
+	c.pending[token] = pendingConfirmation{
+		binding: binding,
+		expires: c.now().Add(c.ttl),
+	}
+
+	return token, nil
+}
+
+// Redeem consumes a token, failing if it is unknown, expired or was issued for a different call
+func (c *ConfirmationStore) Redeem(ctx context.Context, token, toolName string, arguments map[string]interface{}) error {
+	binding, err := confirmationBinding(ctx, toolName, arguments)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expireLocked()
+
+	pending, ok := c.pending[token]
+	if !ok {
+		return fmt.Errorf("confirmation token is invalid or has expired; call %s again without a token to get a new one", toolName)
+	}
+
+	// Tokens are single use, even when they do not match
+	delete(c.pending, token)
+
+	if pending.binding != binding {
+		return fmt.Errorf("confirmation token was issued for a different call; call %s again without a token to get a new one", toolName)
+	}
+
+	return nil
+}
+
+// expireLocked drops expired tokens. Callers must hold mu.
+func (c *ConfirmationStore) expireLocked() {
+	now := c.now()
+	for token, pending := range c.pending {
+		if now.After(pending.expires) {
+			delete(c.pending, token)
+		}
+	}
+}
+
+// confirmationBinding identifies a call by session, tool and arguments so a token cannot be
+// replayed against another device or by another client
+func confirmationBinding(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	sessionID := ""
+	if session := mcp.SessionFromContext(ctx); session != nil {
+		sessionID = session.ID()
+	}
+
+	// encoding/json sorts map keys, which makes the encoding canonical
+	data, err := json.Marshal(withoutConfirmationToken(arguments))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode arguments: %w", err)
+	}
+
+	return sessionID + "\x00" + toolName + "\x00" + string(data), nil
+}
+
+// withoutConfirmationToken returns a copy of arguments without the confirmation token
+func withoutConfirmationToken(arguments map[string]interface{}) map[string]interface{} {
+	stripped := make(map[string]interface{}, len(arguments))
+	for key, value := range arguments {
+		if key != ConfirmationTokenArgument {
+			stripped[key] = value
+		}
+	}
+	return stripped
+}
+
+// ConfirmingToolset wraps a toolset so that its destructive tools run in two phases. The
+// first call returns a preview of the target and a confirmation token; only a second call
+// with the same arguments and that token performs the action.
+type ConfirmingToolset struct {
+	Toolset
+	store *ConfirmationStore
+}
+
+// ConfirmationNote is appended to the description of tools that need confirmation
+const ConfirmationNote = " Requires confirmation: the first call returns a preview and a confirmation_token that must be passed to a second call."
+
+// WithConfirmationNote appends ConfirmationNote to a tool description
+func WithConfirmationNote(description string) string {
+	return strings.TrimSuffix(description, ".") + "." + ConfirmationNote
+}
+
+// NewConfirmingToolset wraps toolset with the confirmation flow
+func NewConfirmingToolset(toolset Toolset, store *ConfirmationStore) *ConfirmingToolset {
+	return &ConfirmingToolset{
+		Toolset: toolset,
+		store:   store,
+	}
+}
+
+// GetTools returns the wrapped tools, adding the confirmation_token argument to destructive ones
+func (c *ConfirmingToolset) GetTools() []mcp.Tool {
+	tools := c.Toolset.GetTools()
+	for i, tool := range tools {
+		if tool.Access != mcp.ToolAccessDestructive {
+			continue
+		}
+
+		properties := make(map[string]interface{}, len(tool.InputSchema.Properties)+1)
+		for key, value := range tool.InputSchema.Properties {
+			properties[key] = value
+		}
+		properties[ConfirmationTokenArgument] = map[string]interface{}{
+			"type":        "string",
+			"description": "Token returned by a previous call with the same arguments. Omit it to receive a preview of the target and a token.",
+		}
+
+		tool.InputSchema.Properties = properties
+		tool.Description = WithConfirmationNote(tool.Description)
+		tools[i] = tool
+	}
+	return tools
+}
+
+// ExecuteTool runs non-destructive tools directly and destructive ones through the confirmation flow
+func (c *ConfirmingToolset) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	if !c.isDestructive(toolName) {
+		return c.Toolset.ExecuteTool(ctx, toolName, arguments)
+	}
+
+	token, err := GetStringArgument(arguments, ConfirmationTokenArgument, false)
+	if err != nil {
+		return "", err
+	}
+
+	if token == "" {
+		return c.requestConfirmation(ctx, toolName, arguments)
+	}
+
+	if err := c.store.Redeem(ctx, token, toolName, arguments); err != nil {
+		return "", err
+	}
+
+	return c.Toolset.ExecuteTool(ctx, toolName, withoutConfirmationToken(arguments))
+}
+
+// requestConfirmation previews the target of a destructive call and issues a token for it
+func (c *ConfirmingToolset) requestConfirmation(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	var preview *ActionPreview
+	if previewer, ok := c.Toolset.(Previewer); ok {
+		var err error
+		preview, err = previewer.PreviewTool(ctx, toolName, arguments)
+		if err != nil {
+			return "", fmt.Errorf("failed to preview %s: %w", toolName, err)
+		}
+	}
+
+	token, err := c.store.Issue(ctx, toolName, arguments)
+	if err != nil {
+		return "", err
+	}
+
+	var target interface{} = withoutConfirmationToken(arguments)
+	if preview != nil {
+		target = preview
+	}
+
+	// The preview is not the tool's result, so it is not recorded as structured output
+	previewCtx := context.Background()
+	if redacting, ok := c.Toolset.(*redactingToolset); ok {
+		previewCtx = ContextWithRedaction(previewCtx, redacting.redaction)
+	}
+	response, err := FormatJSONResponse(previewCtx, target)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Confirmation required. %s has not been run. Review the target below, then call %s again with the same arguments and %s set to %q within %s to proceed.\n\n%s",
+		toolName, toolName, ConfirmationTokenArgument, token, c.store.TTL(), response), nil
+}
+
+// isDestructive reports whether the wrapped toolset classifies toolName as destructive
+func (c *ConfirmingToolset) isDestructive(toolName string) bool {
+	for _, tool := range c.Toolset.GetTools() {
+		if tool.Name == toolName {
+			return tool.Access == mcp.ToolAccessDestructive
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package toolsets
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// confirmationTokenPattern extracts the token from a confirmation prompt
+var confirmationTokenPattern = regexp.MustCompile(`confirmation_token set to "([0-9a-f]+)"`)
+
+// newConfirmingInventoryToolset creates a confirming computer inventory toolset backed by a mock
+func newConfirmingInventoryToolset(store *ConfirmationStore) (*ConfirmingToolset, *mock.Mock) {
+	mockObj := createMockComputerInventoryClient()
+	toolset := NewComputerInventoryToolset(NewComputerInventoryClientAdapter(mockObj), zap.NewNop())
+
+	mockObj.On("GetComputerInventoryByID", "7").Return(&jamfpro.ResourceComputerInventory{
+		ID:              "7",
+		General:         jamfpro.ComputerInventorySubsetGeneral{Name: "Finance-MBP"},
+		Hardware:        jamfpro.ComputerInventorySubsetHardware{SerialNumber: "C02XYZ123"},
+		UserAndLocation: jamfpro.ComputerInventorySubsetUserAndLocation{Username: "jdoe"},
+	}, nil)
+
+	return NewConfirmingToolset(toolset, store), mockObj
+}
+
+// extractConfirmationToken returns the token embedded in a confirmation prompt
+func extractConfirmationToken(t *testing.T, result string) string {
+	t.Helper()
+	match := confirmationTokenPattern.FindStringSubmatch(result)
+	require.Len(t, match, 2, "no confirmation token in %q", result)
+	return match[1]
+}
+
+// TestConfirmingToolsetTwoPhaseErase tests that erase_computer only runs once confirmed
+func TestConfirmingToolsetTwoPhaseErase(t *testing.T) {
+	toolset, mockObj := newConfirmingInventoryToolset(NewConfirmationStore(time.Minute))
+	mockObj.On("EraseComputerByID", "7", jamfpro.RequestEraseDeviceComputer{}).Return(nil)
+
+	ctx := context.Background()
+	args := map[string]interface{}{"id": "7"}
+
+	// The first call only previews the target
+	result, err := toolset.ExecuteTool(ctx, "erase_computer", args)
+	require.NoError(t, err)
+	assert.Contains(t, result, "Confirmation required")
+	assert.Contains(t, result, "Finance-MBP")
+	assert.Contains(t, result, "C02XYZ123")
+	assert.Contains(t, result, "jdoe")
+	mockObj.AssertNotCalled(t, "EraseComputerByID", mock.Anything, mock.Anything)
+
+	token := extractConfirmationToken(t, result)
+
+	result, err = toolset.ExecuteTool(ctx, "erase_computer", map[string]interface{}{"id": "7", ConfirmationTokenArgument: token})
+	require.NoError(t, err)
+	assert.Contains(t, result, "Successfully initiated erase command for computer ID 7")
+	mockObj.AssertNumberOfCalls(t, "EraseComputerByID", 1)
+
+	// Tokens are single use
+	_, err = toolset.ExecuteTool(ctx, "erase_computer", map[string]interface{}{"id": "7", ConfirmationTokenArgument: token})
+	assert.ErrorContains(t, err, "invalid or has expired")
+	mockObj.AssertNumberOfCalls(t, "EraseComputerByID", 1)
+}
+
+// TestConfirmingToolsetPreviewsBulkDelete tests that confirming a bulk delete lists the name,
+// serial number and user of every computer it would delete
+func TestConfirmingToolsetPreviewsBulkDelete(t *testing.T) {
+	toolset, mockObj := newConfirmingInventoryToolset(NewConfirmationStore(time.Minute))
+	mockObj.On("GetComputerInventoryByID", "8").Return(&jamfpro.ResourceComputerInventory{
+		ID:              "8",
+		General:         jamfpro.ComputerInventorySubsetGeneral{Name: "Legal-MBA"},
+		Hardware:        jamfpro.ComputerInventorySubsetHardware{SerialNumber: "C02ABC456"},
+		UserAndLocation: jamfpro.ComputerInventorySubsetUserAndLocation{Username: "asmith"},
+	}, nil)
+
+	result, err := toolset.ExecuteTool(context.Background(), "delete_computers_inventory", map[string]interface{}{
+		"ids": []interface{}{"7", "8"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, result, "Confirmation required")
+	for _, detail := range []string{"Finance-MBP", "C02XYZ123", "jdoe", "Legal-MBA", "C02ABC456", "asmith"} {
+		assert.Contains(t, result, detail)
+	}
+	mockObj.AssertNotCalled(t, "DeleteComputerInventoryByID", mock.Anything)
+}
+
+// TestConfirmingToolsetRejectsMismatchedCalls tests that a token only authorises the call it was issued for
+func TestConfirmingToolsetRejectsMismatchedCalls(t *testing.T) {
+	toolset, mockObj := newConfirmingInventoryToolset(NewConfirmationStore(time.Minute))
+	mockObj.On("GetComputerInventoryByID", "8").Return(&jamfpro.ResourceComputerInventory{ID: "8"}, nil)
+
+	server := mcp.NewServer("test-server", "1.0.0")
+	firstSession, err := server.CreateSession()
+	require.NoError(t, err)
+	secondSession, err := server.CreateSession()
+	require.NoError(t, err)
+
+	first := mcp.ContextWithSession(context.Background(), firstSession)
+	second := mcp.ContextWithSession(context.Background(), secondSession)
+
+	result, err := toolset.ExecuteTool(first, "erase_computer", map[string]interface{}{"id": "7"})
+	require.NoError(t, err)
+	token := extractConfirmationToken(t, result)
+
+	// A different target
+	_, err = toolset.ExecuteTool(first, "erase_computer", map[string]interface{}{"id": "8", ConfirmationTokenArgument: token})
+	assert.ErrorContains(t, err, "different call")
+
+	// A different tool
+	result, err = toolset.ExecuteTool(first, "erase_computer", map[string]interface{}{"id": "7"})
+	require.NoError(t, err)
+	token = extractConfirmationToken(t, result)
+	_, err = toolset.ExecuteTool(first, "remove_computer_mdm_profile", map[string]interface{}{"id": "7", ConfirmationTokenArgument: token})
+	assert.ErrorContains(t, err, "different call")
+
+	// A different session
+	result, err = toolset.ExecuteTool(first, "erase_computer", map[string]interface{}{"id": "7"})
+	require.NoError(t, err)
+	token = extractConfirmationToken(t, result)
+	_, err = toolset.ExecuteTool(second, "erase_computer", map[string]interface{}{"id": "7", ConfirmationTokenArgument: token})
+	assert.ErrorContains(t, err, "different call")
+
+	mockObj.AssertNotCalled(t, "EraseComputerByID", mock.Anything, mock.Anything)
+	mockObj.AssertNotCalled(t, "RemoveComputerMDMProfile", mock.Anything)
+}
+
+// TestConfirmationStoreExpiry tests that tokens expire after the TTL
+func TestConfirmationStoreExpiry(t *testing.T) {
+	store := NewConfirmationStore(time.Minute)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	args := map[string]interface{}{"id": "7"}
+
+	token, err := store.Issue(ctx, "erase_computer", args)
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+	assert.ErrorContains(t, store.Redeem(ctx, token, "erase_computer", args), "expired")
+}
+
+// TestConfirmingToolsetTools tests that only destructive tools gain the confirmation argument
+func TestConfirmingToolsetTools(t *testing.T) {
+	toolset, mockObj := newConfirmingInventoryToolset(NewConfirmationStore(time.Minute))
+
+	for _, tool := range toolset.GetTools() {
+		_, hasToken := tool.InputSchema.Properties[ConfirmationTokenArgument]
+		assert.Equal(t, tool.Access == mcp.ToolAccessDestructive, hasToken, tool.Name)
+		assert.NotContains(t, tool.InputSchema.Required, ConfirmationTokenArgument, tool.Name)
+	}
+
+	// The wrapped toolset's schemas are left untouched
+	for _, tool := range toolset.Toolset.GetTools() {
+		assert.NotContains(t, tool.InputSchema.Properties, ConfirmationTokenArgument, tool.Name)
+	}
+
+	// Non-destructive tools run immediately
+	result, err := toolset.ExecuteTool(context.Background(), "get_computer_inventory_by_id", map[string]interface{}{"id": "7"})
+	require.NoError(t, err)
+	assert.Contains(t, result, "Finance-MBP")
+	mockObj.AssertExpectations(t)
+}
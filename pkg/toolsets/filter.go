@@ -0,0 +1,72 @@
+package toolsets
+
+import (
+	"fmt"
+	"path"
+)
+
+// ToolFilter decides which tools are registered from allow and deny lists of glob patterns
+// such as "delete_*" or "*_recovery_lock_*". Patterns use path.Match syntax.
+type ToolFilter struct {
+	allowed []string
+	denied  []string
+}
+
+// NewToolFilter creates a filter. An empty allow list allows every tool; the deny list always
+// takes precedence.
+func NewToolFilter(allowed, denied []string) (*ToolFilter, error) {
+	for _, pattern := range append(append([]string(nil), allowed...), denied...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+		}
+	}
+
+	return &ToolFilter{
+		allowed: allowed,
+		denied:  denied,
+	}, nil
+}
+
+// Allows reports whether a tool may be registered, and if not, why
+func (f *ToolFilter) Allows(toolName string) (bool, string) {
+	if pattern, ok := matchToolPattern(f.denied, toolName); ok {
+		return false, fmt.Sprintf("matches denied_tools pattern %q", pattern)
+	}
+
+	if len(f.allowed) > 0 {
+		if _, ok := matchToolPattern(f.allowed, toolName); !ok {
+			return false, "does not match any allowed_tools pattern"
+		}
+	}
+
+	return true, ""
+}
+
+// UnmatchedPatterns returns the patterns that match none of the given tool names, which
+// usually points to a typo in the configuration
+func (f *ToolFilter) UnmatchedPatterns(toolNames []string) []string {
+	var unmatched []string
+	for _, pattern := range append(append([]string(nil), f.allowed...), f.denied...) {
+		matched := false
+		for _, name := range toolNames {
+			if ok, _ := path.Match(pattern, name); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, pattern)
+		}
+	}
+	return unmatched
+}
+
+// matchToolPattern returns the first pattern that matches toolName
+func matchToolPattern(patterns []string, toolName string) (string, bool) {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, toolName); ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
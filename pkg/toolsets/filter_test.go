@@ -0,0 +1,49 @@
+package toolsets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestToolFilter tests allow and deny glob patterns
+func TestToolFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		denied  []string
+		tool    string
+		want    bool
+	}{
+		{name: "no lists", tool: "erase_computer", want: true},
+		{name: "denied glob", denied: []string{"delete_*"}, tool: "delete_policy_by_id", want: false},
+		{name: "denied infix glob", denied: []string{"*_recovery_lock_*"}, tool: "get_computer_recovery_lock_password", want: false},
+		{name: "not denied", denied: []string{"delete_*"}, tool: "get_policy_by_id", want: true},
+		{name: "allowed", allowed: []string{"get_*"}, tool: "get_policy_by_id", want: true},
+		{name: "not allowed", allowed: []string{"get_*"}, tool: "erase_computer", want: false},
+		{name: "deny wins", allowed: []string{"get_*"}, denied: []string{"get_computer_recovery_lock_password"}, tool: "get_computer_recovery_lock_password", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewToolFilter(tt.allowed, tt.denied)
+			require.NoError(t, err)
+
+			allowed, reason := filter.Allows(tt.tool)
+			assert.Equal(t, tt.want, allowed)
+			assert.Equal(t, tt.want, reason == "", reason)
+		})
+	}
+}
+
+// TestToolFilterPatterns tests pattern validation and detection of patterns that match nothing
+func TestToolFilterPatterns(t *testing.T) {
+	_, err := NewToolFilter([]string{"get_["}, nil)
+	assert.Error(t, err)
+
+	filter, err := NewToolFilter([]string{"get_*"}, []string{"delete_*", "erase_computr"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"erase_computr"}, filter.UnmatchedPatterns([]string{"get_policy_by_id", "delete_policy_by_id", "erase_computer"}))
+}
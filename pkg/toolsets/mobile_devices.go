@@ -5,7 +5,7 @@ import (
 	"fmt"
 
 	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
-	"github.com/deploymenttheory/jamfpro-mcp-server/internal/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
 	"go.uber.org/zap"
 )
 
@@ -14,6 +14,17 @@ type MobileDevicesToolset struct {
 	*BaseToolset
 }
 
+func init() {
+	Register(Registration{
+		Name:        "mobile-devices",
+		Description: "iOS and iPadOS devices, groups, apps and profiles",
+		Category:    CategoryDeviceManagement,
+		Constructor: func(client JamfProClient, logger *zap.Logger) Toolset {
+			return NewMobileDevicesToolset(client, logger)
+		},
+	})
+}
+
 // NewMobileDevicesToolset creates a new mobile devices toolset
 func NewMobileDevicesToolset(client JamfProClient, logger *zap.Logger) *MobileDevicesToolset {
 	base := NewBaseToolset(
@@ -34,8 +45,11 @@ func NewMobileDevicesToolset(client JamfProClient, logger *zap.Logger) *MobileDe
 func (m *MobileDevicesToolset) addTools() {
 	// Get Mobile Devices
 	m.AddTool(mcp.Tool{
-		Name:        "get_mobile_devices",
-		Description: "Retrieve a list of all mobile devices from Jamf Pro",
+		Name:         "get_mobile_devices",
+		Description:  "Retrieve a list of all mobile devices from Jamf Pro",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List Mobile Devices"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseMobileDeviceList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
@@ -45,8 +59,11 @@ func (m *MobileDevicesToolset) addTools() {
 
 	// Get Mobile Device by ID
 	m.AddTool(mcp.Tool{
-		Name:        "get_mobile_device_by_id",
-		Description: "Retrieve detailed information about a specific mobile device by its ID",
+		Name:         "get_mobile_device_by_id",
+		Description:  "Retrieve detailed information about a specific mobile device by its ID",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Mobile Device by ID"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceMobileDevice{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -61,8 +78,11 @@ func (m *MobileDevicesToolset) addTools() {
 
 	// Get Mobile Device by Name
 	m.AddTool(mcp.Tool{
-		Name:        "get_mobile_device_by_name",
-		Description: "Retrieve detailed information about a specific mobile device by its name",
+		Name:         "get_mobile_device_by_name",
+		Description:  "Retrieve detailed information about a specific mobile device by its name",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Mobile Device by Name"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceMobileDevice{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -77,8 +97,11 @@ func (m *MobileDevicesToolset) addTools() {
 
 	// Get Mobile Device Groups
 	m.AddTool(mcp.Tool{
-		Name:        "get_mobile_device_groups",
-		Description: "Retrieve a list of all mobile device groups from Jamf Pro",
+		Name:         "get_mobile_device_groups",
+		Description:  "Retrieve a list of all mobile device groups from Jamf Pro",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List Mobile Device Groups"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseMobileDeviceGroupsList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
@@ -88,8 +111,11 @@ func (m *MobileDevicesToolset) addTools() {
 
 	// Get Mobile Device Group by ID
 	m.AddTool(mcp.Tool{
-		Name:        "get_mobile_device_group_by_id",
-		Description: "Retrieve detailed information about a specific mobile device group by its ID",
+		Name:         "get_mobile_device_group_by_id",
+		Description:  "Retrieve detailed information about a specific mobile device group by its ID",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Mobile Device Group by ID"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceMobileDeviceGroup{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -104,8 +130,11 @@ func (m *MobileDevicesToolset) addTools() {
 
 	// Get Mobile Device Applications
 	m.AddTool(mcp.Tool{
-		Name:        "get_mobile_device_applications",
-		Description: "Retrieve a list of all mobile device applications from Jamf Pro",
+		Name:         "get_mobile_device_applications",
+		Description:  "Retrieve a list of all mobile device applications from Jamf Pro",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List Mobile Device Applications"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseMobileDeviceApplicationsList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
@@ -115,8 +144,11 @@ func (m *MobileDevicesToolset) addTools() {
 
 	// Get Mobile Device Configuration Profiles
 	m.AddTool(mcp.Tool{
-		Name:        "get_mobile_device_configuration_profiles",
-		Description: "Retrieve a list of all mobile device configuration profiles from Jamf Pro",
+		Name:         "get_mobile_device_configuration_profiles",
+		Description:  "Retrieve a list of all mobile device configuration profiles from Jamf Pro",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List Mobile Device Configuration Profiles"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseMobileDeviceConfigurationProfilesList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
@@ -128,6 +160,8 @@ func (m *MobileDevicesToolset) addTools() {
 	m.AddTool(mcp.Tool{
 		Name:        "delete_mobile_device",
 		Description: "Delete a mobile device from Jamf Pro by its ID",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Delete Mobile Device", true),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -144,6 +178,8 @@ func (m *MobileDevicesToolset) addTools() {
 	m.AddTool(mcp.Tool{
 		Name:        "create_mobile_device",
 		Description: "Create a new mobile device in Jamf Pro",
+		Access:      mcp.ToolAccessWrite,
+		Annotations: mcp.WriteAnnotations("Create Mobile Device", false),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -261,6 +297,8 @@ func (m *MobileDevicesToolset) addTools() {
 	m.AddTool(mcp.Tool{
 		Name:        "update_mobile_device_by_id",
 		Description: "Update an existing mobile device by its ID",
+		Access:      mcp.ToolAccessWrite,
+		Annotations: mcp.UpdateAnnotations("Update Mobile Device by ID"),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -380,8 +418,11 @@ func (m *MobileDevicesToolset) addTools() {
 
 	// Get Mobile Device Template
 	m.AddTool(mcp.Tool{
-		Name:        "get_mobile_device_template",
-		Description: "Get a reference template for a mobile device resource showing all available fields",
+		Name:         "get_mobile_device_template",
+		Description:  "Get a reference template for a mobile device resource showing all available fields",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Mobile Device Template"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceMobileDevice{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
@@ -390,8 +431,34 @@ func (m *MobileDevicesToolset) addTools() {
 	})
 }
 
+// PreviewTool describes the mobile device targeted by a destructive mobile device tool
+func (m *MobileDevicesToolset) PreviewTool(ctx context.Context, toolName string, arguments map[string]interface{}) (*ActionPreview, error) {
+	if toolName != "delete_mobile_device" {
+		return nil, nil
+	}
+
+	id, err := GetStringArgument(arguments, "id", true)
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := m.GetClient().GetMobileDeviceByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mobile device ID %s: %w", id, err)
+	}
+
+	return &ActionPreview{
+		Action:       toolName,
+		TargetType:   "mobile_device",
+		ID:           id,
+		Name:         device.General.Name,
+		SerialNumber: device.General.SerialNumber,
+		Username:     device.Location.Username,
+	}, nil
+}
+
 func (m *MobileDevicesToolset) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
-	m.GetLogger().Debug("Executing mobile devices tool", zap.String("tool", toolName))
+	m.SessionLogger(ctx).Debug("Executing mobile devices tool", zap.String("tool", toolName))
 
 	switch toolName {
 	case "get_mobile_devices":
@@ -427,7 +494,7 @@ func (m *MobileDevicesToolset) getMobileDevices(ctx context.Context) (string, er
 		return "", fmt.Errorf("failed to get mobile devices: %w", err)
 	}
 
-	return FormatListResponse(devices, "mobile devices")
+	return FormatListResponse(ctx, devices, "mobile devices")
 }
 
 func (m *MobileDevicesToolset) getMobileDeviceByID(ctx context.Context, args map[string]interface{}) (string, error) {
@@ -441,7 +508,7 @@ func (m *MobileDevicesToolset) getMobileDeviceByID(ctx context.Context, args map
 		return "", fmt.Errorf("failed to get mobile device with ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(device)
+	response, err := FormatJSONResponse(ctx, device)
 	if err != nil {
 		return "", err
 	}
@@ -460,7 +527,7 @@ func (m *MobileDevicesToolset) getMobileDeviceByName(ctx context.Context, args m
 		return "", fmt.Errorf("failed to get mobile device with name %s: %w", name, err)
 	}
 
-	response, err := FormatJSONResponse(device)
+	response, err := FormatJSONResponse(ctx, device)
 	if err != nil {
 		return "", err
 	}
@@ -474,7 +541,7 @@ func (m *MobileDevicesToolset) getMobileDeviceGroups(ctx context.Context) (strin
 		return "", fmt.Errorf("failed to get mobile device groups: %w", err)
 	}
 
-	return FormatListResponse(groups, "mobile device groups")
+	return FormatListResponse(ctx, groups, "mobile device groups")
 }
 
 func (m *MobileDevicesToolset) getMobileDeviceGroupByID(ctx context.Context, args map[string]interface{}) (string, error) {
@@ -488,7 +555,7 @@ func (m *MobileDevicesToolset) getMobileDeviceGroupByID(ctx context.Context, arg
 		return "", fmt.Errorf("failed to get mobile device group with ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(group)
+	response, err := FormatJSONResponse(ctx, group)
 	if err != nil {
 		return "", err
 	}
@@ -502,7 +569,7 @@ func (m *MobileDevicesToolset) getMobileDeviceApplications(ctx context.Context)
 		return "", fmt.Errorf("failed to get mobile device applications: %w", err)
 	}
 
-	return FormatListResponse(apps, "mobile device applications")
+	return FormatListResponse(ctx, apps, "mobile device applications")
 }
 
 func (m *MobileDevicesToolset) getMobileDeviceConfigurationProfiles(ctx context.Context) (string, error) {
@@ -511,7 +578,7 @@ func (m *MobileDevicesToolset) getMobileDeviceConfigurationProfiles(ctx context.
 		return "", fmt.Errorf("failed to get mobile device configuration profiles: %w", err)
 	}
 
-	return FormatListResponse(profiles, "mobile device configuration profiles")
+	return FormatListResponse(ctx, profiles, "mobile device configuration profiles")
 }
 
 func (m *MobileDevicesToolset) deleteMobileDevice(ctx context.Context, args map[string]interface{}) (string, error) {
@@ -827,7 +894,7 @@ func (m *MobileDevicesToolset) GetMobileDeviceTemplate() *jamfpro.ResourceMobile
 
 func (m *MobileDevicesToolset) getMobileDeviceTemplate(ctx context.Context) (string, error) {
 	template := m.GetMobileDeviceTemplate()
-	response, err := FormatJSONResponse(template)
+	response, err := FormatJSONResponse(ctx, template)
 	if err != nil {
 		return "", fmt.Errorf("failed to format mobile device template: %w", err)
 	}
@@ -0,0 +1,190 @@
+package toolsets
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// structuredResultKey wraps results that are not JSON objects, since structuredContent must be
+// an object
+const structuredResultKey = "result"
+
+// maxSchemaDepth limits how deeply nested objects are described in an output schema. Deeper
+// objects are described only as objects so that large Jamf Pro responses keep tools/list small.
+const maxSchemaDepth = 1
+
+// StructuredOutput collects the value a tool call renders so that it can be returned as the
+// call's structuredContent alongside the text
+type StructuredOutput struct {
+	mu    sync.Mutex
+	value interface{}
+}
+
+type structuredOutputContextKey struct{}
+
+// WithStructuredOutput returns a context in which FormatJSONResponse, FormatListResponse and
+// FormatSecretResponse record the value they render
+func WithStructuredOutput(ctx context.Context) (context.Context, *StructuredOutput) {
+	output := &StructuredOutput{}
+	return context.WithValue(ctx, structuredOutputContextKey{}, output), output
+}
+
+// Value returns the recorded value, or nil if the call rendered nothing
+func (o *StructuredOutput) Value() interface{} {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.value
+}
+
+// recordStructuredOutput records rendered JSON as the structured result of the call in ctx.
+// The JSON is decoded again so that the structured result is redacted exactly like the text.
+func recordStructuredOutput(ctx context.Context, rendered []byte) {
+	output, ok := ctx.Value(structuredOutputContextKey{}).(*StructuredOutput)
+	if !ok {
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(rendered))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil || value == nil {
+		return
+	}
+	if _, isObject := value.(map[string]interface{}); !isObject {
+		value = map[string]interface{}{structuredResultKey: value}
+	}
+
+	output.mu.Lock()
+	output.value = value
+	output.mu.Unlock()
+}
+
+// OutputSchemaFor returns the JSON schema of the structured result of a tool that renders
+// values of the same type as example
+func OutputSchemaFor(example interface{}) map[string]interface{} {
+	t := reflect.TypeOf(example)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == nil:
+		return map[string]interface{}{"type": "object"}
+	case t.Kind() == reflect.Map:
+		return map[string]interface{}{"type": "object"}
+	case t.Kind() == reflect.Struct && t != timeType:
+		return typeSchema(t, nil, 0)
+	default:
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{structuredResultKey: typeSchema(t, nil, 0)},
+		}
+	}
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// typeSchema describes how encoding/json renders values of type t found at path
+func typeSchema(t reflect.Type, path []string, depth int) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string"}
+	case t == rawMessageType, t.Implements(jsonMarshalerType), reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return map[string]interface{}{}
+	case t.Implements(textMarshalerType), reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]interface{}{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(typeSchema(t.Elem(), path, depth))
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return nullable(map[string]interface{}{"type": "string"})
+		}
+		return nullable(map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), path, depth)})
+	case reflect.Array:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), path, depth)}
+	case reflect.Map:
+		return nullable(map[string]interface{}{"type": "object"})
+	case reflect.Struct:
+		if depth >= maxSchemaDepth {
+			return map[string]interface{}{"type": "object"}
+		}
+		properties := make(map[string]interface{})
+		addStructProperties(t, path, depth, properties)
+		return map[string]interface{}{"type": "object", "properties": properties}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// addStructProperties adds the JSON properties of a struct, inlining embedded structs the way
+// encoding/json does
+func addStructProperties(t reflect.Type, path []string, depth int, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructProperties(embedded, path, depth, properties)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldPath := append(path[:len(path):len(path)], name)
+		switch {
+		case strings.Contains(","+options+",", ",string,"):
+			properties[name] = map[string]interface{}{"type": "string"}
+		default:
+			properties[name] = typeSchema(field.Type, fieldPath, depth+1)
+		}
+	}
+}
+
+// nullable allows null in addition to the schema's type, for pointers, slices and maps
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	if kind, ok := schema["type"].(string); ok {
+		schema["type"] = []string{kind, "null"}
+	}
+	return schema
+}
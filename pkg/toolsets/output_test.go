@@ -0,0 +1,114 @@
+package toolsets
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestFormatJSONResponseRecordsStructuredOutput tests that the rendered value is recorded as
+// the structured result, with non-object values wrapped in an object
+func TestFormatJSONResponseRecordsStructuredOutput(t *testing.T) {
+	ctx, output := WithStructuredOutput(context.Background())
+
+	_, err := FormatJSONResponse(ctx, &jamfpro.ResourceScript{ID: "3", Name: "Cleanup"})
+	require.NoError(t, err)
+
+	value, ok := output.Value().(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "3", value["id"])
+	assert.Equal(t, "Cleanup", value["name"])
+
+	_, err = FormatListResponse(ctx, []string{"a", "b"}, "items")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"result": []interface{}{"a", "b"}}, output.Value())
+
+	// Without a collector the renderers only return text
+	_, err = FormatJSONResponse(context.Background(), map[string]interface{}{"id": "4"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"result": []interface{}{"a", "b"}}, output.Value())
+}
+
+// TestStructuredOutputIsRedacted tests that the structured result masks secrets like the text
+func TestStructuredOutputIsRedacted(t *testing.T) {
+	redaction := defaultRedaction(t, false)
+	ctx, output := WithStructuredOutput(ContextWithRedaction(context.Background(), redaction))
+
+	_, err := FormatJSONResponse(ctx, &jamfpro.FileVaultInventory{ComputerId: "7", PersonalRecoveryKey: "ABCD-EFGH-IJKL"})
+	require.NoError(t, err)
+
+	value, ok := output.Value().(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "[REDACTED]", value["personalRecoveryKey"])
+	assert.Equal(t, "7", value["computerId"])
+
+	// Redacted properties accept the mask in place of their usual type
+	redacting := &redactingToolset{redaction: redaction}
+	schema := redacting.redactOutputSchema(OutputSchemaFor(&jamfpro.FileVaultInventory{}))
+	properties, ok := schema["properties"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, map[string]interface{}{}, properties["personalRecoveryKey"])
+	assert.Equal(t, map[string]interface{}{"type": "string"}, properties["computerId"])
+}
+
+// TestOutputSchemaFor tests the schemas generated for structs, maps and other values
+func TestOutputSchemaFor(t *testing.T) {
+	type nested struct {
+		Name string `json:"name"`
+	}
+	type example struct {
+		ID      int               `json:"id"`
+		Serial  int               `json:"serial,string"`
+		Enabled *bool             `json:"enabled,omitempty"`
+		Tags    []string          `json:"tags"`
+		Extra   map[string]string `json:"extra"`
+		Nested  nested            `json:"nested"`
+		Ignored string            `json:"-"`
+		hidden  string
+	}
+
+	schema := OutputSchemaFor(&example{})
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, map[string]interface{}{
+		"id":      map[string]interface{}{"type": "integer"},
+		"serial":  map[string]interface{}{"type": "string"},
+		"enabled": map[string]interface{}{"type": []string{"boolean", "null"}},
+		"tags":    map[string]interface{}{"type": []string{"array", "null"}, "items": map[string]interface{}{"type": "string"}},
+		"extra":   map[string]interface{}{"type": []string{"object", "null"}},
+		"nested":  map[string]interface{}{"type": "object"},
+	}, schema["properties"])
+
+	assert.Equal(t, map[string]interface{}{"type": "object"}, OutputSchemaFor(map[string]interface{}{}))
+	assert.Equal(t, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"result": map[string]interface{}{"type": []string{"array", "null"}, "items": map[string]interface{}{"type": "string"}},
+		},
+	}, OutputSchemaFor([]string{}))
+
+	_, err := json.Marshal(OutputSchemaFor(&jamfpro.ResourceComputerInventory{}))
+	assert.NoError(t, err)
+}
+
+// TestDestructiveToolsHaveNoOutputSchema tests that tools whose result may be a confirmation
+// preview rather than the resource do not promise a structured result
+func TestDestructiveToolsHaveNoOutputSchema(t *testing.T) {
+	factory := NewFactory(new(MockJamfProClient), zap.NewNop())
+
+	for _, name := range implementedToolsets {
+		toolset, err := factory.CreateToolset(name)
+		require.NoError(t, err, name)
+
+		for _, tool := range toolset.GetTools() {
+			if tool.Access == mcp.ToolAccessDestructive {
+				assert.Nil(t, tool.OutputSchema, tool.Name)
+			}
+		}
+	}
+}
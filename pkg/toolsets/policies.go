@@ -3,9 +3,10 @@ package toolsets
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
-	"github.com/deploymenttheory/jamfpro-mcp-server/internal/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
 	"go.uber.org/zap"
 )
 
@@ -14,6 +15,17 @@ type PoliciesToolset struct {
 	*BaseToolset
 }
 
+func init() {
+	Register(Registration{
+		Name:        "policies",
+		Description: "Policy management and deployment",
+		Category:    CategoryPoliciesConfiguration,
+		Constructor: func(client JamfProClient, logger *zap.Logger) Toolset {
+			return NewPoliciesToolset(client, logger)
+		},
+	})
+}
+
 // NewPoliciesToolset creates a new policies toolset
 func NewPoliciesToolset(client JamfProClient, logger *zap.Logger) *PoliciesToolset {
 	base := NewBaseToolset(
@@ -37,8 +49,11 @@ func NewPoliciesToolset(client JamfProClient, logger *zap.Logger) *PoliciesTools
 func (p *PoliciesToolset) addTools() {
 	// Get Policies List
 	p.AddTool(mcp.Tool{
-		Name:        "get_policies",
-		Description: "Retrieve a list of all policies from Jamf Pro",
+		Name:         "get_policies",
+		Description:  "Retrieve a list of all policies from Jamf Pro",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List Policies"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponsePoliciesList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
@@ -48,8 +63,11 @@ func (p *PoliciesToolset) addTools() {
 
 	// Get Policy by ID
 	p.AddTool(mcp.Tool{
-		Name:        "get_policy_by_id",
-		Description: "Retrieve a policy by its ID",
+		Name:         "get_policy_by_id",
+		Description:  "Retrieve a policy by its ID",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Policy by ID"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourcePolicy{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -64,8 +82,11 @@ func (p *PoliciesToolset) addTools() {
 
 	// Get Policy by Name
 	p.AddTool(mcp.Tool{
-		Name:        "get_policy_by_name",
-		Description: "Retrieve a policy by its name",
+		Name:         "get_policy_by_name",
+		Description:  "Retrieve a policy by its name",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Policy by Name"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourcePolicy{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -80,8 +101,11 @@ func (p *PoliciesToolset) addTools() {
 
 	// Get Policies by Category
 	p.AddTool(mcp.Tool{
-		Name:        "get_policies_by_category",
-		Description: "Retrieve policies by their category",
+		Name:         "get_policies_by_category",
+		Description:  "Retrieve policies by their category",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List Policies by Category"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponsePoliciesList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -96,8 +120,11 @@ func (p *PoliciesToolset) addTools() {
 
 	// Get Policies by Type
 	p.AddTool(mcp.Tool{
-		Name:        "get_policies_by_type",
-		Description: "Retrieve policies by the type of entity that created them (either 'casper' for Casper Remote or 'jss' for GUI/API created policies)",
+		Name:         "get_policies_by_type",
+		Description:  "Retrieve policies by the type of entity that created them (either 'casper' for Casper Remote or 'jss' for GUI/API created policies)",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List Policies by Type"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponsePoliciesList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -113,8 +140,11 @@ func (p *PoliciesToolset) addTools() {
 
 	// Create Policy
 	p.AddTool(mcp.Tool{
-		Name:        "create_policy",
-		Description: "Create a new policy in Jamf Pro with basic configuration",
+		Name:         "create_policy",
+		Description:  "Create a new policy in Jamf Pro with basic configuration",
+		Access:       mcp.ToolAccessWrite,
+		Annotations:  mcp.WriteAnnotations("Create Policy", false),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponsePolicyCreateAndUpdate{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -220,6 +250,8 @@ func (p *PoliciesToolset) addTools() {
 	p.AddTool(mcp.Tool{
 		Name:        "delete_policy_by_id",
 		Description: "Delete a policy from Jamf Pro by its ID",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Delete Policy by ID", true),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -236,6 +268,8 @@ func (p *PoliciesToolset) addTools() {
 	p.AddTool(mcp.Tool{
 		Name:        "delete_policy_by_name",
 		Description: "Delete a policy from Jamf Pro by its name",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Delete Policy by Name", true),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -249,9 +283,45 @@ func (p *PoliciesToolset) addTools() {
 	})
 }
 
+// PreviewTool describes the policy targeted by a destructive policy tool
+func (p *PoliciesToolset) PreviewTool(ctx context.Context, toolName string, arguments map[string]interface{}) (*ActionPreview, error) {
+	var (
+		policy *jamfpro.ResourcePolicy
+		err    error
+	)
+
+	switch toolName {
+	case "delete_policy_by_id":
+		id, argErr := GetStringArgument(arguments, "id", true)
+		if argErr != nil {
+			return nil, argErr
+		}
+		policy, err = p.GetClient().GetPolicyByID(id)
+	case "delete_policy_by_name":
+		name, argErr := GetStringArgument(arguments, "name", true)
+		if argErr != nil {
+			return nil, argErr
+		}
+		policy, err = p.GetClient().GetPolicyByName(name)
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy: %w", err)
+	}
+
+	return &ActionPreview{
+		Action:     toolName,
+		TargetType: "policy",
+		ID:         strconv.Itoa(policy.General.ID),
+		Name:       policy.General.Name,
+	}, nil
+}
+
 // ExecuteTool executes a policy-related tool
 func (p *PoliciesToolset) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
-	p.GetLogger().Debug("Executing policies tool", zap.String("tool", toolName))
+	p.SessionLogger(ctx).Debug("Executing policies tool", zap.String("tool", toolName))
 
 	switch toolName {
 	case "get_policies":
@@ -282,7 +352,7 @@ func (p *PoliciesToolset) getPolicies(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("failed to get policies: %w", err)
 	}
 
-	response, err := FormatJSONResponse(policies)
+	response, err := FormatJSONResponse(ctx, policies)
 	if err != nil {
 		return "", err
 	}
@@ -302,7 +372,7 @@ func (p *PoliciesToolset) getPolicyByID(ctx context.Context, args map[string]int
 		return "", fmt.Errorf("failed to get policy with ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(policy)
+	response, err := FormatJSONResponse(ctx, policy)
 	if err != nil {
 		return "", err
 	}
@@ -322,7 +392,7 @@ func (p *PoliciesToolset) getPolicyByName(ctx context.Context, args map[string]i
 		return "", fmt.Errorf("failed to get policy with name %s: %w", name, err)
 	}
 
-	response, err := FormatJSONResponse(policy)
+	response, err := FormatJSONResponse(ctx, policy)
 	if err != nil {
 		return "", err
 	}
@@ -342,7 +412,7 @@ func (p *PoliciesToolset) getPoliciesByCategory(ctx context.Context, args map[st
 		return "", fmt.Errorf("failed to get policies with category %s: %w", category, err)
 	}
 
-	response, err := FormatJSONResponse(policies)
+	response, err := FormatJSONResponse(ctx, policies)
 	if err != nil {
 		return "", err
 	}
@@ -362,7 +432,7 @@ func (p *PoliciesToolset) getPoliciesByType(ctx context.Context, args map[string
 		return "", fmt.Errorf("failed to get policies created by %s: %w", createdBy, err)
 	}
 
-	response, err := FormatJSONResponse(policies)
+	response, err := FormatJSONResponse(ctx, policies)
 	if err != nil {
 		return "", err
 	}
@@ -501,7 +571,7 @@ func (p *PoliciesToolset) createPolicy(ctx context.Context, args map[string]inte
 		return "", fmt.Errorf("failed to create policy '%s': %w", name, err)
 	}
 
-	response, err := FormatJSONResponse(createdPolicy)
+	response, err := FormatJSONResponse(ctx, createdPolicy)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,75 @@
+package toolsets_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/toolsets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// reportsToolset is a private toolset written the way a team would in its own module, using
+// only the exported toolsets and mcp packages
+type reportsToolset struct {
+	*toolsets.BaseToolset
+}
+
+func newReportsToolset(client toolsets.JamfProClient, logger *zap.Logger) toolsets.Toolset {
+	toolset := &reportsToolset{
+		BaseToolset: toolsets.NewBaseToolset("acme-reports", "Reports specific to Acme", client, logger),
+	}
+	toolset.AddTool(mcp.Tool{
+		Name:        "get_acme_report",
+		Description: "Summarise the Jamf Pro instance",
+		InputSchema: mcp.ToolInputSchema{Type: "object", Properties: map[string]interface{}{}},
+		Access:      mcp.ToolAccessRead,
+		Annotations: mcp.ReadOnlyAnnotations("Get Acme Report"),
+	})
+	return toolset
+}
+
+func (r *reportsToolset) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	switch toolName {
+	case "get_acme_report":
+		info, err := r.GetClient().GetJamfProInformation()
+		if err != nil {
+			return "", err
+		}
+		return toolsets.FormatJSONResponse(ctx, info)
+	default:
+		return "", fmt.Errorf("unknown tool: %s", toolName)
+	}
+}
+
+func init() {
+	toolsets.Register(toolsets.Registration{
+		Name:        "acme-reports",
+		Description: "Reports specific to Acme",
+		Category:    "Acme",
+		Constructor: newReportsToolset,
+	})
+}
+
+// TestPrivateToolsetFromAnotherPackage tests that a toolset registered by another package is
+// validated, expanded, listed and built like a built-in one
+func TestPrivateToolsetFromAnotherPackage(t *testing.T) {
+	require.NoError(t, toolsets.ValidateToolsets([]string{"computers", "acme-reports"}))
+	assert.Contains(t, toolsets.ExpandToolsets([]string{toolsets.AllToolsets}), "acme-reports")
+	assert.True(t, slices.ContainsFunc(toolsets.Registrations(), func(registration toolsets.Registration) bool {
+		return registration.Name == "acme-reports" && registration.Category == "Acme"
+	}))
+
+	client := new(toolsets.MockJamfProClient)
+	toolset, err := toolsets.NewFactory(client, zap.NewNop()).CreateToolset("acme-reports")
+	require.NoError(t, err)
+	require.Len(t, toolset.GetTools(), 1)
+	assert.Equal(t, "acme-reports", toolset.GetTools()[0].Toolset)
+
+	_, err = toolset.ExecuteTool(context.Background(), "get_unknown", nil)
+	assert.EqualError(t, err, "unknown tool: get_unknown")
+}
@@ -0,0 +1,189 @@
+package toolsets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+)
+
+// RevealSecretsArgument is the argument a caller passes to a secret-bearing tool to receive
+// the secret unmasked
+const RevealSecretsArgument = "reveal_secrets"
+
+// Redactor masks secrets in rendered JSON
+type Redactor interface {
+	// RedactJSON returns data with every secret masked
+	RedactJSON(data []byte) ([]byte, error)
+
+	// Matches reports whether the value at a path of object keys is masked
+	Matches(path []string) bool
+}
+
+// Redaction controls how formatted responses are redacted. A nil Redactor turns redaction off.
+// AllowReveal lets tools that accept reveal_secrets return unmasked output.
+type Redaction struct {
+	Redactor    Redactor
+	AllowReveal bool
+}
+
+type redactionContextKey struct{}
+
+// ContextWithRedaction returns a context in which FormatJSONResponse, FormatListResponse and
+// FormatSecretResponse apply redaction. Toolsets created by a Factory with redaction set add
+// it to the context of every call themselves.
+func ContextWithRedaction(ctx context.Context, redaction Redaction) context.Context {
+	return context.WithValue(ctx, redactionContextKey{}, redaction)
+}
+
+// redactionFromContext returns the redaction of ctx, which is off if none was set
+func redactionFromContext(ctx context.Context) Redaction {
+	redaction, _ := ctx.Value(redactionContextKey{}).(Redaction)
+	return redaction
+}
+
+// revealSecretsProperty is the input schema property for RevealSecretsArgument
+func revealSecretsProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"description": "Return the secret unmasked. Only honoured when the server allows secrets to be revealed; otherwise the call fails. Leave unset unless the user explicitly needs the value.",
+	}
+}
+
+// FormatSecretResponse renders a response that holds secrets. The secrets are masked unless
+// the caller set reveal_secrets and the redaction of ctx allows revealing them.
+func FormatSecretResponse(ctx context.Context, data interface{}, args map[string]interface{}) (string, error) {
+	reveal, err := GetBoolArgument(args, RevealSecretsArgument, false)
+	if err != nil {
+		return "", err
+	}
+
+	redaction := redactionFromContext(ctx)
+	if !reveal || redaction.Redactor == nil {
+		return FormatJSONResponse(ctx, data)
+	}
+
+	if !redaction.AllowReveal {
+		return "", fmt.Errorf("revealing secrets is disabled on this server; set allow_secret_reveal to permit it")
+	}
+
+	if data == nil {
+		return "No data returned", nil
+	}
+
+	jsonBytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format response as JSON: %w", err)
+	}
+
+	recordStructuredOutput(ctx, jsonBytes)
+	return string(jsonBytes), nil
+}
+
+// redactResponse masks secrets in formatted JSON according to the redaction of ctx
+func redactResponse(ctx context.Context, jsonBytes []byte) (string, error) {
+	redaction := redactionFromContext(ctx)
+	if redaction.Redactor == nil {
+		return string(jsonBytes), nil
+	}
+
+	redacted, err := redaction.Redactor.RedactJSON(jsonBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to redact response: %w", err)
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, redacted, "", "  "); err != nil {
+		return "", fmt.Errorf("failed to format response as JSON: %w", err)
+	}
+
+	return indented.String(), nil
+}
+
+// redactingToolset applies a factory's redaction to the calls and output schemas of a toolset
+type redactingToolset struct {
+	Toolset
+	redaction Redaction
+}
+
+// GetTools returns the tools of the wrapped toolset with values masked by redaction accepting
+// any type in their output schemas
+func (r *redactingToolset) GetTools() []mcp.Tool {
+	tools := r.Toolset.GetTools()
+	redacted := make([]mcp.Tool, len(tools))
+	for i, tool := range tools {
+		if tool.OutputSchema != nil {
+			tool.OutputSchema = r.redactOutputSchema(tool.OutputSchema)
+		}
+		redacted[i] = tool
+	}
+	return redacted
+}
+
+// ExecuteTool executes a tool of the wrapped toolset with redaction applied to its response
+func (r *redactingToolset) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	return r.Toolset.ExecuteTool(ContextWithRedaction(ctx, r.redaction), toolName, arguments)
+}
+
+// PreviewTool previews a call with the wrapped toolset, if it can
+func (r *redactingToolset) PreviewTool(ctx context.Context, toolName string, arguments map[string]interface{}) (*ActionPreview, error) {
+	previewer, ok := r.Toolset.(Previewer)
+	if !ok {
+		return nil, nil
+	}
+	return previewer.PreviewTool(ContextWithRedaction(ctx, r.redaction), toolName, arguments)
+}
+
+// redactOutputSchema copies an output schema built by OutputSchemaFor. A result wrapped in
+// structuredResultKey is at the root of the rendered JSON, so its path starts empty.
+func (r *redactingToolset) redactOutputSchema(schema map[string]interface{}) map[string]interface{} {
+	properties, _ := schema["properties"].(map[string]interface{})
+	wrapped, isWrapped := properties[structuredResultKey].(map[string]interface{})
+	if !isWrapped || len(properties) != 1 {
+		return r.redactSchema(schema, nil)
+	}
+
+	copied := copySchema(schema)
+	copied["properties"] = map[string]interface{}{structuredResultKey: r.redactSchema(wrapped, nil)}
+	return copied
+}
+
+// redactSchema copies the schema of the value found at path, replacing the schema of every
+// masked property with one that accepts any value
+func (r *redactingToolset) redactSchema(schema map[string]interface{}, path []string) map[string]interface{} {
+	copied := copySchema(schema)
+
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		copied["items"] = r.redactSchema(items, path)
+	}
+
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		redacted := make(map[string]interface{}, len(properties))
+		for name, property := range properties {
+			propertyPath := append(path[:len(path):len(path)], name)
+			propertySchema, isSchema := property.(map[string]interface{})
+			switch {
+			case r.redaction.Redactor.Matches(propertyPath):
+				redacted[name] = map[string]interface{}{}
+			case isSchema:
+				redacted[name] = r.redactSchema(propertySchema, propertyPath)
+			default:
+				redacted[name] = property
+			}
+		}
+		copied["properties"] = redacted
+	}
+
+	return copied
+}
+
+// copySchema returns a shallow copy of schema
+func copySchema(schema map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		copied[key] = value
+	}
+	return copied
+}
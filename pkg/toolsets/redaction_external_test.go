@@ -0,0 +1,97 @@
+package toolsets_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/toolsets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// keyRedactor masks every value stored under one object key, written the way another module
+// would implement toolsets.Redactor
+type keyRedactor struct {
+	key string
+}
+
+var _ toolsets.Redactor = keyRedactor{}
+
+func (r keyRedactor) Matches(path []string) bool {
+	return len(path) > 0 && strings.EqualFold(path[len(path)-1], r.key)
+}
+
+func (r keyRedactor) RedactJSON(data []byte) ([]byte, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(r.redact(value))
+}
+
+func (r keyRedactor) redact(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			if strings.EqualFold(key, r.key) {
+				value[key] = "[MASKED]"
+			} else {
+				value[key] = r.redact(nested)
+			}
+		}
+	case []interface{}:
+		for i, nested := range value {
+			value[i] = r.redact(nested)
+		}
+	}
+	return value
+}
+
+// recoveryLockClient returns one recovery lock password and fails every other call
+type recoveryLockClient struct {
+	toolsets.JamfProClient
+}
+
+func (recoveryLockClient) GetComputerRecoveryLockPasswordByID(id string) (*jamfpro.ResponseRecoveryLockPassword, error) {
+	return &jamfpro.ResponseRecoveryLockPassword{RecoveryLockPassword: "s3cr3t"}, nil
+}
+
+// TestFactoryRedactionFromAnotherPackage tests that another module can build toolsets that
+// mask secrets using only the exported toolsets types
+func TestFactoryRedactionFromAnotherPackage(t *testing.T) {
+	factory := toolsets.NewFactory(recoveryLockClient{}, zap.NewNop())
+	factory.SetRedaction(toolsets.Redaction{Redactor: keyRedactor{key: "recoveryLockPassword"}})
+
+	toolset, err := factory.CreateToolset("computer-inventory")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	result, err := toolset.ExecuteTool(ctx, "get_computer_recovery_lock_password", map[string]interface{}{"id": "7"})
+	require.NoError(t, err)
+	assert.NotContains(t, result, "s3cr3t")
+	assert.Contains(t, result, `"recoveryLockPassword": "[MASKED]"`)
+
+	_, err = toolset.ExecuteTool(ctx, "get_computer_recovery_lock_password", map[string]interface{}{"id": "7", toolsets.RevealSecretsArgument: true})
+	assert.ErrorContains(t, err, "allow_secret_reveal")
+
+	var properties map[string]interface{}
+	for _, tool := range toolset.GetTools() {
+		if tool.Name == "get_computer_recovery_lock_password" {
+			properties, _ = tool.OutputSchema["properties"].(map[string]interface{})
+		}
+	}
+	require.NotNil(t, properties)
+	assert.Equal(t, map[string]interface{}{}, properties["recoveryLockPassword"])
+
+	factory.SetRedaction(toolsets.Redaction{Redactor: keyRedactor{key: "recoveryLockPassword"}, AllowReveal: true})
+	toolset, err = factory.CreateToolset("computer-inventory")
+	require.NoError(t, err)
+
+	result, err = toolset.ExecuteTool(ctx, "get_computer_recovery_lock_password", map[string]interface{}{"id": "7", toolsets.RevealSecretsArgument: true})
+	require.NoError(t, err)
+	assert.Contains(t, result, "s3cr3t")
+}
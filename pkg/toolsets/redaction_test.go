@@ -0,0 +1,102 @@
+package toolsets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/internal/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// defaultRedaction returns redaction with the default paths
+func defaultRedaction(t *testing.T, allowReveal bool) Redaction {
+	t.Helper()
+
+	redactor, err := redact.New(redact.DefaultPaths)
+	require.NoError(t, err)
+	return Redaction{Redactor: redactor, AllowReveal: allowReveal}
+}
+
+// TestFormatJSONResponseRedactsSecrets tests that formatted responses mask secrets
+func TestFormatJSONResponseRedactsSecrets(t *testing.T) {
+	ctx := ContextWithRedaction(context.Background(), defaultRedaction(t, false))
+
+	response, err := FormatJSONResponse(ctx, &jamfpro.FileVaultInventory{
+		ComputerId:          "7",
+		Name:                "Finance-MBP",
+		PersonalRecoveryKey: "ABCD-EFGH-IJKL",
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, response, "ABCD-EFGH-IJKL")
+	assert.Contains(t, response, `"personalRecoveryKey": "[REDACTED]"`)
+	assert.Contains(t, response, `"name": "Finance-MBP"`)
+
+	list, err := FormatListResponse(ctx, []interface{}{map[string]interface{}{"recoveryLockPassword": "s3cr3t"}}, "passwords")
+	require.NoError(t, err)
+	assert.NotContains(t, list, "s3cr3t")
+}
+
+// TestRecoveryLockPasswordRequiresReveal tests that the recovery lock password is only returned
+// when the caller asks for it and the server allows it
+func TestRecoveryLockPasswordRequiresReveal(t *testing.T) {
+	mockObj := createMockComputerInventoryClient()
+	factory := NewFactory(NewComputerInventoryClientAdapter(mockObj), zap.NewNop())
+	mockObj.On("GetComputerRecoveryLockPasswordByID", "7").Return(&jamfpro.ResponseRecoveryLockPassword{
+		RecoveryLockPassword: "s3cr3t",
+	}, nil)
+
+	ctx := context.Background()
+	masked := map[string]interface{}{"id": "7"}
+	revealed := map[string]interface{}{"id": "7", RevealSecretsArgument: true}
+
+	factory.SetRedaction(defaultRedaction(t, false))
+	toolset, err := factory.CreateToolset("computer-inventory")
+	require.NoError(t, err)
+
+	result, err := toolset.ExecuteTool(ctx, "get_computer_recovery_lock_password", masked)
+	require.NoError(t, err)
+	assert.NotContains(t, result, "s3cr3t")
+
+	_, err = toolset.ExecuteTool(ctx, "get_computer_recovery_lock_password", revealed)
+	assert.ErrorContains(t, err, "allow_secret_reveal")
+
+	factory.SetRedaction(defaultRedaction(t, true))
+	toolset, err = factory.CreateToolset("computer-inventory")
+	require.NoError(t, err)
+
+	result, err = toolset.ExecuteTool(ctx, "get_computer_recovery_lock_password", masked)
+	require.NoError(t, err)
+	assert.NotContains(t, result, "s3cr3t")
+
+	result, err = toolset.ExecuteTool(ctx, "get_computer_recovery_lock_password", revealed)
+	require.NoError(t, err)
+	assert.Contains(t, result, "s3cr3t")
+}
+
+// TestFactoryRedactsOutputSchemas tests that the output schemas of toolsets created with
+// redaction accept any value where the response is masked
+func TestFactoryRedactsOutputSchemas(t *testing.T) {
+	factory := NewFactory(new(MockJamfProClient), zap.NewNop())
+	outputSchema := func(toolset Toolset) map[string]interface{} {
+		for _, tool := range toolset.GetTools() {
+			if tool.Name == "get_computer_recovery_lock_password" {
+				return tool.OutputSchema["properties"].(map[string]interface{})["recoveryLockPassword"].(map[string]interface{})
+			}
+		}
+		t.Fatal("get_computer_recovery_lock_password not found")
+		return nil
+	}
+
+	plain, err := factory.CreateToolset("computer-inventory")
+	require.NoError(t, err)
+	assert.Equal(t, "string", outputSchema(plain)["type"])
+
+	factory.SetRedaction(defaultRedaction(t, false))
+	redacted, err := factory.CreateToolset("computer-inventory")
+	require.NoError(t, err)
+	assert.Empty(t, outputSchema(redacted))
+	assert.Equal(t, "string", outputSchema(plain)["type"], "redacting a schema must not change the toolset's own")
+}
@@ -0,0 +1,140 @@
+package toolsets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// AllToolsets is the toolset name that enables every registered toolset
+const AllToolsets = "all"
+
+// Categories group toolsets in help text and in list_available_toolsets
+const (
+	CategoryDeviceManagement      = "Device Management"
+	CategoryPoliciesConfiguration = "Policies & Configuration"
+	CategoryApplicationsSoftware  = "Applications & Software"
+	CategoryOther                 = "Other"
+)
+
+// Constructor builds a toolset on a Jamf Pro client
+type Constructor func(client JamfProClient, logger *zap.Logger) Toolset
+
+// Registration describes a toolset that can be enabled by name
+type Registration struct {
+	// Name is the name used in the toolsets setting, such as "computers"
+	Name string
+
+	// Description is a one-line summary shown in help text
+	Description string
+
+	// Category groups related toolsets; CategoryOther is used when empty
+	Category string
+
+	// Constructor builds the toolset
+	Constructor Constructor
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Registration)
+)
+
+// Register makes a toolset available by name. It is meant to be called from an init function,
+// including in packages of other modules that build the server with cli.Execute, and panics if
+// the registration is incomplete or the name is already taken.
+func Register(registration Registration) {
+	if registration.Name == "" || registration.Name == AllToolsets {
+		panic(fmt.Sprintf("toolsets: invalid toolset name %q", registration.Name))
+	}
+	if registration.Constructor == nil {
+		panic(fmt.Sprintf("toolsets: toolset %s has no constructor", registration.Name))
+	}
+	if registration.Category == "" {
+		registration.Category = CategoryOther
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[registration.Name]; exists {
+		panic(fmt.Sprintf("toolsets: toolset %s is already registered", registration.Name))
+	}
+	registry[registration.Name] = registration
+}
+
+// Lookup returns the registration of a toolset
+func Lookup(name string) (Registration, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	registration, ok := registry[name]
+	return registration, ok
+}
+
+// Registrations returns every registered toolset, sorted by category and then name
+func Registrations() []Registration {
+	registryMu.RLock()
+	registrations := make([]Registration, 0, len(registry))
+	for _, registration := range registry {
+		registrations = append(registrations, registration)
+	}
+	registryMu.RUnlock()
+
+	sort.Slice(registrations, func(i, j int) bool {
+		if registrations[i].Category != registrations[j].Category {
+			return registrations[i].Category < registrations[j].Category
+		}
+		return registrations[i].Name < registrations[j].Name
+	})
+	return registrations
+}
+
+// Names returns the names of every registered toolset in alphabetical order
+func Names() []string {
+	registryMu.RLock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	registryMu.RUnlock()
+
+	sort.Strings(names)
+	return names
+}
+
+// ValidateToolsets checks that every name is a registered toolset or "all"
+func ValidateToolsets(names []string) error {
+	for _, name := range names {
+		if name == AllToolsets {
+			continue
+		}
+		if _, ok := Lookup(name); !ok {
+			return fmt.Errorf("invalid toolset: %s (available: %s, %s)", name, strings.Join(Names(), ", "), AllToolsets)
+		}
+	}
+	return nil
+}
+
+// ExpandToolsets returns the toolsets named by a toolsets setting, replacing "all" with every
+// registered toolset and dropping duplicates
+func ExpandToolsets(names []string) []string {
+	for _, name := range names {
+		if name == AllToolsets {
+			return Names()
+		}
+	}
+
+	seen := make(map[string]bool, len(names))
+	expanded := make([]string, 0, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			expanded = append(expanded, name)
+		}
+	}
+	return expanded
+}
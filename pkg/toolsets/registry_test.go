@@ -0,0 +1,89 @@
+package toolsets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// registryTestToolset is a toolset registered from outside the built-in set
+type registryTestToolset struct {
+	*BaseToolset
+}
+
+func (r *registryTestToolset) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
+	return toolName, nil
+}
+
+// TestBuiltInToolsetsAreRegistered tests that every implemented toolset is registered and that
+// "all" expands to exactly the registered toolsets
+func TestBuiltInToolsetsAreRegistered(t *testing.T) {
+	for _, name := range implementedToolsets {
+		registration, ok := Lookup(name)
+		require.True(t, ok, name)
+		assert.NotEmpty(t, registration.Description, name)
+		assert.NotEmpty(t, registration.Category, name)
+	}
+
+	assert.Equal(t, Names(), ExpandToolsets([]string{"computers", AllToolsets}))
+	assert.Equal(t, []string{"scripts", "computers"}, ExpandToolsets([]string{"scripts", "computers", "scripts"}))
+}
+
+// TestValidateToolsets tests that only registered toolsets and "all" are accepted
+func TestValidateToolsets(t *testing.T) {
+	assert.NoError(t, ValidateToolsets([]string{"computers", "computer-inventory", AllToolsets}))
+
+	err := ValidateToolsets([]string{"computers", "printers"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid toolset: printers")
+}
+
+// TestRegisterCustomToolset tests that a toolset registered by another package can be created
+// through the factory
+func TestRegisterCustomToolset(t *testing.T) {
+	// The registry is global, so register only once when tests run repeatedly
+	if _, registered := Lookup("registry-test"); !registered {
+		registerTestToolset()
+	}
+
+	registration, ok := Lookup("registry-test")
+	require.True(t, ok)
+	assert.Equal(t, CategoryOther, registration.Category)
+	assert.Contains(t, Names(), "registry-test")
+	assert.NoError(t, ValidateToolsets([]string{"registry-test"}))
+
+	toolset, err := NewFactory(new(MockJamfProClient), zap.NewNop()).CreateToolset("registry-test")
+	require.NoError(t, err)
+	require.Len(t, toolset.GetTools(), 1)
+	assert.Equal(t, "registry-test", toolset.GetTools()[0].Toolset)
+
+	// Names are unique and "all" is reserved
+	assert.Panics(t, func() { Register(registration) })
+	assert.Panics(t, func() {
+		Register(Registration{Name: AllToolsets, Constructor: registration.Constructor})
+	})
+}
+
+// TestCreateUnknownToolset tests that the factory refuses unregistered names
+func TestCreateUnknownToolset(t *testing.T) {
+	_, err := NewFactory(new(MockJamfProClient), zap.NewNop()).CreateToolset("printers")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown toolset: printers")
+}
+
+// registerTestToolset registers a toolset the way a package outside this one would
+func registerTestToolset() {
+	Register(Registration{
+		Name:        "registry-test",
+		Description: "Toolset used by the registry tests",
+		Constructor: func(client JamfProClient, logger *zap.Logger) Toolset {
+			toolset := &registryTestToolset{BaseToolset: NewBaseToolset("registry-test", "Registry test", client, logger)}
+			toolset.AddTool(mcp.Tool{Name: "get_registry_test", Access: mcp.ToolAccessRead})
+			return toolset
+		},
+	})
+}
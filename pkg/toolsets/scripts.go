@@ -7,7 +7,7 @@ import (
 	"strconv"
 
 	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
-	"github.com/deploymenttheory/jamfpro-mcp-server/internal/mcp"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
 	"go.uber.org/zap"
 )
 
@@ -16,6 +16,17 @@ type ScriptsToolset struct {
 	*BaseToolset
 }
 
+func init() {
+	Register(Registration{
+		Name:        "scripts",
+		Description: "Script management",
+		Category:    CategoryApplicationsSoftware,
+		Constructor: func(client JamfProClient, logger *zap.Logger) Toolset {
+			return NewScriptsToolset(client, logger)
+		},
+	})
+}
+
 // NewScriptsToolset creates a new scripts toolset
 func NewScriptsToolset(client JamfProClient, logger *zap.Logger) *ScriptsToolset {
 	base := NewBaseToolset(
@@ -38,8 +49,11 @@ func NewScriptsToolset(client JamfProClient, logger *zap.Logger) *ScriptsToolset
 func (s *ScriptsToolset) addTools() {
 	// Get Scripts List
 	s.AddTool(mcp.Tool{
-		Name:        "get_scripts",
-		Description: "Retrieve a list of all scripts from Jamf Pro with optional pagination, sorting, and filtering",
+		Name:         "get_scripts",
+		Description:  "Retrieve a list of all scripts from Jamf Pro with optional pagination, sorting, and filtering",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("List Scripts"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseScriptsList{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -69,8 +83,11 @@ func (s *ScriptsToolset) addTools() {
 
 	// Get Script by ID
 	s.AddTool(mcp.Tool{
-		Name:        "get_script_by_id",
-		Description: "Retrieve detailed information about a specific script by its ID",
+		Name:         "get_script_by_id",
+		Description:  "Retrieve detailed information about a specific script by its ID",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Script by ID"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceScript{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -85,8 +102,11 @@ func (s *ScriptsToolset) addTools() {
 
 	// Get Script by Name
 	s.AddTool(mcp.Tool{
-		Name:        "get_script_by_name",
-		Description: "Retrieve detailed information about a specific script by its name",
+		Name:         "get_script_by_name",
+		Description:  "Retrieve detailed information about a specific script by its name",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Script by Name"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceScript{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -101,8 +121,11 @@ func (s *ScriptsToolset) addTools() {
 
 	// Create Script
 	s.AddTool(mcp.Tool{
-		Name:        "create_script",
-		Description: "Create a new script in Jamf Pro with script contents and configuration",
+		Name:         "create_script",
+		Description:  "Create a new script in Jamf Pro with script contents and configuration",
+		Access:       mcp.ToolAccessWrite,
+		Annotations:  mcp.WriteAnnotations("Create Script", false),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResponseScriptCreate{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -178,8 +201,11 @@ func (s *ScriptsToolset) addTools() {
 
 	// Update Script by ID
 	s.AddTool(mcp.Tool{
-		Name:        "update_script_by_id",
-		Description: "Update an existing script by its ID. Only specified fields will be updated.",
+		Name:         "update_script_by_id",
+		Description:  "Update an existing script by its ID. Only specified fields will be updated.",
+		Access:       mcp.ToolAccessWrite,
+		Annotations:  mcp.UpdateAnnotations("Update Script by ID"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceScript{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -259,8 +285,11 @@ func (s *ScriptsToolset) addTools() {
 
 	// Update Script by Name
 	s.AddTool(mcp.Tool{
-		Name:        "update_script_by_name",
-		Description: "Update an existing script by its name. Only specified fields will be updated.",
+		Name:         "update_script_by_name",
+		Description:  "Update an existing script by its name. Only specified fields will be updated.",
+		Access:       mcp.ToolAccessWrite,
+		Annotations:  mcp.UpdateAnnotations("Update Script by Name"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceScript{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -342,6 +371,8 @@ func (s *ScriptsToolset) addTools() {
 	s.AddTool(mcp.Tool{
 		Name:        "delete_script_by_id",
 		Description: "Delete a script from Jamf Pro by its ID",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Delete Script by ID", true),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -358,6 +389,8 @@ func (s *ScriptsToolset) addTools() {
 	s.AddTool(mcp.Tool{
 		Name:        "delete_script_by_name",
 		Description: "Delete a script from Jamf Pro by its name",
+		Access:      mcp.ToolAccessDestructive,
+		Annotations: mcp.DestructiveAnnotations("Delete Script by Name", true),
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -372,8 +405,11 @@ func (s *ScriptsToolset) addTools() {
 
 	// Get Script Template
 	s.AddTool(mcp.Tool{
-		Name:        "get_script_template",
-		Description: "Get a reference template for a script resource showing all available fields",
+		Name:         "get_script_template",
+		Description:  "Get a reference template for a script resource showing all available fields",
+		Access:       mcp.ToolAccessRead,
+		Annotations:  mcp.ReadOnlyAnnotations("Get Script Template"),
+		OutputSchema: OutputSchemaFor(&jamfpro.ResourceScript{}),
 		InputSchema: mcp.ToolInputSchema{
 			Type:       "object",
 			Properties: map[string]interface{}{},
@@ -382,9 +418,45 @@ func (s *ScriptsToolset) addTools() {
 	})
 }
 
+// PreviewTool describes the script targeted by a destructive script tool
+func (s *ScriptsToolset) PreviewTool(ctx context.Context, toolName string, arguments map[string]interface{}) (*ActionPreview, error) {
+	var (
+		script *jamfpro.ResourceScript
+		err    error
+	)
+
+	switch toolName {
+	case "delete_script_by_id":
+		id, argErr := GetStringArgument(arguments, "id", true)
+		if argErr != nil {
+			return nil, argErr
+		}
+		script, err = s.GetClient().GetScriptByID(id)
+	case "delete_script_by_name":
+		name, argErr := GetStringArgument(arguments, "name", true)
+		if argErr != nil {
+			return nil, argErr
+		}
+		script, err = s.GetClient().GetScriptByName(name)
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get script: %w", err)
+	}
+
+	return &ActionPreview{
+		Action:     toolName,
+		TargetType: "script",
+		ID:         script.ID,
+		Name:       script.Name,
+	}, nil
+}
+
 // ExecuteTool executes a script-related tool
 func (s *ScriptsToolset) ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error) {
-	s.GetLogger().Debug("Executing scripts tool", zap.String("tool", toolName))
+	s.SessionLogger(ctx).Debug("Executing scripts tool", zap.String("tool", toolName))
 
 	switch toolName {
 	case "get_scripts":
@@ -438,7 +510,7 @@ func (s *ScriptsToolset) getScripts(ctx context.Context, args map[string]interfa
 		return "", fmt.Errorf("failed to get scripts: %w", err)
 	}
 
-	response, err := FormatJSONResponse(scripts)
+	response, err := FormatJSONResponse(ctx, scripts)
 	if err != nil {
 		return "", err
 	}
@@ -457,7 +529,7 @@ func (s *ScriptsToolset) getScriptByID(ctx context.Context, args map[string]inte
 		return "", fmt.Errorf("failed to get script with ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(script)
+	response, err := FormatJSONResponse(ctx, script)
 	if err != nil {
 		return "", err
 	}
@@ -476,7 +548,7 @@ func (s *ScriptsToolset) getScriptByName(ctx context.Context, args map[string]in
 		return "", fmt.Errorf("failed to get script with name %s: %w", name, err)
 	}
 
-	response, err := FormatJSONResponse(script)
+	response, err := FormatJSONResponse(ctx, script)
 	if err != nil {
 		return "", err
 	}
@@ -552,7 +624,7 @@ func (s *ScriptsToolset) createScript(ctx context.Context, args map[string]inter
 		return "", fmt.Errorf("failed to create script '%s': %w", name, err)
 	}
 
-	response, err := FormatJSONResponse(result)
+	response, err := FormatJSONResponse(ctx, result)
 	if err != nil {
 		return "", err
 	}
@@ -624,7 +696,7 @@ func (s *ScriptsToolset) updateScriptByID(ctx context.Context, args map[string]i
 		return "", fmt.Errorf("failed to update script with ID %s: %w", id, err)
 	}
 
-	response, err := FormatJSONResponse(result)
+	response, err := FormatJSONResponse(ctx, result)
 	if err != nil {
 		return "", err
 	}
@@ -696,7 +768,7 @@ func (s *ScriptsToolset) updateScriptByName(ctx context.Context, args map[string
 		return "", fmt.Errorf("failed to update script with name %s: %w", name, err)
 	}
 
-	response, err := FormatJSONResponse(result)
+	response, err := FormatJSONResponse(ctx, result)
 	if err != nil {
 		return "", err
 	}
@@ -755,7 +827,7 @@ func (s *ScriptsToolset) GetScriptTemplate() *jamfpro.ResourceScript {
 
 func (s *ScriptsToolset) getScriptTemplate(ctx context.Context) (string, error) {
 	template := s.GetScriptTemplate()
-	response, err := FormatJSONResponse(template)
+	response, err := FormatJSONResponse(ctx, template)
 	if err != nil {
 		return "", fmt.Errorf("failed to format script template: %w", err)
 	}
@@ -0,0 +1,693 @@
+package toolsets
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+)
+
+// Site identifies the Jamf Pro site a scoped client is restricted to, either by ID or by name
+type Site struct {
+	ID   string
+	Name string
+}
+
+// ParseSite interprets a configured site: a number is taken as a site ID, anything else as a name
+func ParseSite(value string) Site {
+	value = strings.TrimSpace(value)
+	if _, err := strconv.Atoi(value); err == nil {
+		return Site{ID: value}
+	}
+	return Site{Name: value}
+}
+
+// String returns the site for use in messages
+func (s Site) String() string {
+	if s.ID != "" {
+		return "ID " + s.ID
+	}
+	return s.Name
+}
+
+// matches reports whether an object's site is this site
+func (s Site) matches(id, name string) bool {
+	if s.ID != "" {
+		return id == s.ID
+	}
+	return name != "" && strings.EqualFold(name, s.Name)
+}
+
+// matchesClassic reports whether a Classic API site reference is this site
+func (s Site) matchesClassic(site *jamfpro.SharedResourceSite) bool {
+	if site == nil {
+		return false
+	}
+	return s.matches(strconv.Itoa(site.ID), site.Name)
+}
+
+// matchesPro reports whether a Jamf Pro API site reference is this site
+func (s Site) matchesPro(site jamfpro.SharedResourceSiteProAPI) bool {
+	return s.matches(site.ID, site.Name)
+}
+
+// inventoryFilter returns an RSQL filter that matches Jamf Pro API inventory records in the site
+func (s Site) inventoryFilter() string {
+	if s.ID != "" {
+		return fmt.Sprintf(`general.site.id=="%s"`, s.ID)
+	}
+	return fmt.Sprintf(`general.site.name=="%s"`, strings.ReplaceAll(s.Name, `"`, `\"`))
+}
+
+// classic returns the site as a Classic API site reference
+func (s Site) classic() jamfpro.SharedResourceSite {
+	id, _ := strconv.Atoi(s.ID)
+	return jamfpro.SharedResourceSite{ID: id, Name: s.Name}
+}
+
+// SiteScopedClient wraps a JamfProClient so that it only sees and changes objects in one site.
+// List calls are filtered to the site, calls on a single object fail if the object is in
+// another site, and created or updated objects are placed in the site, failing if they name a
+// different one.
+//
+// Computer lists are filtered with an inventory query on the site. The Classic API group and
+// policy lists do not include the site of each object, so filtering them fetches every listed
+// object individually; a lookup that fails for any reason other than the site fails the list.
+// Objects whose site the SDK does not expose, such as mobile devices, and global objects such
+// as scripts cannot be scoped and are refused.
+//
+// Every JamfProClient method is implemented here rather than inherited, so a method added to
+// the interface cannot reach Jamf Pro unscoped.
+type SiteScopedClient struct {
+	client JamfProClient
+	site   Site
+}
+
+var _ JamfProClient = (*SiteScopedClient)(nil)
+
+// NewSiteScopedClient restricts client to site
+func NewSiteScopedClient(client JamfProClient, site Site) *SiteScopedClient {
+	return &SiteScopedClient{
+		client: client,
+		site:   site,
+	}
+}
+
+// outsideSiteError is the error returned for objects in another site
+type outsideSiteError struct {
+	kind string
+	ref  string
+	site Site
+}
+
+func (e *outsideSiteError) Error() string {
+	return fmt.Sprintf("%s %s is not in site %s", e.kind, e.ref, e.site)
+}
+
+// outsideSite is the error returned for objects in another site
+func (c *SiteScopedClient) outsideSite(kind, ref string) error {
+	return &outsideSiteError{kind: kind, ref: ref, site: c.site}
+}
+
+// inSite interprets the error of a scoped lookup made to filter a list: the object is kept
+// when the lookup succeeds and left out when it is in another site. Any other error is
+// returned, so that a failed lookup fails the list rather than silently shortening it.
+func inSite(err error) (bool, error) {
+	var outside *outsideSiteError
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &outside):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// siteComputerIDs returns the IDs of the computers in the site with one inventory query
+func (c *SiteScopedClient) siteComputerIDs() (map[string]bool, error) {
+	inventory, err := c.GetComputersInventory(url.Values{"section": {"GENERAL"}})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool, len(inventory.Results))
+	for _, record := range inventory.Results {
+		ids[record.ID] = true
+	}
+	return ids, nil
+}
+
+// GetJamfProInformation returns information about the Jamf Pro server, which is not site-specific
+func (c *SiteScopedClient) GetJamfProInformation() (*jamfpro.ResponseJamfProInformation, error) {
+	return c.client.GetJamfProInformation()
+}
+
+// unscopable is the error returned for calls that cannot be limited to a site
+func (c *SiteScopedClient) unscopable(kind string) error {
+	return fmt.Errorf("%s cannot be restricted to a site and are unavailable while the server is scoped to site %s", kind, c.site)
+}
+
+// placeClassic puts a new or updated Classic API object in the site, refusing other sites
+func (c *SiteScopedClient) placeClassic(kind string, site *jamfpro.SharedResourceSite) error {
+	if site.ID == 0 && site.Name == "" {
+		*site = c.site.classic()
+		return nil
+	}
+	if !c.site.matchesClassic(site) {
+		return fmt.Errorf("%s cannot be placed in site %q; this server is scoped to site %s", kind, site.Name, c.site)
+	}
+	return nil
+}
+
+// Computers (Classic API)
+
+// GetComputers returns the computers in the site
+func (c *SiteScopedClient) GetComputers() (*jamfpro.ResponseComputersList, error) {
+	list, err := c.client.GetComputers()
+	if err != nil {
+		return nil, err
+	}
+
+	// Classic API computer IDs are the inventory IDs of the Jamf Pro API
+	ids, err := c.siteComputerIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]jamfpro.ComputersListItem, 0, len(list.Results))
+	for _, item := range list.Results {
+		if ids[strconv.Itoa(item.ID)] {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return &jamfpro.ResponseComputersList{TotalCount: len(filtered), Results: filtered}, nil
+}
+
+// GetComputerByID returns a computer if it is in the site
+func (c *SiteScopedClient) GetComputerByID(id string) (*jamfpro.ResponseComputer, error) {
+	computer, err := c.client.GetComputerByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !c.site.matchesClassic(&computer.General.Site) {
+		return nil, c.outsideSite("computer", id)
+	}
+	return computer, nil
+}
+
+// GetComputerByName returns a computer if it is in the site
+func (c *SiteScopedClient) GetComputerByName(name string) (*jamfpro.ResponseComputer, error) {
+	computer, err := c.client.GetComputerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if !c.site.matchesClassic(&computer.General.Site) {
+		return nil, c.outsideSite("computer", name)
+	}
+	return computer, nil
+}
+
+// CreateComputer creates a computer in the site
+func (c *SiteScopedClient) CreateComputer(computer jamfpro.ResponseComputer) (*jamfpro.ResponseComputer, error) {
+	if err := c.placeClassic("computer", &computer.General.Site); err != nil {
+		return nil, err
+	}
+	return c.client.CreateComputer(computer)
+}
+
+// UpdateComputerByID updates a computer in the site
+func (c *SiteScopedClient) UpdateComputerByID(id string, computer jamfpro.ResponseComputer) (*jamfpro.ResponseComputer, error) {
+	if _, err := c.GetComputerByID(id); err != nil {
+		return nil, err
+	}
+	if err := c.placeClassic("computer", &computer.General.Site); err != nil {
+		return nil, err
+	}
+	return c.client.UpdateComputerByID(id, computer)
+}
+
+// UpdateComputerByName updates a computer in the site
+func (c *SiteScopedClient) UpdateComputerByName(name string, computer jamfpro.ResponseComputer) (*jamfpro.ResponseComputer, error) {
+	if _, err := c.GetComputerByName(name); err != nil {
+		return nil, err
+	}
+	if err := c.placeClassic("computer", &computer.General.Site); err != nil {
+		return nil, err
+	}
+	return c.client.UpdateComputerByName(name, computer)
+}
+
+// DeleteComputerByID deletes a computer in the site
+func (c *SiteScopedClient) DeleteComputerByID(id string) error {
+	if _, err := c.GetComputerByID(id); err != nil {
+		return err
+	}
+	return c.client.DeleteComputerByID(id)
+}
+
+// DeleteComputerByName deletes a computer in the site
+func (c *SiteScopedClient) DeleteComputerByName(name string) error {
+	if _, err := c.GetComputerByName(name); err != nil {
+		return err
+	}
+	return c.client.DeleteComputerByName(name)
+}
+
+// GetComputerGroups returns the computer groups in the site
+func (c *SiteScopedClient) GetComputerGroups() (*jamfpro.ResponseComputerGroupsList, error) {
+	list, err := c.client.GetComputerGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]jamfpro.ComputerGroupListItem, 0, len(list.Results))
+	for _, item := range list.Results {
+		_, err := c.GetComputerGroupByID(strconv.Itoa(item.ID))
+		keep, err := inSite(err)
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return &jamfpro.ResponseComputerGroupsList{Size: len(filtered), Results: filtered}, nil
+}
+
+// GetComputerGroupByID returns a computer group if it is in the site
+func (c *SiteScopedClient) GetComputerGroupByID(id string) (*jamfpro.ResourceComputerGroup, error) {
+	group, err := c.client.GetComputerGroupByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !c.site.matchesClassic(group.Site) {
+		return nil, c.outsideSite("computer group", id)
+	}
+	return group, nil
+}
+
+// Computer inventory (Jamf Pro API)
+
+// GetComputersInventory returns the inventory records in the site. Jamf Pro is asked to filter
+// by site, and the GENERAL section is always requested so that the site of every returned
+// record is checked again here.
+func (c *SiteScopedClient) GetComputersInventory(params url.Values) (*jamfpro.ResponseComputerInventoryList, error) {
+	scoped := url.Values{}
+	for key, values := range params {
+		scoped[key] = append([]string(nil), values...)
+	}
+	if sections := scoped.Get("section"); sections != "" && !containsFold(strings.Split(sections, ","), "GENERAL") {
+		scoped.Set("section", sections+",GENERAL")
+	}
+	if filter := scoped.Get("filter"); filter != "" {
+		scoped.Set("filter", "("+filter+");"+c.site.inventoryFilter())
+	} else {
+		scoped.Set("filter", c.site.inventoryFilter())
+	}
+
+	list, err := c.client.GetComputersInventory(scoped)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]jamfpro.ResourceComputerInventory, 0, len(list.Results))
+	for _, inventory := range list.Results {
+		if c.site.matchesPro(inventory.General.Site) {
+			filtered = append(filtered, inventory)
+		}
+	}
+
+	total := list.TotalCount - (len(list.Results) - len(filtered))
+	return &jamfpro.ResponseComputerInventoryList{TotalCount: total, Results: filtered}, nil
+}
+
+// GetComputerInventoryByID returns an inventory record if the computer is in the site
+func (c *SiteScopedClient) GetComputerInventoryByID(id string) (*jamfpro.ResourceComputerInventory, error) {
+	inventory, err := c.client.GetComputerInventoryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !c.site.matchesPro(inventory.General.Site) {
+		return nil, c.outsideSite("computer", id)
+	}
+	return inventory, nil
+}
+
+// GetComputerInventoryByName returns an inventory record if the computer is in the site
+func (c *SiteScopedClient) GetComputerInventoryByName(name string) (*jamfpro.ResourceComputerInventory, error) {
+	inventory, err := c.client.GetComputerInventoryByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if !c.site.matchesPro(inventory.General.Site) {
+		return nil, c.outsideSite("computer", name)
+	}
+	return inventory, nil
+}
+
+// UpdateComputerInventoryByID updates an inventory record in the site
+func (c *SiteScopedClient) UpdateComputerInventoryByID(id string, inventory *jamfpro.ResourceComputerInventory) (*jamfpro.ResourceComputerInventory, error) {
+	if _, err := c.GetComputerInventoryByID(id); err != nil {
+		return nil, err
+	}
+	if site := inventory.General.Site; (site.ID != "" || site.Name != "") && !c.site.matchesPro(site) {
+		return nil, fmt.Errorf("computer cannot be moved to site %q; this server is scoped to site %s", site.Name, c.site)
+	}
+	return c.client.UpdateComputerInventoryByID(id, inventory)
+}
+
+// DeleteComputerInventoryByID deletes a computer in the site
+func (c *SiteScopedClient) DeleteComputerInventoryByID(id string) error {
+	if _, err := c.GetComputerInventoryByID(id); err != nil {
+		return err
+	}
+	return c.client.DeleteComputerInventoryByID(id)
+}
+
+// GetComputersFileVaultInventory returns the FileVault records of computers in the site
+func (c *SiteScopedClient) GetComputersFileVaultInventory(params url.Values) (*jamfpro.FileVaultInventoryList, error) {
+	list, err := c.client.GetComputersFileVaultInventory(params)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := c.siteComputerIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]jamfpro.FileVaultInventory, 0, len(list.Results))
+	for _, inventory := range list.Results {
+		if ids[inventory.ComputerId] {
+			filtered = append(filtered, inventory)
+		}
+	}
+
+	total := list.TotalCount - (len(list.Results) - len(filtered))
+	return &jamfpro.FileVaultInventoryList{TotalCount: total, Results: filtered}, nil
+}
+
+// GetComputerFileVaultInventoryByID returns the FileVault record of a computer in the site
+func (c *SiteScopedClient) GetComputerFileVaultInventoryByID(id string) (*jamfpro.FileVaultInventory, error) {
+	if _, err := c.GetComputerInventoryByID(id); err != nil {
+		return nil, err
+	}
+	return c.client.GetComputerFileVaultInventoryByID(id)
+}
+
+// GetComputerRecoveryLockPasswordByID returns the recovery lock password of a computer in the site
+func (c *SiteScopedClient) GetComputerRecoveryLockPasswordByID(id string) (*jamfpro.ResponseRecoveryLockPassword, error) {
+	if _, err := c.GetComputerInventoryByID(id); err != nil {
+		return nil, err
+	}
+	return c.client.GetComputerRecoveryLockPasswordByID(id)
+}
+
+// RemoveComputerMDMProfile unenrolls a computer in the site
+func (c *SiteScopedClient) RemoveComputerMDMProfile(id string) (*jamfpro.ResponseRemoveMDMProfile, error) {
+	if _, err := c.GetComputerInventoryByID(id); err != nil {
+		return nil, err
+	}
+	return c.client.RemoveComputerMDMProfile(id)
+}
+
+// EraseComputerByID erases a computer in the site
+func (c *SiteScopedClient) EraseComputerByID(id string, request jamfpro.RequestEraseDeviceComputer) error {
+	if _, err := c.GetComputerInventoryByID(id); err != nil {
+		return err
+	}
+	return c.client.EraseComputerByID(id, request)
+}
+
+// UploadAttachmentAndAssignToComputerByID attaches files to a computer in the site
+func (c *SiteScopedClient) UploadAttachmentAndAssignToComputerByID(computerID string, filePaths []string) (*jamfpro.ResponseUploadAttachment, error) {
+	if _, err := c.GetComputerInventoryByID(computerID); err != nil {
+		return nil, err
+	}
+	return c.client.UploadAttachmentAndAssignToComputerByID(computerID, filePaths)
+}
+
+// DeleteAttachmentByIDAndComputerID removes an attachment from a computer in the site
+func (c *SiteScopedClient) DeleteAttachmentByIDAndComputerID(computerID, attachmentID string) error {
+	if _, err := c.GetComputerInventoryByID(computerID); err != nil {
+		return err
+	}
+	return c.client.DeleteAttachmentByIDAndComputerID(computerID, attachmentID)
+}
+
+// Mobile devices (Classic API). The SDK's mobile device record has no site, so these fail closed.
+
+// GetMobileDevices is refused because mobile devices cannot be scoped
+func (c *SiteScopedClient) GetMobileDevices() (*jamfpro.ResponseMobileDeviceList, error) {
+	return nil, c.unscopable("mobile devices")
+}
+
+// GetMobileDeviceByID is refused because mobile devices cannot be scoped
+func (c *SiteScopedClient) GetMobileDeviceByID(id string) (*jamfpro.ResourceMobileDevice, error) {
+	return nil, c.unscopable("mobile devices")
+}
+
+// GetMobileDeviceByName is refused because mobile devices cannot be scoped
+func (c *SiteScopedClient) GetMobileDeviceByName(name string) (*jamfpro.ResourceMobileDevice, error) {
+	return nil, c.unscopable("mobile devices")
+}
+
+// CreateMobileDevice is refused because the new device cannot be placed in the site
+func (c *SiteScopedClient) CreateMobileDevice(device *jamfpro.ResourceMobileDevice) (*jamfpro.ResourceMobileDevice, error) {
+	return nil, c.unscopable("mobile devices")
+}
+
+// UpdateMobileDeviceByID is refused because mobile devices cannot be scoped
+func (c *SiteScopedClient) UpdateMobileDeviceByID(id string, device *jamfpro.ResourceMobileDevice) (*jamfpro.ResourceMobileDevice, error) {
+	return nil, c.unscopable("mobile devices")
+}
+
+// DeleteMobileDeviceByID is refused because mobile devices cannot be scoped
+func (c *SiteScopedClient) DeleteMobileDeviceByID(id string) error {
+	return c.unscopable("mobile devices")
+}
+
+// GetMobileDeviceApplications is refused because the list cannot be filtered by site
+func (c *SiteScopedClient) GetMobileDeviceApplications() (*jamfpro.ResponseMobileDeviceApplicationsList, error) {
+	return nil, c.unscopable("mobile device applications")
+}
+
+// GetMobileDeviceConfigurationProfiles is refused because the list cannot be filtered by site
+func (c *SiteScopedClient) GetMobileDeviceConfigurationProfiles() (*jamfpro.ResponseMobileDeviceConfigurationProfilesList, error) {
+	return nil, c.unscopable("mobile device configuration profiles")
+}
+
+// GetMobileDeviceGroups returns the mobile device groups in the site
+func (c *SiteScopedClient) GetMobileDeviceGroups() (*jamfpro.ResponseMobileDeviceGroupsList, error) {
+	list, err := c.client.GetMobileDeviceGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]jamfpro.MobileDeviceGroupsListItem, 0, len(list.MobileDeviceGroup))
+	for _, item := range list.MobileDeviceGroup {
+		_, err := c.GetMobileDeviceGroupByID(strconv.Itoa(item.ID))
+		keep, err := inSite(err)
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			filtered = append(filtered, item)
+		}
+	}
+
+	return &jamfpro.ResponseMobileDeviceGroupsList{Size: len(filtered), MobileDeviceGroup: filtered}, nil
+}
+
+// GetMobileDeviceGroupByID returns a mobile device group if it is in the site
+func (c *SiteScopedClient) GetMobileDeviceGroupByID(id string) (*jamfpro.ResourceMobileDeviceGroup, error) {
+	group, err := c.client.GetMobileDeviceGroupByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !c.site.matchesClassic(&group.Site) {
+		return nil, c.outsideSite("mobile device group", id)
+	}
+	return group, nil
+}
+
+// Policies (Classic API)
+
+// filterPolicies keeps the listed policies that are in the site
+func (c *SiteScopedClient) filterPolicies(list *jamfpro.ResponsePoliciesList) (*jamfpro.ResponsePoliciesList, error) {
+	filtered := make([]jamfpro.ResponsePolicyListItem, 0, len(list.Policy))
+	for _, item := range list.Policy {
+		_, err := c.GetPolicyByID(strconv.Itoa(item.ID))
+		keep, err := inSite(err)
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			filtered = append(filtered, item)
+		}
+	}
+	return &jamfpro.ResponsePoliciesList{Size: len(filtered), Policy: filtered}, nil
+}
+
+// GetPolicies returns the policies in the site
+func (c *SiteScopedClient) GetPolicies() (*jamfpro.ResponsePoliciesList, error) {
+	list, err := c.client.GetPolicies()
+	if err != nil {
+		return nil, err
+	}
+	return c.filterPolicies(list)
+}
+
+// GetPolicyByCategory returns the policies in a category and the site
+func (c *SiteScopedClient) GetPolicyByCategory(category string) (*jamfpro.ResponsePoliciesList, error) {
+	list, err := c.client.GetPolicyByCategory(category)
+	if err != nil {
+		return nil, err
+	}
+	return c.filterPolicies(list)
+}
+
+// GetPoliciesByType returns the policies of a type in the site
+func (c *SiteScopedClient) GetPoliciesByType(createdBy string) (*jamfpro.ResponsePoliciesList, error) {
+	list, err := c.client.GetPoliciesByType(createdBy)
+	if err != nil {
+		return nil, err
+	}
+	return c.filterPolicies(list)
+}
+
+// GetPolicyByID returns a policy if it is in the site
+func (c *SiteScopedClient) GetPolicyByID(id string) (*jamfpro.ResourcePolicy, error) {
+	policy, err := c.client.GetPolicyByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !c.site.matchesClassic(policy.General.Site) {
+		return nil, c.outsideSite("policy", id)
+	}
+	return policy, nil
+}
+
+// GetPolicyByName returns a policy if it is in the site
+func (c *SiteScopedClient) GetPolicyByName(name string) (*jamfpro.ResourcePolicy, error) {
+	policy, err := c.client.GetPolicyByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if !c.site.matchesClassic(policy.General.Site) {
+		return nil, c.outsideSite("policy", name)
+	}
+	return policy, nil
+}
+
+// placePolicy puts a new or updated policy in the site
+func (c *SiteScopedClient) placePolicy(policy *jamfpro.ResourcePolicy) error {
+	if policy.General.Site == nil {
+		policy.General.Site = &jamfpro.SharedResourceSite{}
+	}
+	return c.placeClassic("policy", policy.General.Site)
+}
+
+// CreatePolicy creates a policy in the site
+func (c *SiteScopedClient) CreatePolicy(policy *jamfpro.ResourcePolicy) (*jamfpro.ResponsePolicyCreateAndUpdate, error) {
+	if err := c.placePolicy(policy); err != nil {
+		return nil, err
+	}
+	return c.client.CreatePolicy(policy)
+}
+
+// UpdatePolicyByID updates a policy in the site
+func (c *SiteScopedClient) UpdatePolicyByID(id string, policy *jamfpro.ResourcePolicy) (*jamfpro.ResponsePolicyCreateAndUpdate, error) {
+	if _, err := c.GetPolicyByID(id); err != nil {
+		return nil, err
+	}
+	if err := c.placePolicy(policy); err != nil {
+		return nil, err
+	}
+	return c.client.UpdatePolicyByID(id, policy)
+}
+
+// UpdatePolicyByName updates a policy in the site
+func (c *SiteScopedClient) UpdatePolicyByName(name string, policy *jamfpro.ResourcePolicy) (*jamfpro.ResponsePolicyCreateAndUpdate, error) {
+	if _, err := c.GetPolicyByName(name); err != nil {
+		return nil, err
+	}
+	if err := c.placePolicy(policy); err != nil {
+		return nil, err
+	}
+	return c.client.UpdatePolicyByName(name, policy)
+}
+
+// DeletePolicyByID deletes a policy in the site
+func (c *SiteScopedClient) DeletePolicyByID(id string) error {
+	if _, err := c.GetPolicyByID(id); err != nil {
+		return err
+	}
+	return c.client.DeletePolicyByID(id)
+}
+
+// DeletePolicyByName deletes a policy in the site
+func (c *SiteScopedClient) DeletePolicyByName(name string) error {
+	if _, err := c.GetPolicyByName(name); err != nil {
+		return err
+	}
+	return c.client.DeletePolicyByName(name)
+}
+
+// Scripts (Jamf Pro API). Scripts are shared by every site, so they can be read but not changed.
+
+// GetScripts returns the scripts, which every site shares
+func (c *SiteScopedClient) GetScripts(params url.Values) (*jamfpro.ResponseScriptsList, error) {
+	return c.client.GetScripts(params)
+}
+
+// GetScriptByID returns a script, which every site shares
+func (c *SiteScopedClient) GetScriptByID(id string) (*jamfpro.ResourceScript, error) {
+	return c.client.GetScriptByID(id)
+}
+
+// GetScriptByName returns a script, which every site shares
+func (c *SiteScopedClient) GetScriptByName(name string) (*jamfpro.ResourceScript, error) {
+	return c.client.GetScriptByName(name)
+}
+
+// CreateScript is refused because scripts are not site-specific
+func (c *SiteScopedClient) CreateScript(script *jamfpro.ResourceScript) (*jamfpro.ResponseScriptCreate, error) {
+	return nil, c.unscopable("changes to scripts")
+}
+
+// UpdateScriptByID is refused because scripts are not site-specific
+func (c *SiteScopedClient) UpdateScriptByID(id string, script *jamfpro.ResourceScript) (*jamfpro.ResourceScript, error) {
+	return nil, c.unscopable("changes to scripts")
+}
+
+// UpdateScriptByName is refused because scripts are not site-specific
+func (c *SiteScopedClient) UpdateScriptByName(name string, script *jamfpro.ResourceScript) (*jamfpro.ResourceScript, error) {
+	return nil, c.unscopable("changes to scripts")
+}
+
+// DeleteScriptByID is refused because scripts are not site-specific
+func (c *SiteScopedClient) DeleteScriptByID(id string) error {
+	return c.unscopable("changes to scripts")
+}
+
+// DeleteScriptByName is refused because scripts are not site-specific
+func (c *SiteScopedClient) DeleteScriptByName(name string) error {
+	return c.unscopable("changes to scripts")
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
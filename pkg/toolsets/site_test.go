@@ -0,0 +1,202 @@
+package toolsets
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// siteTestClient is an in-memory Jamf Pro client with objects in two sites. Methods it does
+// not override panic through the nil embedded interface.
+type siteTestClient struct {
+	JamfProClient
+	computerSites map[int]jamfpro.SharedResourceSite
+	policySites   map[int]*jamfpro.SharedResourceSite
+	policyErrors  map[int]error
+	filters       []string
+	created       *jamfpro.ResourcePolicy
+	deleted       []string
+}
+
+// newSiteTestClient creates a client with computers and policies 1 in site London and 2 in site Paris
+func newSiteTestClient() *siteTestClient {
+	london := jamfpro.SharedResourceSite{ID: 1, Name: "London"}
+	paris := jamfpro.SharedResourceSite{ID: 2, Name: "Paris"}
+	return &siteTestClient{
+		computerSites: map[int]jamfpro.SharedResourceSite{1: london, 2: paris},
+		policySites:   map[int]*jamfpro.SharedResourceSite{1: &london, 2: &paris, 3: nil},
+	}
+}
+
+func (c *siteTestClient) GetComputers() (*jamfpro.ResponseComputersList, error) {
+	return &jamfpro.ResponseComputersList{TotalCount: 2, Results: []jamfpro.ComputersListItem{{ID: 1, Name: "mac-1"}, {ID: 2, Name: "mac-2"}}}, nil
+}
+
+func (c *siteTestClient) GetComputerByID(id string) (*jamfpro.ResponseComputer, error) {
+	n, _ := strconv.Atoi(id)
+	computer := &jamfpro.ResponseComputer{}
+	computer.General.ID = n
+	computer.General.Site = c.computerSites[n]
+	return computer, nil
+}
+
+func (c *siteTestClient) GetComputersInventory(params url.Values) (*jamfpro.ResponseComputerInventoryList, error) {
+	c.filters = append(c.filters, params.Get("filter"))
+	var results []jamfpro.ResourceComputerInventory
+	for _, id := range []string{"1", "2"} {
+		inventory, _ := c.GetComputerInventoryByID(id)
+		results = append(results, *inventory)
+	}
+	return &jamfpro.ResponseComputerInventoryList{TotalCount: len(results), Results: results}, nil
+}
+
+func (c *siteTestClient) GetComputerInventoryByID(id string) (*jamfpro.ResourceComputerInventory, error) {
+	n, _ := strconv.Atoi(id)
+	site := c.computerSites[n]
+	inventory := &jamfpro.ResourceComputerInventory{ID: id}
+	inventory.General.Site = jamfpro.SharedResourceSiteProAPI{ID: strconv.Itoa(site.ID), Name: site.Name}
+	return inventory, nil
+}
+
+func (c *siteTestClient) DeleteComputerInventoryByID(id string) error {
+	c.deleted = append(c.deleted, id)
+	return nil
+}
+
+func (c *siteTestClient) GetPolicies() (*jamfpro.ResponsePoliciesList, error) {
+	return &jamfpro.ResponsePoliciesList{Size: 3, Policy: []jamfpro.ResponsePolicyListItem{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}}}, nil
+}
+
+func (c *siteTestClient) GetPolicyByID(id string) (*jamfpro.ResourcePolicy, error) {
+	n, _ := strconv.Atoi(id)
+	if err := c.policyErrors[n]; err != nil {
+		return nil, err
+	}
+	return &jamfpro.ResourcePolicy{General: jamfpro.PolicySubsetGeneral{ID: n, Site: c.policySites[n]}}, nil
+}
+
+func (c *siteTestClient) CreatePolicy(policy *jamfpro.ResourcePolicy) (*jamfpro.ResponsePolicyCreateAndUpdate, error) {
+	c.created = policy
+	return &jamfpro.ResponsePolicyCreateAndUpdate{ID: 10}, nil
+}
+
+// TestParseSite tests that numeric sites are IDs and anything else is a name
+func TestParseSite(t *testing.T) {
+	assert.Equal(t, Site{ID: "12"}, ParseSite(" 12 "))
+	assert.Equal(t, Site{Name: "London"}, ParseSite("London"))
+	assert.Equal(t, "ID 12", ParseSite("12").String())
+}
+
+// TestSiteScopedClientFiltersLists tests that list calls only return objects in the site
+func TestSiteScopedClientFiltersLists(t *testing.T) {
+	client := NewSiteScopedClient(newSiteTestClient(), ParseSite("london"))
+
+	computers, err := client.GetComputers()
+	require.NoError(t, err)
+	assert.Equal(t, []jamfpro.ComputersListItem{{ID: 1, Name: "mac-1"}}, computers.Results)
+	assert.Equal(t, 1, computers.TotalCount)
+
+	inventory, err := client.GetComputersInventory(url.Values{})
+	require.NoError(t, err)
+	require.Len(t, inventory.Results, 1)
+	assert.Equal(t, "1", inventory.Results[0].ID)
+
+	// Policies without a site are not in any site
+	policies, err := client.GetPolicies()
+	require.NoError(t, err)
+	assert.Equal(t, []jamfpro.ResponsePolicyListItem{{ID: 1, Name: "a"}}, policies.Policy)
+}
+
+// TestSiteScopedClientFiltersInventoryQueries tests that Jamf Pro is asked for the inventory
+// of the site alone, and that computer lists are filtered with that query
+func TestSiteScopedClientFiltersInventoryQueries(t *testing.T) {
+	inner := newSiteTestClient()
+
+	_, err := NewSiteScopedClient(inner, ParseSite("1")).GetComputersInventory(url.Values{"filter": {`general.name=="mac-1"`}})
+	require.NoError(t, err)
+	_, err = NewSiteScopedClient(inner, ParseSite("London")).GetComputers()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`(general.name=="mac-1");general.site.id=="1"`,
+		`general.site.name=="London"`,
+	}, inner.filters)
+}
+
+// TestSiteScopedClientFailsListsOnLookupErrors tests that a lookup failing for a reason other
+// than the site fails the list instead of leaving the object out
+func TestSiteScopedClientFailsListsOnLookupErrors(t *testing.T) {
+	inner := newSiteTestClient()
+	inner.policyErrors = map[int]error{2: errors.New("503 Service Unavailable")}
+	client := NewSiteScopedClient(inner, ParseSite("London"))
+
+	_, err := client.GetPolicies()
+	assert.ErrorContains(t, err, "503 Service Unavailable")
+}
+
+// TestSiteScopedClientRefusesOtherSites tests that objects in other sites can be neither read nor deleted
+func TestSiteScopedClientRefusesOtherSites(t *testing.T) {
+	inner := newSiteTestClient()
+	client := NewSiteScopedClient(inner, ParseSite("1"))
+
+	_, err := client.GetComputerByID("2")
+	assert.ErrorContains(t, err, "computer 2 is not in site ID 1")
+
+	_, err = client.GetComputerRecoveryLockPasswordByID("2")
+	assert.Error(t, err)
+
+	assert.Error(t, client.DeleteComputerInventoryByID("2"))
+	assert.NoError(t, client.DeleteComputerInventoryByID("1"))
+	assert.Equal(t, []string{"1"}, inner.deleted)
+}
+
+// TestSiteScopedClientPlacesNewObjects tests that created objects are put in the site unless they name another
+func TestSiteScopedClientPlacesNewObjects(t *testing.T) {
+	inner := newSiteTestClient()
+	client := NewSiteScopedClient(inner, ParseSite("2"))
+
+	_, err := client.CreatePolicy(&jamfpro.ResourcePolicy{General: jamfpro.PolicySubsetGeneral{Name: "new"}})
+	require.NoError(t, err)
+	require.NotNil(t, inner.created.General.Site)
+	assert.Equal(t, 2, inner.created.General.Site.ID)
+
+	inner.created = nil
+	_, err = client.CreatePolicy(&jamfpro.ResourcePolicy{General: jamfpro.PolicySubsetGeneral{
+		Name: "elsewhere",
+		Site: &jamfpro.SharedResourceSite{ID: 1, Name: "London"},
+	}})
+	assert.ErrorContains(t, err, `cannot be placed in site "London"`)
+	assert.Nil(t, inner.created)
+}
+
+// TestSiteScopedClientRefusesMobileDevices tests that mobile devices, which carry no site, fail closed
+func TestSiteScopedClientRefusesMobileDevices(t *testing.T) {
+	client := NewSiteScopedClient(newSiteTestClient(), ParseSite("London"))
+
+	_, err := client.GetMobileDevices()
+	assert.ErrorContains(t, err, "cannot be restricted to a site")
+
+	_, err = client.CreateMobileDevice(&jamfpro.ResourceMobileDevice{})
+	assert.Error(t, err)
+}
+
+// TestSiteScopedToolset tests that toolsets built on a scoped client only see the site
+func TestSiteScopedToolset(t *testing.T) {
+	client := NewSiteScopedClient(newSiteTestClient(), ParseSite("Paris"))
+	toolset := NewPoliciesToolset(client, zap.NewNop())
+
+	result, err := toolset.ExecuteTool(context.Background(), "get_policies", map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Contains(t, result, `"b"`)
+	assert.NotContains(t, result, `"a"`)
+
+	_, err = toolset.ExecuteTool(context.Background(), "get_policy_by_id", map[string]interface{}{"id": "1"})
+	assert.ErrorContains(t, err, "policy 1 is not in site Paris")
+}
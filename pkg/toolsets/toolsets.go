@@ -0,0 +1,345 @@
+package toolsets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/deploymenttheory/go-api-sdk-jamfpro/sdk/jamfpro"
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"go.uber.org/zap"
+)
+
+// JamfProClient defines the interface for Jamf Pro API client
+// This allows for dependency injection and easier testing
+type JamfProClient interface {
+	// Common methods
+	GetJamfProInformation() (*jamfpro.ResponseJamfProInformation, error)
+
+	// Computer methods (Classic API)
+	GetComputers() (*jamfpro.ResponseComputersList, error)
+	GetComputerByID(id string) (*jamfpro.ResponseComputer, error)
+	GetComputerByName(name string) (*jamfpro.ResponseComputer, error)
+	GetComputerGroups() (*jamfpro.ResponseComputerGroupsList, error)
+	GetComputerGroupByID(id string) (*jamfpro.ResourceComputerGroup, error)
+	CreateComputer(computer jamfpro.ResponseComputer) (*jamfpro.ResponseComputer, error)
+	UpdateComputerByID(id string, computer jamfpro.ResponseComputer) (*jamfpro.ResponseComputer, error)
+	UpdateComputerByName(name string, computer jamfpro.ResponseComputer) (*jamfpro.ResponseComputer, error)
+	DeleteComputerByID(id string) error
+	DeleteComputerByName(name string) error
+
+	// Computer Inventory methods (Pro API)
+	GetComputersInventory(params url.Values) (*jamfpro.ResponseComputerInventoryList, error)
+	GetComputerInventoryByID(id string) (*jamfpro.ResourceComputerInventory, error)
+	GetComputerInventoryByName(name string) (*jamfpro.ResourceComputerInventory, error)
+	UpdateComputerInventoryByID(id string, inventory *jamfpro.ResourceComputerInventory) (*jamfpro.ResourceComputerInventory, error)
+	DeleteComputerInventoryByID(id string) error
+
+	// FileVault methods
+	GetComputersFileVaultInventory(params url.Values) (*jamfpro.FileVaultInventoryList, error)
+	GetComputerFileVaultInventoryByID(id string) (*jamfpro.FileVaultInventory, error)
+	GetComputerRecoveryLockPasswordByID(id string) (*jamfpro.ResponseRecoveryLockPassword, error)
+
+	// Device management methods
+	RemoveComputerMDMProfile(id string) (*jamfpro.ResponseRemoveMDMProfile, error)
+	EraseComputerByID(id string, request jamfpro.RequestEraseDeviceComputer) error
+
+	// Attachment methods
+	UploadAttachmentAndAssignToComputerByID(computerID string, filePaths []string) (*jamfpro.ResponseUploadAttachment, error)
+	DeleteAttachmentByIDAndComputerID(computerID, attachmentID string) error
+
+	// Mobile Device methods (Classic API)
+	GetMobileDevices() (*jamfpro.ResponseMobileDeviceList, error)
+	GetMobileDeviceByID(id string) (*jamfpro.ResourceMobileDevice, error)
+	GetMobileDeviceByName(name string) (*jamfpro.ResourceMobileDevice, error)
+	GetMobileDeviceGroups() (*jamfpro.ResponseMobileDeviceGroupsList, error)
+	GetMobileDeviceGroupByID(id string) (*jamfpro.ResourceMobileDeviceGroup, error)
+	GetMobileDeviceApplications() (*jamfpro.ResponseMobileDeviceApplicationsList, error)
+	GetMobileDeviceConfigurationProfiles() (*jamfpro.ResponseMobileDeviceConfigurationProfilesList, error)
+	CreateMobileDevice(device *jamfpro.ResourceMobileDevice) (*jamfpro.ResourceMobileDevice, error)
+	UpdateMobileDeviceByID(id string, device *jamfpro.ResourceMobileDevice) (*jamfpro.ResourceMobileDevice, error)
+	DeleteMobileDeviceByID(id string) error
+
+	// Policies methods (Classic API) - FIXED: Corrected return types
+	GetPolicies() (*jamfpro.ResponsePoliciesList, error)
+	GetPolicyByID(id string) (*jamfpro.ResourcePolicy, error)
+	GetPolicyByName(name string) (*jamfpro.ResourcePolicy, error)
+	GetPolicyByCategory(category string) (*jamfpro.ResponsePoliciesList, error)
+	GetPoliciesByType(createdBy string) (*jamfpro.ResponsePoliciesList, error)
+	CreatePolicy(policy *jamfpro.ResourcePolicy) (*jamfpro.ResponsePolicyCreateAndUpdate, error)
+	UpdatePolicyByID(id string, policy *jamfpro.ResourcePolicy) (*jamfpro.ResponsePolicyCreateAndUpdate, error)
+	UpdatePolicyByName(name string, policy *jamfpro.ResourcePolicy) (*jamfpro.ResponsePolicyCreateAndUpdate, error)
+	DeletePolicyByID(id string) error
+	DeletePolicyByName(name string) error
+
+	// Scripts methods (Pro API)
+	GetScripts(params url.Values) (*jamfpro.ResponseScriptsList, error)
+	GetScriptByID(id string) (*jamfpro.ResourceScript, error)
+	GetScriptByName(name string) (*jamfpro.ResourceScript, error)
+	CreateScript(script *jamfpro.ResourceScript) (*jamfpro.ResponseScriptCreate, error)
+	UpdateScriptByID(id string, script *jamfpro.ResourceScript) (*jamfpro.ResourceScript, error)
+	UpdateScriptByName(name string, script *jamfpro.ResourceScript) (*jamfpro.ResourceScript, error)
+	DeleteScriptByID(id string) error
+	DeleteScriptByName(name string) error
+}
+
+// Toolset represents a collection of related tools
+type Toolset interface {
+	// GetName returns the name of the toolset
+	GetName() string
+
+	// GetDescription returns the description of the toolset
+	GetDescription() string
+
+	// GetTools returns the list of tools provided by this toolset
+	GetTools() []mcp.Tool
+
+	// ExecuteTool executes a specific tool with the given arguments
+	ExecuteTool(ctx context.Context, toolName string, arguments map[string]interface{}) (string, error)
+}
+
+// BaseToolset provides common functionality for all toolsets
+type BaseToolset struct {
+	name        string
+	description string
+	client      JamfProClient
+	logger      *zap.Logger
+	tools       map[string]mcp.Tool
+	toolOrder   []string // Tool names in the order they were added
+}
+
+// NewBaseToolset creates a new base toolset
+func NewBaseToolset(name, description string, client JamfProClient, logger *zap.Logger) *BaseToolset {
+	return &BaseToolset{
+		name:        name,
+		description: description,
+		client:      client,
+		logger:      logger,
+		tools:       make(map[string]mcp.Tool),
+	}
+}
+
+// GetName returns the name of the toolset
+func (b *BaseToolset) GetName() string {
+	return b.name
+}
+
+// GetDescription returns the description of the toolset
+func (b *BaseToolset) GetDescription() string {
+	return b.description
+}
+
+// GetTools returns the tools in the order the toolset added them
+func (b *BaseToolset) GetTools() []mcp.Tool {
+	tools := make([]mcp.Tool, 0, len(b.toolOrder))
+	for _, name := range b.toolOrder {
+		tools = append(tools, b.tools[name])
+	}
+	return tools
+}
+
+// AddTool adds a tool to the toolset. Adding a tool with the name of an existing one replaces
+// it in place.
+func (b *BaseToolset) AddTool(tool mcp.Tool) {
+	tool.Toolset = b.name
+	if _, exists := b.tools[tool.Name]; !exists {
+		b.toolOrder = append(b.toolOrder, tool.Name)
+	}
+	b.tools[tool.Name] = tool
+}
+
+// GetClient returns the Jamf Pro client
+func (b *BaseToolset) GetClient() JamfProClient {
+	return b.client
+}
+
+// GetLogger returns the logger
+func (b *BaseToolset) GetLogger() *zap.Logger {
+	return b.logger
+}
+
+// SessionLogger returns the logger tied to the session of ctx, so that log messages about a
+// tool call are sent to the client that made it and to no other
+func (b *BaseToolset) SessionLogger(ctx context.Context) *zap.Logger {
+	return b.logger.With(mcp.SessionField(ctx))
+}
+
+// Factory creates toolsets
+type Factory struct {
+	client    JamfProClient
+	logger    *zap.Logger
+	redaction Redaction
+}
+
+// NewFactory creates a new toolset factory
+func NewFactory(client JamfProClient, logger *zap.Logger) *Factory {
+	return &Factory{
+		client: client,
+		logger: logger,
+	}
+}
+
+// SetRedaction masks secrets in the responses of the toolsets the factory creates from now on
+func (f *Factory) SetRedaction(redaction Redaction) {
+	f.redaction = redaction
+}
+
+// CreateToolset creates a registered toolset by name. The toolset logs under its own name,
+// which clients see as the logger of its log messages.
+func (f *Factory) CreateToolset(name string) (Toolset, error) {
+	registration, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown toolset: %s", name)
+	}
+	toolset := registration.Constructor(f.client, f.logger.Named(name))
+	if f.redaction.Redactor == nil {
+		return toolset, nil
+	}
+	return &redactingToolset{Toolset: toolset, redaction: f.redaction}, nil
+}
+
+// Helper functions for common argument validation and conversion
+
+// GetStringArgument safely gets a string argument
+func GetStringArgument(args map[string]interface{}, key string, required bool) (string, error) {
+	value, exists := args[key]
+	if !exists {
+		if required {
+			return "", fmt.Errorf("required argument %s is missing", key)
+		}
+		return "", nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("argument %s must be a string", key)
+	}
+
+	if required && str == "" {
+		return "", fmt.Errorf("required argument %s cannot be empty", key)
+	}
+
+	return str, nil
+}
+
+// GetIntArgument safely gets an integer argument
+func GetIntArgument(args map[string]interface{}, key string, required bool) (int, error) {
+	value, exists := args[key]
+	if !exists {
+		if required {
+			return 0, fmt.Errorf("required argument %s is missing", key)
+		}
+		return 0, nil
+	}
+
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		return int(v), nil
+	case string:
+		// Try to parse string as int - FIXED: Don't allow string conversion for int args
+		if v == "" && !required {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("argument %s must be a number, got string: %s", key, v)
+	default:
+		return 0, fmt.Errorf("argument %s must be a number", key)
+	}
+}
+
+// GetBoolArgument safely gets a boolean argument
+func GetBoolArgument(args map[string]interface{}, key string, required bool) (bool, error) {
+	value, exists := args[key]
+	if !exists {
+		if required {
+			return false, fmt.Errorf("required argument %s is missing", key)
+		}
+		return false, nil
+	}
+
+	boolean, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("argument %s must be a boolean", key)
+	}
+
+	return boolean, nil
+}
+
+// GetStringSliceArgument safely gets a string slice argument
+func GetStringSliceArgument(args map[string]interface{}, key string, required bool) ([]string, error) {
+	value, exists := args[key]
+	if !exists {
+		if required {
+			return nil, fmt.Errorf("required argument %s is missing", key)
+		}
+		return []string{}, nil
+	}
+
+	slice, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("argument %s must be an array", key)
+	}
+
+	result := make([]string, len(slice))
+	for i, item := range slice {
+		str, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument %s must be an array of strings", key)
+		}
+		result[i] = str
+	}
+
+	return result, nil
+}
+
+// FormatJSONResponse renders a response as pretty-printed JSON, masked by the redaction of ctx. If ctx collects structured
+// output, the rendered value is also recorded as the call's structured result.
+func FormatJSONResponse(ctx context.Context, data interface{}) (string, error) {
+	if data == nil {
+		return "No data returned", nil
+	}
+
+	jsonBytes, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format response as JSON: %w", err)
+	}
+
+	response, err := redactResponse(ctx, jsonBytes)
+	if err != nil {
+		return "", err
+	}
+
+	recordStructuredOutput(ctx, []byte(response))
+	return response, nil
+}
+
+// FormatListResponse renders a list response with count information, recording the list as
+// the structured result like FormatJSONResponse - FIXED: Better type handling
+func FormatListResponse(ctx context.Context, items interface{}, itemType string) (string, error) {
+	jsonBytes, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format response as JSON: %w", err)
+	}
+
+	response, err := redactResponse(ctx, jsonBytes)
+	if err != nil {
+		return "", err
+	}
+	recordStructuredOutput(ctx, []byte(response))
+
+	// Try to get count from different response types
+	count := "unknown"
+
+	// Handle different response types that might have count fields
+	if respWithCount, ok := items.(interface{ GetSize() int }); ok {
+		count = fmt.Sprintf("%d", respWithCount.GetSize())
+	} else if respWithTotal, ok := items.(interface{ GetTotalCount() int }); ok {
+		count = fmt.Sprintf("%d", respWithTotal.GetTotalCount())
+	} else if slice, ok := items.([]interface{}); ok {
+		count = fmt.Sprintf("%d", len(slice))
+	}
+
+	return fmt.Sprintf("Found %s %s:\n\n%s", count, itemType, response), nil
+}
@@ -0,0 +1,102 @@
+package toolsets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/deploymenttheory/jamfpro-mcp-server/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// implementedToolsets lists every toolset the factory can build
+var implementedToolsets = []string{
+	"computers", "computer-inventory", "mobile-devices", "policies", "scripts",
+}
+
+// TestToolAccessClassification tests that every tool is classified and that the
+// classification matches what the tool does
+func TestToolAccessClassification(t *testing.T) {
+	factory := NewFactory(new(MockJamfProClient), zap.NewNop())
+
+	for _, name := range implementedToolsets {
+		toolset, err := factory.CreateToolset(name)
+		require.NoError(t, err, name)
+
+		for _, tool := range toolset.GetTools() {
+			switch {
+			case strings.HasPrefix(tool.Name, "get_"):
+				assert.Equal(t, mcp.ToolAccessRead, tool.Access, tool.Name)
+			case strings.HasPrefix(tool.Name, "create_"), strings.HasPrefix(tool.Name, "update_"), strings.HasPrefix(tool.Name, "upload_"):
+				assert.Equal(t, mcp.ToolAccessWrite, tool.Access, tool.Name)
+			case strings.HasPrefix(tool.Name, "delete_"), tool.Name == "erase_computer", tool.Name == "remove_computer_mdm_profile":
+				assert.Equal(t, mcp.ToolAccessDestructive, tool.Access, tool.Name)
+			default:
+				assert.NotEmpty(t, tool.Access, "tool %s is not classified", tool.Name)
+			}
+		}
+	}
+}
+
+// TestToolsRecordTheirToolset tests that every tool is tagged with the toolset it belongs to
+func TestToolsRecordTheirToolset(t *testing.T) {
+	factory := NewFactory(new(MockJamfProClient), zap.NewNop())
+
+	for _, name := range implementedToolsets {
+		toolset, err := factory.CreateToolset(name)
+		require.NoError(t, err, name)
+
+		for _, tool := range toolset.GetTools() {
+			assert.Equal(t, name, tool.Toolset, tool.Name)
+		}
+	}
+}
+
+// TestToolAnnotations tests that every tool is annotated and that the hints match the tool's
+// access classification
+func TestToolAnnotations(t *testing.T) {
+	factory := NewFactory(new(MockJamfProClient), zap.NewNop())
+
+	for _, name := range implementedToolsets {
+		toolset, err := factory.CreateToolset(name)
+		require.NoError(t, err, name)
+
+		for _, tool := range toolset.GetTools() {
+			annotations := tool.Annotations
+			if !assert.NotNil(t, annotations, "tool %s has no annotations", tool.Name) {
+				continue
+			}
+			assert.NotEmpty(t, annotations.Title, tool.Name)
+			if assert.NotNil(t, annotations.ReadOnlyHint, tool.Name) {
+				assert.Equal(t, tool.Access == mcp.ToolAccessRead, *annotations.ReadOnlyHint, tool.Name)
+			}
+			if tool.Access == mcp.ToolAccessRead {
+				continue
+			}
+			// In-place updates overwrite existing values, so they are destructive too
+			if assert.NotNil(t, annotations.DestructiveHint, tool.Name) {
+				destructive := tool.Access == mcp.ToolAccessDestructive || strings.HasPrefix(tool.Name, "update_")
+				assert.Equal(t, destructive, *annotations.DestructiveHint, tool.Name)
+			}
+			assert.NotNil(t, annotations.IdempotentHint, tool.Name)
+		}
+	}
+}
+
+// TestGetToolsKeepsToolsetOrder tests that tools are returned in the order the toolset added
+// them and that replacing a tool keeps its position
+func TestGetToolsKeepsToolsetOrder(t *testing.T) {
+	base := NewBaseToolset("example", "Example toolset", nil, zap.NewNop())
+	for _, name := range []string{"get_things", "get_thing_by_id", "create_thing", "delete_thing"} {
+		base.AddTool(mcp.Tool{Name: name})
+	}
+	base.AddTool(mcp.Tool{Name: "get_thing_by_id", Description: "Replaced"})
+
+	var names []string
+	for _, tool := range base.GetTools() {
+		names = append(names, tool.Name)
+	}
+	assert.Equal(t, []string{"get_things", "get_thing_by_id", "create_thing", "delete_thing"}, names)
+	assert.Equal(t, "Replaced", base.GetTools()[1].Description)
+}